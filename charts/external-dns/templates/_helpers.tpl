@@ -103,3 +103,21 @@ labelSelector:
   matchLabels:
     {{ include "external-dns.selectorLabels" . | nindent 4 }}
 {{- end }}
+
+{{/*
+Return "true" if the given flag is enabled in extraArgs, which is either a map of flags to values or a list of arguments.
+*/}}
+{{- define "external-dns.hasExtraArg" -}}
+{{- $flag := .flag }}
+{{- if kindIs "map" .args }}
+{{- if and (hasKey .args $flag) (ne (toString (index .args $flag)) "false") }}true{{ end }}
+{{- else if kindIs "slice" .args }}
+{{- $set := false }}
+{{- range .args }}
+{{- if or (eq (toString .) (printf "--%s" $flag)) (and (hasPrefix (printf "--%s=" $flag) (toString .)) (ne (toString .) (printf "--%s=false" $flag))) }}
+{{- $set = true }}
+{{- end }}
+{{- end }}
+{{- if $set }}true{{ end }}
+{{- end }}
+{{- end }}
@@ -36,6 +36,16 @@ const (
 	ResourceLabelKey = "resource"
 	// OwnedRecordLabelKey is the name of the label that identifies the record that is owned by the labeled TXT registry record
 	OwnedRecordLabelKey = "ownedRecord"
+	// RouteKindLabelKey is the name of the label that identifies the kind of the Gateway API Route that created the Endpoint
+	RouteKindLabelKey = "route-kind"
+	// RouteNamespaceLabelKey is the name of the label that identifies the namespace of the Gateway API Route that created the Endpoint
+	RouteNamespaceLabelKey = "route-namespace"
+	// RouteNameLabelKey is the name of the label that identifies the name of the Gateway API Route that created the Endpoint
+	RouteNameLabelKey = "route-name"
+	// ProvisionalLabelKey is the name of the label that marks Endpoints of Gateway API Routes that haven't been accepted yet
+	ProvisionalLabelKey = "provisional"
+	// FallbackLabelKey is the name of the label that marks Endpoints of Gateway API Routes that point at fallback targets
+	FallbackLabelKey = "fallback"
 
 	// AWSSDDescriptionLabel label responsible for storing raw owner/resource combination information in the Labels
 	// supposed to be inserted by AWS SD Provider, and parsed into OwnerLabelKey and ResourceLabelKey key by AWS SD Registry
@@ -59,7 +59,58 @@ type Config struct {
 	ExposeInternalIPV6                            bool
 	GatewayName                                   string
 	GatewayNamespace                              string
+	GatewayNamespaceAnnotations                   bool
+	GatewayNamespaceTTL                           map[string]string
+	GatewayOnDemandNamespaces                     bool
+	GatewayParentGroup                            string
+	GatewayParentKind                             string
+	GatewayParentMatching                         string
+	GatewayParentlessRoutes                       bool
+	GatewayPreferredAddressType                   string
+	GatewayLabelFallback                          bool
 	GatewayLabelFilter                            string
+	GatewayListBackoff                            time.Duration
+	GatewayListRetries                            int
+	GatewayListenerRecordTypes                    []string
+	GatewayMaxRouteEndpoints                      int
+	GatewayRequireReferenceGrant                  bool
+	GatewayRequireProgrammed                      bool
+	GatewayRequireAttachedRoutes                  bool
+	GatewayRequireBackends                        bool
+	GatewayResolveBackends                        bool
+	GatewayResolveConcurrency                     int
+	GatewayResolveNodes                           bool
+	GatewayResolveHostnameTargets                 bool
+	GatewayResourceTemplate                       string
+	GatewayRouteEvents                            bool
+	GatewayRouteFieldSelector                     string
+	GatewayRouteLabels                            bool
+	GatewayRoutingPolicyProvider                  string
+	GatewayStrictHostnames                        bool
+	GatewayStrictListenerPorts                    bool
+	GatewayStrictProtocols                        bool
+	GatewaySetIdentifierTemplate                  string
+	GatewayTargetCIDRAllowlist                    []string
+	GatewayBackendHostnames                       bool
+	GatewayBackendZone                            string
+	GatewayCertificateHostnames                   bool
+	GatewayClassFilter                            string
+	GatewayClassParameters                        bool
+	GatewayClusterName                            string
+	GatewayControllerValue                        string
+	GatewayCreatePTR                              bool
+	GatewayDeletionGracePeriod                    time.Duration
+	GatewayDeniedHostnames                        []string
+	GatewayMultiLabelWildcards                    bool
+	GatewayEventDebounce                          time.Duration
+	GatewayFallbackTargets                        []string
+	GatewayFilterHostnames                        bool
+	GatewayHostnameSuffixes                       []string
+	GatewayIgnoreL4FQDNTemplate                   bool
+	GatewayIncludeUnaccepted                      bool
+	GatewayWarnUnsuffixedHosts                    bool
+	GatewayWeightedTargets                        bool
+	GatewayWildcardApex                           bool
 	Compatibility                                 string
 	PodSourceDomain                               string
 	PublishInternal                               bool
@@ -259,130 +310,182 @@ var defaultConfig = &Config{
 	CloudflareRegionalServices:                    false,
 	CloudflareRegionKey:                           "earth",
 
-	CombineFQDNAndAnnotation:     false,
-	Compatibility:                "",
-	ConnectorSourceServer:        "localhost:8080",
-	CoreDNSPrefix:                "/skydns/",
-	CRDSourceAPIVersion:          "externaldns.k8s.io/v1alpha1",
-	CRDSourceKind:                "DNSEndpoint",
-	DefaultTargets:               []string{},
-	DigitalOceanAPIPageSize:      50,
-	DomainFilter:                 []string{},
-	DryRun:                       false,
-	ExcludeDNSRecordTypes:        []string{},
-	ExcludeDomains:               []string{},
-	ExcludeTargetNets:            []string{},
-	ExcludeUnschedulable:         true,
-	ExoscaleAPIEnvironment:       "api",
-	ExoscaleAPIKey:               "",
-	ExoscaleAPISecret:            "",
-	ExoscaleAPIZone:              "ch-gva-2",
-	ExposeInternalIPV6:           false,
-	FQDNTemplate:                 "",
-	GatewayLabelFilter:           "",
-	GatewayName:                  "",
-	GatewayNamespace:             "",
-	GlooNamespaces:               []string{"gloo-system"},
-	GoDaddyAPIKey:                "",
-	GoDaddyOTE:                   false,
-	GoDaddySecretKey:             "",
-	GoDaddyTTL:                   600,
-	GoogleBatchChangeInterval:    time.Second,
-	GoogleBatchChangeSize:        1000,
-	GoogleProject:                "",
-	GoogleZoneVisibility:         "",
-	IgnoreHostnameAnnotation:     false,
-	IgnoreIngressRulesSpec:       false,
-	IgnoreIngressTLSSpec:         false,
-	IngressClassNames:            nil,
-	InMemoryZones:                []string{},
-	Interval:                     time.Minute,
-	KubeConfig:                   "",
-	LabelFilter:                  labels.Everything().String(),
-	LogFormat:                    "text",
-	LogLevel:                     logrus.InfoLevel.String(),
-	ManagedDNSRecordTypes:        []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
-	MetricsAddress:               ":7979",
-	MinEventSyncInterval:         5 * time.Second,
-	Namespace:                    "",
-	NAT64Networks:                []string{},
-	NS1Endpoint:                  "",
-	NS1IgnoreSSL:                 false,
-	OCIConfigFile:                "/etc/kubernetes/oci.yaml",
-	OCIZoneCacheDuration:         0 * time.Second,
-	OCIZoneScope:                 "GLOBAL",
-	Once:                         false,
-	OVHApiRateLimit:              20,
-	OVHEnableCNAMERelative:       false,
-	OVHEndpoint:                  "ovh-eu",
-	PDNSAPIKey:                   "",
-	PDNSServer:                   "http://localhost:8081",
-	PDNSServerID:                 "localhost",
-	PDNSSkipTLSVerify:            false,
-	PiholeApiVersion:             "5",
-	PiholePassword:               "",
-	PiholeServer:                 "",
-	PiholeTLSInsecureSkipVerify:  false,
-	PluralCluster:                "",
-	PluralProvider:               "",
-	PodSourceDomain:              "",
-	Policy:                       "sync",
-	Provider:                     "",
-	ProviderCacheTime:            0,
-	PublishHostIP:                false,
-	PublishInternal:              false,
-	RegexDomainExclusion:         regexp.MustCompile(""),
-	RegexDomainFilter:            regexp.MustCompile(""),
-	Registry:                     "txt",
-	RequestTimeout:               time.Second * 30,
-	RFC2136BatchChangeSize:       50,
-	RFC2136GSSTSIG:               false,
-	RFC2136Host:                  []string{""},
-	RFC2136Insecure:              false,
-	RFC2136KerberosPassword:      "",
-	RFC2136KerberosRealm:         "",
-	RFC2136KerberosUsername:      "",
-	RFC2136LoadBalancingStrategy: "disabled",
-	RFC2136MinTTL:                0,
-	RFC2136Port:                  0,
-	RFC2136SkipTLSVerify:         false,
-	RFC2136TAXFR:                 true,
-	RFC2136TSIGKeyName:           "",
-	RFC2136TSIGSecret:            "",
-	RFC2136TSIGSecretAlg:         "",
-	RFC2136UseTLS:                false,
-	RFC2136Zone:                  []string{},
-	ServiceTypeFilter:            []string{},
-	SkipperRouteGroupVersion:     "zalando.org/v1",
-	Sources:                      nil,
-	TargetNetFilter:              []string{},
-	TLSCA:                        "",
-	TLSClientCert:                "",
-	TLSClientCertKey:             "",
-	TraefikEnableLegacy:          false,
-	TraefikDisableNew:            false,
-	TransIPAccountName:           "",
-	TransIPPrivateKeyFile:        "",
-	TXTCacheInterval:             0,
-	TXTEncryptAESKey:             "",
-	TXTEncryptEnabled:            false,
-	TXTOwnerID:                   "default",
-	TXTPrefix:                    "",
-	TXTSuffix:                    "",
-	TXTWildcardReplacement:       "",
-	UpdateEvents:                 false,
-	WebhookProviderReadTimeout:   5 * time.Second,
-	WebhookProviderURL:           "http://localhost:8888",
-	WebhookProviderWriteTimeout:  10 * time.Second,
-	WebhookServer:                false,
-	ZoneIDFilter:                 []string{},
-	ForceDefaultTargets:          false,
+	CombineFQDNAndAnnotation:      false,
+	Compatibility:                 "",
+	ConnectorSourceServer:         "localhost:8080",
+	CoreDNSPrefix:                 "/skydns/",
+	CRDSourceAPIVersion:           "externaldns.k8s.io/v1alpha1",
+	CRDSourceKind:                 "DNSEndpoint",
+	DefaultTargets:                []string{},
+	DigitalOceanAPIPageSize:       50,
+	DomainFilter:                  []string{},
+	DryRun:                        false,
+	ExcludeDNSRecordTypes:         []string{},
+	ExcludeDomains:                []string{},
+	ExcludeTargetNets:             []string{},
+	ExcludeUnschedulable:          true,
+	ExoscaleAPIEnvironment:        "api",
+	ExoscaleAPIKey:                "",
+	ExoscaleAPISecret:             "",
+	ExoscaleAPIZone:               "ch-gva-2",
+	ExposeInternalIPV6:            false,
+	FQDNTemplate:                  "",
+	GatewayBackendHostnames:       false,
+	GatewayBackendZone:            "",
+	GatewayCertificateHostnames:   false,
+	GatewayClassFilter:            "",
+	GatewayClassParameters:        false,
+	GatewayClusterName:            "",
+	GatewayControllerValue:        "dns-controller",
+	GatewayCreatePTR:              false,
+	GatewayDeletionGracePeriod:    0,
+	GatewayDeniedHostnames:        []string{},
+	GatewayEventDebounce:          0,
+	GatewayFallbackTargets:        []string{},
+	GatewayFilterHostnames:        false,
+	GatewayHostnameSuffixes:       []string{},
+	GatewayIgnoreL4FQDNTemplate:   false,
+	GatewayIncludeUnaccepted:      false,
+	GatewayLabelFallback:          false,
+	GatewayLabelFilter:            "",
+	GatewayListBackoff:            time.Second,
+	GatewayListRetries:            3,
+	GatewayListenerRecordTypes:    []string{},
+	GatewayMaxRouteEndpoints:      0,
+	GatewayMultiLabelWildcards:    false,
+	GatewayName:                   "",
+	GatewayNamespace:              "",
+	GatewayNamespaceAnnotations:   false,
+	GatewayNamespaceTTL:           map[string]string{},
+	GatewayOnDemandNamespaces:     false,
+	GatewayParentGroup:            "gateway.networking.k8s.io",
+	GatewayParentKind:             "Gateway",
+	GatewayParentMatching:         "union",
+	GatewayParentlessRoutes:       false,
+	GatewayPreferredAddressType:   "",
+	GatewayRequireReferenceGrant:  false,
+	GatewayRequireAttachedRoutes:  false,
+	GatewayRequireBackends:        false,
+	GatewayRequireProgrammed:      false,
+	GatewayResolveBackends:        false,
+	GatewayResolveConcurrency:     1,
+	GatewayResolveNodes:           false,
+	GatewayResolveHostnameTargets: false,
+	GatewayResourceTemplate:       "",
+	GatewayRouteEvents:            false,
+	GatewayRouteFieldSelector:     "",
+	GatewayRouteLabels:            false,
+	GatewayRoutingPolicyProvider:  "aws",
+	GatewayStrictHostnames:        false,
+	GatewaySetIdentifierTemplate:  "",
+	GatewayStrictListenerPorts:    false,
+	GatewayStrictProtocols:        false,
+	GatewayTargetCIDRAllowlist:    []string{},
+	GatewayWarnUnsuffixedHosts:    false,
+	GatewayWeightedTargets:        false,
+	GatewayWildcardApex:           false,
+	GlooNamespaces:                []string{"gloo-system"},
+	GoDaddyAPIKey:                 "",
+	GoDaddyOTE:                    false,
+	GoDaddySecretKey:              "",
+	GoDaddyTTL:                    600,
+	GoogleBatchChangeInterval:     time.Second,
+	GoogleBatchChangeSize:         1000,
+	GoogleProject:                 "",
+	GoogleZoneVisibility:          "",
+	IgnoreHostnameAnnotation:      false,
+	IgnoreIngressRulesSpec:        false,
+	IgnoreIngressTLSSpec:          false,
+	IngressClassNames:             nil,
+	InMemoryZones:                 []string{},
+	Interval:                      time.Minute,
+	KubeConfig:                    "",
+	LabelFilter:                   labels.Everything().String(),
+	LogFormat:                     "text",
+	LogLevel:                      logrus.InfoLevel.String(),
+	ManagedDNSRecordTypes:         []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+	MetricsAddress:                ":7979",
+	MinEventSyncInterval:          5 * time.Second,
+	Namespace:                     "",
+	NAT64Networks:                 []string{},
+	NS1Endpoint:                   "",
+	NS1IgnoreSSL:                  false,
+	OCIConfigFile:                 "/etc/kubernetes/oci.yaml",
+	OCIZoneCacheDuration:          0 * time.Second,
+	OCIZoneScope:                  "GLOBAL",
+	Once:                          false,
+	OVHApiRateLimit:               20,
+	OVHEnableCNAMERelative:        false,
+	OVHEndpoint:                   "ovh-eu",
+	PDNSAPIKey:                    "",
+	PDNSServer:                    "http://localhost:8081",
+	PDNSServerID:                  "localhost",
+	PDNSSkipTLSVerify:             false,
+	PiholeApiVersion:              "5",
+	PiholePassword:                "",
+	PiholeServer:                  "",
+	PiholeTLSInsecureSkipVerify:   false,
+	PluralCluster:                 "",
+	PluralProvider:                "",
+	PodSourceDomain:               "",
+	Policy:                        "sync",
+	Provider:                      "",
+	ProviderCacheTime:             0,
+	PublishHostIP:                 false,
+	PublishInternal:               false,
+	RegexDomainExclusion:          regexp.MustCompile(""),
+	RegexDomainFilter:             regexp.MustCompile(""),
+	Registry:                      "txt",
+	RequestTimeout:                time.Second * 30,
+	RFC2136BatchChangeSize:        50,
+	RFC2136GSSTSIG:                false,
+	RFC2136Host:                   []string{""},
+	RFC2136Insecure:               false,
+	RFC2136KerberosPassword:       "",
+	RFC2136KerberosRealm:          "",
+	RFC2136KerberosUsername:       "",
+	RFC2136LoadBalancingStrategy:  "disabled",
+	RFC2136MinTTL:                 0,
+	RFC2136Port:                   0,
+	RFC2136SkipTLSVerify:          false,
+	RFC2136TAXFR:                  true,
+	RFC2136TSIGKeyName:            "",
+	RFC2136TSIGSecret:             "",
+	RFC2136TSIGSecretAlg:          "",
+	RFC2136UseTLS:                 false,
+	RFC2136Zone:                   []string{},
+	ServiceTypeFilter:             []string{},
+	SkipperRouteGroupVersion:      "zalando.org/v1",
+	Sources:                       nil,
+	TargetNetFilter:               []string{},
+	TLSCA:                         "",
+	TLSClientCert:                 "",
+	TLSClientCertKey:              "",
+	TraefikEnableLegacy:           false,
+	TraefikDisableNew:             false,
+	TransIPAccountName:            "",
+	TransIPPrivateKeyFile:         "",
+	TXTCacheInterval:              0,
+	TXTEncryptAESKey:              "",
+	TXTEncryptEnabled:             false,
+	TXTOwnerID:                    "default",
+	TXTPrefix:                     "",
+	TXTSuffix:                     "",
+	TXTWildcardReplacement:        "",
+	UpdateEvents:                  false,
+	WebhookProviderReadTimeout:    5 * time.Second,
+	WebhookProviderURL:            "http://localhost:8888",
+	WebhookProviderWriteTimeout:   10 * time.Second,
+	WebhookServer:                 false,
+	ZoneIDFilter:                  []string{},
+	ForceDefaultTargets:           false,
 }
 
 // NewConfig returns new Config object
 func NewConfig() *Config {
 	return &Config{
-		AWSSDCreateTag: map[string]string{},
+		AWSSDCreateTag:      map[string]string{},
+		GatewayNamespaceTTL: map[string]string{},
 	}
 }
 
@@ -466,9 +569,60 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("exclude-unschedulable", "Exclude nodes that are considered unschedulable (default: true)").Default(strconv.FormatBool(defaultConfig.ExcludeUnschedulable)).BoolVar(&cfg.ExcludeUnschedulable)
 	app.Flag("expose-internal-ipv6", "When using the node source, expose internal IPv6 addresses (optional, default: false)").BoolVar(&cfg.ExposeInternalIPV6)
 	app.Flag("fqdn-template", "A templated string that's used to generate DNS names from sources that don't define a hostname themselves, or to add a hostname suffix when paired with the fake source (optional). Accepts comma separated list for multiple global FQDN.").Default(defaultConfig.FQDNTemplate).StringVar(&cfg.FQDNTemplate)
+	app.Flag("gateway-backend-hostnames", "When resolving backends, also use the hostname annotations of the Services referenced by a Route's backendRefs as its hostnames; requires --gateway-resolve-backends (default: false)").BoolVar(&cfg.GatewayBackendHostnames)
+	app.Flag("gateway-backend-zone", "When resolving backends, use the ready endpoints of ClusterIP Services whose topology hints are for this zone instead of their cluster IP, unless endpoints lack hints; requires --gateway-resolve-backends and permission to list and watch EndpointSlices (optional)").StringVar(&cfg.GatewayBackendZone)
+	app.Flag("gateway-certificate-hostnames", "Limit Routes attached to Gateway Listeners without a hostname to the SANs of the Listener's TLS certificates (default: false)").BoolVar(&cfg.GatewayCertificateHostnames)
+	app.Flag("gateway-class-filter", "Limit Gateways of Route endpoints to a comma-separated list of GatewayClass names (default: all classes)").StringVar(&cfg.GatewayClassFilter)
+	app.Flag("gateway-class-parameters", "Use the target annotation of the ConfigMap referenced by the parametersRef of a GatewayClass as the default target of its Gateways without addresses; requires permission to list and watch GatewayClasses and ConfigMaps (default: false)").BoolVar(&cfg.GatewayClassParameters)
+	app.Flag("gateway-cluster-name", "The name of the cluster, which the --gateway-set-identifier-template may refer to as {{ .Cluster }} (optional)").StringVar(&cfg.GatewayClusterName)
+	app.Flag("gateway-controller-value", "Only process Gateway API resources whose controller annotation is absent or set to this value, e.g. to split them between multiple instances").Default(defaultConfig.GatewayControllerValue).StringVar(&cfg.GatewayControllerValue)
+	app.Flag("gateway-create-ptr", "Also publish PTR records that map the reverse names of the IP targets of Routes to their hostnames; wildcard hostnames are skipped (default: false)").BoolVar(&cfg.GatewayCreatePTR)
+	app.Flag("gateway-deletion-grace-period", "Keep publishing the endpoints of deleted Routes for the given grace period in duration format, so that the records of Routes that are briefly recreated, e.g. during rollouts, don't flap; 0 deletes them right away (default: 0s)").Default(defaultConfig.GatewayDeletionGracePeriod.String()).DurationVar(&cfg.GatewayDeletionGracePeriod)
+	app.Flag("gateway-denied-hostname", "Never publish the given hostnames of Routes, e.g. protected hostnames that tenants must not claim; wildcards match like those of Gateway Listeners, so *.admin.example.com denies all of its subdomains; specify multiple times for multiple hostnames (optional)").StringsVar(&cfg.GatewayDeniedHostnames)
+	app.Flag("gateway-event-debounce", "Coalesce Gateway API events within the given window into a single synchronization in duration format; 0 disables debouncing (default: 0s)").Default(defaultConfig.GatewayEventDebounce.String()).DurationVar(&cfg.GatewayEventDebounce)
+	app.Flag("gateway-fallback-target", "Publish the hosts of Routes attached to Gateways without addresses or target overrides with the given target until the Gateways have addresses, e.g. a sinkhole IP or a maintenance page; specify multiple times for multiple targets (optional)").StringsVar(&cfg.GatewayFallbackTargets)
+	app.Flag("gateway-filter-hostnames", "Also publish the hostnames that the RequestRedirect and URLRewrite filters of HTTPRoutes redirect or rewrite requests to (default: false)").BoolVar(&cfg.GatewayFilterHostnames)
+	app.Flag("gateway-hostname-suffix", "Only publish hostnames of Routes and Gateway Listeners that are the given domain or a subdomain of it; specify multiple times for multiple domains (optional)").StringsVar(&cfg.GatewayHostnameSuffixes)
+	app.Flag("gateway-ignore-l4-fqdn-template", "Don't apply the FQDN template to TCPRoutes and UDPRoutes, so that only hostname annotations create their records (default: false)").BoolVar(&cfg.GatewayIgnoreL4FQDNTemplate)
+	app.Flag("gateway-include-unaccepted", "Also publish Routes that their Gateways haven't accepted yet, e.g. to pre-create records, labeling their endpoints as provisional; intended for debugging (default: false)").BoolVar(&cfg.GatewayIncludeUnaccepted)
+	app.Flag("gateway-label-fallback", "Attach Routes whose parent Gateway isn't found to a Gateway selected by --gateway-label-filter in the parent's namespace, preferring the first by name; requires --gateway-label-filter (default: false)").BoolVar(&cfg.GatewayLabelFallback)
 	app.Flag("gateway-label-filter", "Filter Gateways of Route endpoints via label selector (default: all gateways)").StringVar(&cfg.GatewayLabelFilter)
-	app.Flag("gateway-name", "Limit Gateways of Route endpoints to a specific name (default: all names)").StringVar(&cfg.GatewayName)
+	app.Flag("gateway-list-backoff", "Initial delay before retrying to list Gateway API resources whose informers haven't synced yet, doubled on each retry, in duration format (default: 1s)").Default(defaultConfig.GatewayListBackoff.String()).DurationVar(&cfg.GatewayListBackoff)
+	app.Flag("gateway-list-retries", "Number of times to retry listing Gateway API resources whose informers haven't synced yet before failing; 0 disables retries (default: 3)").Default(strconv.Itoa(defaultConfig.GatewayListRetries)).IntVar(&cfg.GatewayListRetries)
+	app.Flag("gateway-listener-record-type", "Also publish records of the given type for the hosts that Routes match on the named Listener of the given protocol, in the form PROTOCOL/LISTENER=TYPE, e.g. TCP/ldap=SRV; only SRV is supported; specify multiple times for multiple Listeners (optional)").StringsVar(&cfg.GatewayListenerRecordTypes)
+	app.Flag("gateway-max-route-endpoints", "Skip Routes that generate more than this number of endpoints, as a guardrail against runaway FQDN templates or hostname lists; 0 means unlimited (default: 0)").Default(strconv.Itoa(defaultConfig.GatewayMaxRouteEndpoints)).IntVar(&cfg.GatewayMaxRouteEndpoints)
+	app.Flag("gateway-multi-label-wildcards", "Match wildcard hostnames of Gateway Listeners and Routes against any number of labels instead of exactly one label (default: false)").BoolVar(&cfg.GatewayMultiLabelWildcards)
+	app.Flag("gateway-name", "Limit Gateways of Route endpoints to a specific name, or to the names matching a glob pattern like edge-* (default: all names)").StringVar(&cfg.GatewayName)
 	app.Flag("gateway-namespace", "Limit Gateways of Route endpoints to a specific namespace (default: all namespaces)").StringVar(&cfg.GatewayNamespace)
+	app.Flag("gateway-namespace-annotations", "Also match the namespace selectors of Gateway Listeners against the annotations of a Route's namespace if its labels don't match (default: false)").BoolVar(&cfg.GatewayNamespaceAnnotations)
+	app.Flag("gateway-namespace-ttl", "Default TTL of records from Routes in a namespace that don't specify one, in the format namespace=duration; specify multiple times for multiple namespaces").StringMapVar(&cfg.GatewayNamespaceTTL)
+	app.Flag("gateway-on-demand-namespaces", "Only list and watch Namespaces once a Gateway Listener selects Routes by namespace labels, so that Gateways without such Listeners don't require permission to list Namespaces cluster-wide; until then, Routes in terminating namespaces aren't skipped (default: false)").BoolVar(&cfg.GatewayOnDemandNamespaces)
+	app.Flag("gateway-parent-group", "The API group of the Gateways that Routes reference as parents, for Gateway implementations of another group (default: gateway.networking.k8s.io)").Default(defaultConfig.GatewayParentGroup).StringVar(&cfg.GatewayParentGroup)
+	app.Flag("gateway-parent-kind", "The kind of the Gateways that Routes reference as parents, for Gateway implementations of another kind (default: Gateway)").Default(defaultConfig.GatewayParentKind).StringVar(&cfg.GatewayParentKind)
+	app.Flag("gateway-parent-matching", "How to combine the hosts that the parent Gateways of a Route match; intersection only publishes hosts matched by all of them (default: union, options: union, intersection)").Default(defaultConfig.GatewayParentMatching).EnumVar(&cfg.GatewayParentMatching, "union", "intersection")
+	app.Flag("gateway-parentless-routes", "Publish Routes without parentRefs that have a target annotation, pointing their hostnames from the spec, annotations, or FQDN template at the annotated targets instead of the addresses of Gateways (default: false)").BoolVar(&cfg.GatewayParentlessRoutes)
+	app.Flag("gateway-preferred-address-type", "When a Gateway has addresses of several types, only use those of this type as targets, falling back to the others if there are none (optional, options: IPAddress, Hostname)").Default(defaultConfig.GatewayPreferredAddressType).EnumVar(&cfg.GatewayPreferredAddressType, "", "IPAddress", "Hostname")
+	app.Flag("gateway-require-attached-routes", "Only match Routes to Listeners whose status reports attached Routes and, if listed, supports the kind of the Route (default: false)").BoolVar(&cfg.GatewayRequireAttachedRoutes)
+	app.Flag("gateway-require-backends", "Only publish Routes that reference at least one backend, so that Routes without backendRefs don't create DNS entries for requests the Gateway rejects (default: false)").BoolVar(&cfg.GatewayRequireBackends)
+	app.Flag("gateway-require-programmed", "Only publish Routes attached to Gateways whose Programmed condition is True (default: false)").BoolVar(&cfg.GatewayRequireProgrammed)
+	app.Flag("gateway-require-reference-grant", "Require a ReferenceGrant in the Gateway namespace for Routes attached to a Gateway in another namespace (default: false)").BoolVar(&cfg.GatewayRequireReferenceGrant)
+	app.Flag("gateway-resolve-backends", "Use the addresses of a Route's backend Services as targets if its Gateway has no addresses (default: false)").BoolVar(&cfg.GatewayResolveBackends)
+	app.Flag("gateway-resolve-concurrency", "Number of Routes of each kind to resolve concurrently, to speed up the generation of endpoints in large clusters (default: 1)").Default(strconv.Itoa(defaultConfig.GatewayResolveConcurrency)).IntVar(&cfg.GatewayResolveConcurrency)
+	app.Flag("gateway-resolve-hostname-targets", "Resolve hostname targets of Routes to their IP addresses at each sync and publish A and AAAA records instead of CNAME records, for providers that don't allow CNAME records at the apex or chained CNAME records; hostnames that fail to resolve are published as CNAME records (default: false)").BoolVar(&cfg.GatewayResolveHostnameTargets)
+	app.Flag("gateway-resolve-nodes", "Allow the endpoints-type annotation of Routes to replace Node addresses among their targets with the selected addresses of those Nodes; requires permission to list and watch Nodes (default: false)").BoolVar(&cfg.GatewayResolveNodes)
+	app.Flag("gateway-resource-template", "A template for the resource label of Route records, which the TXT registry persists as their owning resource, rendered against the Route's metadata like the --gateway-set-identifier-template, e.g. '{{ .Cluster }}/{{ .Namespace }}/{{ .Name }}', to match the ownership labels of other tools; defaults to the Route's lowercase kind, namespace, and name, e.g. httproute/default/app (optional)").StringVar(&cfg.GatewayResourceTemplate)
+	app.Flag("gateway-route-events", "Emit Kubernetes Events on Routes when their endpoints are generated or they are skipped, each time the outcome changes; requires permission to create and patch events (default: false)").BoolVar(&cfg.GatewayRouteEvents)
+	app.Flag("gateway-route-field-selector", "Limit Routes to those matching a field selector, to reduce the memory of listing and watching them in namespaces with many unrelated Routes; only metadata.name and metadata.namespace are supported (optional)").StringVar(&cfg.GatewayRouteFieldSelector)
+	app.Flag("gateway-route-labels", "Label the records of Routes with the kind, namespace, and name of the Route, which the TXT registry persists to record their provenance (default: false)").BoolVar(&cfg.GatewayRouteLabels)
+	app.Flag("gateway-routing-policy-provider", "The provider whose provider-specific properties publish the weights, failover roles, and geolocations of Route records; only the AWS provider supports them natively, webhook providers receive them with the webhook/ prefix (default: aws, options: aws, webhook)").Default(defaultConfig.GatewayRoutingPolicyProvider).EnumVar(&cfg.GatewayRoutingPolicyProvider, "aws", "webhook")
+	app.Flag("gateway-set-identifier-template", "A template for the set identifier of Route records, rendered against the Route's metadata, e.g. '{{ .Cluster }}-{{ .Namespace }}-{{ .Name }}'; the set-identifier annotation is available as {{ .SetIdentifier }} (optional)").StringVar(&cfg.GatewaySetIdentifierTemplate)
+	app.Flag("gateway-strict-hostnames", "Don't publish the hostnames of Listeners for HTTP and TLS Routes without hostnames in their spec whose hostnames come from annotations or the FQDN template (default: false)").BoolVar(&cfg.GatewayStrictHostnames)
+	app.Flag("gateway-strict-listener-ports", "Only match Routes that specify neither a sectionName nor a port to Listeners on the well-known port of their protocol (default: false)").BoolVar(&cfg.GatewayStrictListenerPorts)
+	app.Flag("gateway-strict-protocols", "Only match Routes to Listeners of exactly their protocol, instead of treating HTTP and HTTPS, as well as TCP and TLS, alike; the listener-protocol annotation of a Route overrides the protocol of its kind (default: false)").BoolVar(&cfg.GatewayStrictProtocols)
+	app.Flag("gateway-target-cidr-allowlist", "Only publish IP targets of Gateways and Route backends within the given CIDR; specify multiple times for multiple CIDRs (optional)").StringsVar(&cfg.GatewayTargetCIDRAllowlist)
+	app.Flag("gateway-warn-unsuffixed-hosts", "Log a warning instead of a debug message for each hostname of a Route dropped by --gateway-hostname-suffix (default: false)").BoolVar(&cfg.GatewayWarnUnsuffixedHosts)
+	app.Flag("gateway-weighted-targets", "Publish the total weight of a Route's backendRefs as the weight of its records; requires the set-identifier annotation (default: false)").BoolVar(&cfg.GatewayWeightedTargets)
+	app.Flag("gateway-wildcard-apex", "Also publish the apex of wildcard hostnames of Routes with the same targets, e.g. example.com for *.example.com, as alias records if the targets are hostnames (default: false)").BoolVar(&cfg.GatewayWildcardApex)
 	app.Flag("ignore-hostname-annotation", "Ignore hostname annotation when generating DNS names, valid only when --fqdn-template is set (default: false)").BoolVar(&cfg.IgnoreHostnameAnnotation)
 	app.Flag("ignore-ingress-rules-spec", "Ignore the spec.rules section in Ingress resources (default: false)").BoolVar(&cfg.IgnoreIngressRulesSpec)
 	app.Flag("ignore-ingress-tls-spec", "Ignore the spec.tls section in Ingress resources (default: false)").BoolVar(&cfg.IgnoreIngressTLSSpec)
@@ -484,7 +638,7 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("publish-host-ip", "Allow external-dns to publish host-ip for headless services (optional)").BoolVar(&cfg.PublishHostIP)
 	app.Flag("publish-internal-services", "Allow external-dns to publish DNS records for ClusterIP services (optional)").BoolVar(&cfg.PublishInternal)
 	app.Flag("service-type-filter", "The service types to filter by. Specify multiple times for multiple filters to be applied. (optional, default: all, expected: ClusterIP, NodePort, LoadBalancer or ExternalName)").Default(defaultConfig.ServiceTypeFilter...).StringsVar(&cfg.ServiceTypeFilter)
-	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy")
+	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy")
 	app.Flag("target-net-filter", "Limit possible targets by a net filter; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.TargetNetFilter)
 	app.Flag("traefik-enable-legacy", "Enable legacy listeners on Resources under the traefik.containo.us API Group").Default(strconv.FormatBool(defaultConfig.TraefikEnableLegacy)).BoolVar(&cfg.TraefikEnableLegacy)
 	app.Flag("traefik-disable-new", "Disable listeners on Resources under the traefik.io API Group").Default(strconv.FormatBool(defaultConfig.TraefikDisableNew)).BoolVar(&cfg.TraefikDisableNew)
@@ -40,6 +40,15 @@ var (
 		Namespace:                              "",
 		FQDNTemplate:                           "",
 		Compatibility:                          "",
+		GatewayControllerValue:                 "dns-controller",
+		GatewayListBackoff:                     time.Second,
+		GatewayListRetries:                     3,
+		GatewayNamespaceTTL:                    map[string]string{},
+		GatewayParentGroup:                     "gateway.networking.k8s.io",
+		GatewayParentKind:                      "Gateway",
+		GatewayParentMatching:                  "union",
+		GatewayResolveConcurrency:              1,
+		GatewayRoutingPolicyProvider:           "aws",
 		Provider:                               "google",
 		GoogleProject:                          "",
 		GoogleBatchChangeSize:                  1000,
@@ -145,6 +154,17 @@ var (
 		IgnoreIngressRulesSpec:                 true,
 		FQDNTemplate:                           "{{.Name}}.service.example.com",
 		Compatibility:                          "mate",
+		GatewayControllerValue:                 "dns-controller",
+		GatewayListBackoff:                     time.Second,
+		GatewayListRetries:                     3,
+		GatewayNamespaceTTL:                    map[string]string{"team-a": "5m", "team-b": "1h"},
+		GatewayParentGroup:                     "gateway.networking.k8s.io",
+		GatewayParentKind:                      "Gateway",
+		GatewayParentMatching:                  "union",
+		GatewayResolveConcurrency:              1,
+		GatewayResolveHostnameTargets:          true,
+		GatewayRoutingPolicyProvider:           "aws",
+		GatewayWarnUnsuffixedHosts:             true,
 		Provider:                               "google",
 		GoogleProject:                          "project",
 		GoogleBatchChangeSize:                  100,
@@ -350,6 +370,10 @@ func TestParseFlags(t *testing.T) {
 				"--aws-sd-service-cleanup",
 				"--aws-sd-create-tag=key1=value1",
 				"--aws-sd-create-tag=key2=value2",
+				"--gateway-namespace-ttl=team-a=5m",
+				"--gateway-namespace-ttl=team-b=1h",
+				"--gateway-resolve-hostname-targets",
+				"--gateway-warn-unsuffixed-hosts",
 				"--no-aws-evaluate-target-health",
 				"--pihole-api-version=6",
 				"--policy=upsert-only",
@@ -471,6 +495,9 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_AWS_ZONES_CACHE_DURATION":                          "10s",
 				"EXTERNAL_DNS_AWS_SD_SERVICE_CLEANUP":                            "true",
 				"EXTERNAL_DNS_AWS_SD_CREATE_TAG":                                 "key1=value1\nkey2=value2",
+				"EXTERNAL_DNS_GATEWAY_NAMESPACE_TTL":                             "team-a=5m\nteam-b=1h",
+				"EXTERNAL_DNS_GATEWAY_RESOLVE_HOSTNAME_TARGETS":                  "true",
+				"EXTERNAL_DNS_GATEWAY_WARN_UNSUFFIXED_HOSTS":                     "true",
 				"EXTERNAL_DNS_DYNAMODB_TABLE":                                    "custom-table",
 				"EXTERNAL_DNS_PIHOLE_API_VERSION":                                "6",
 				"EXTERNAL_DNS_POLICY":                                            "upsert-only",
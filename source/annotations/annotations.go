@@ -40,6 +40,8 @@ const (
 	SetIdentifierKey = AnnotationKeyPrefix + "set-identifier"
 	AliasKey         = AnnotationKeyPrefix + "alias"
 	TargetKey        = AnnotationKeyPrefix + "target"
+	// The annotation used for forcing the record type of the endpoints
+	RecordTypeKey = AnnotationKeyPrefix + "record-type"
 	// The annotation used for figuring out which controller is responsible
 	ControllerKey = AnnotationKeyPrefix + "controller"
 	// The annotation used for defining the desired hostname
@@ -55,4 +57,39 @@ const (
 	ControllerValue = "dns-controller"
 	// The annotation used for defining the desired hostname
 	InternalHostnameKey = AnnotationKeyPrefix + "internal-hostname"
+	// The annotation used for listing the concrete hostnames that replace a matched wildcard hostname
+	WildcardHostnamesKey = AnnotationKeyPrefix + "wildcard-hostnames"
+	// The annotation used for listing the hostnames that should not be managed
+	ExcludeHostnamesKey = AnnotationKeyPrefix + "exclude-hostnames"
+	// The annotations used for geolocation routing by continent, country, and subdivision of a country
+	GeoContinentKey   = AnnotationKeyPrefix + "geo-continent"
+	GeoCountryKey     = AnnotationKeyPrefix + "geo-country"
+	GeoSubdivisionKey = AnnotationKeyPrefix + "geo-subdivision"
+	// The annotation used for specifying the protocol of the Gateway Listeners a Route attaches to,
+	// if protocols are matched strictly
+	ListenerProtocolKey = AnnotationKeyPrefix + "listener-protocol"
+	// The annotation used for pinning the targets of a Route to the given addresses of its Gateways
+	GatewayAddressKey = AnnotationKeyPrefix + "gateway-address"
+	// The annotations used for active-passive failover records, of either the primary or secondary role,
+	// along with the ID of the provider's health check that decides whether the primary records are served
+	FailoverKey      = AnnotationKeyPrefix + "failover"
+	HealthCheckIDKey = AnnotationKeyPrefix + "health-check-id"
+	// The annotation used for a comment on the records, for providers that support record comments
+	CommentKey = AnnotationKeyPrefix + "comment"
+	// The annotation used for the default hostname of the Listeners of a Gateway that don't specify one
+	ListenerHostnameKey = AnnotationKeyPrefix + "listener-hostname"
+	// The annotation used for the TTLs of the records of individual Listeners of a Gateway
+	ListenerTTLKey = AnnotationKeyPrefix + "listener-ttl"
+	// The annotation used for the Gateway addresses that individual Listeners of a Gateway bind to
+	ListenerAddressesKey = AnnotationKeyPrefix + "listener-addresses"
+	// The annotation used for pausing the management of the records of a resource without removing it
+	IgnoreKey = AnnotationKeyPrefix + "ignore"
+	// The annotation used for limiting the IP families of the targets of a resource to ipv4, ipv6, or both
+	IPFamiliesKey = AnnotationKeyPrefix + "ip-families"
+	// The annotation used for overriding the targets and TTLs of individual hostnames of a resource
+	HostnameOverridesKey = AnnotationKeyPrefix + "hostname-overrides"
+	// The annotation used for the weight of the records of a Gateway's targets among the Gateways of a Route
+	GatewayWeightKey = AnnotationKeyPrefix + "gateway-weight"
+	// The annotation used for the nameservers of the subdomains that a resource delegates with NS records
+	NameserversKey = AnnotationKeyPrefix + "nameservers"
 )
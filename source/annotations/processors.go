@@ -14,6 +14,10 @@ limitations under the License.
 package annotations
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -37,18 +41,184 @@ func TTLFromAnnotations(annotations map[string]string, resource string) endpoint
 	if !ok {
 		return ttlNotConfigured
 	}
-	ttlValue, err := parseTTL(ttlAnnotation)
+	return ttlFromValue(ttlAnnotation, resource)
+}
+
+// ListenerTTLsFromAnnotations extracts the TTLs of the Listeners of the given Gateway from its
+// annotations, keyed by Listener name. The annotation lists comma-separated NAME=TTL pairs, where
+// each TTL has the format of the TTL annotation. Invalid pairs are ignored.
+func ListenerTTLsFromAnnotations(annotations map[string]string, resource string) map[string]endpoint.TTL {
+	ttlAnnotation, ok := annotations[ListenerTTLKey]
+	if !ok {
+		return nil
+	}
+	var ttls map[string]endpoint.TTL
+	for _, pair := range strings.Split(ttlAnnotation, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			log.Warnf("%s: %q is not a valid listener TTL, expected NAME=TTL", resource, pair)
+			continue
+		}
+		if ttl := ttlFromValue(strings.TrimSpace(value), resource); ttl.IsConfigured() {
+			if ttls == nil {
+				ttls = make(map[string]endpoint.TTL)
+			}
+			ttls[name] = ttl
+		}
+	}
+	return ttls
+}
+
+// ListenerAddressesFromAnnotations extracts the Gateway addresses that the Listeners of the given
+// Gateway bind to from its annotations, keyed by Listener name. The annotation lists comma-separated
+// NAME=ADDRESS pairs, where a Listener binding several addresses is named once for each of them.
+// Invalid pairs are ignored.
+func ListenerAddressesFromAnnotations(annotations map[string]string, resource string) map[string][]string {
+	addrsAnnotation, ok := annotations[ListenerAddressesKey]
+	if !ok {
+		return nil
+	}
+	var addrs map[string][]string
+	for _, pair := range strings.Split(addrsAnnotation, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" || value == "" {
+			log.Warnf("%s: %q is not a valid listener address, expected NAME=ADDRESS", resource, pair)
+			continue
+		}
+		if addrs == nil {
+			addrs = make(map[string][]string)
+		}
+		addrs[name] = append(addrs[name], value)
+	}
+	return addrs
+}
+
+func ttlFromValue(value string, resource string) endpoint.TTL {
+	ttlNotConfigured := endpoint.TTL(0)
+	ttlValue, err := parseTTL(strings.TrimSpace(value))
 	if err != nil {
-		log.Warnf("%s: %q is not a valid TTL value: %v", resource, ttlAnnotation, err)
+		log.Warnf("%s: %q is not a valid TTL value: %v", resource, value, err)
 		return ttlNotConfigured
 	}
 	if ttlValue < ttlMinimum || ttlValue > ttlMaximum {
-		log.Warnf("TTL value %q must be between [%d, %d]", ttlValue, ttlMinimum, ttlMaximum)
+		log.Warnf("%s: TTL value %q of %d seconds must be between [%d, %d]", resource, value, ttlValue, ttlMinimum, ttlMaximum)
 		return ttlNotConfigured
 	}
 	return endpoint.TTL(ttlValue)
 }
 
+// IPFamiliesFromAnnotations extracts the IP families of the given resource's ip-families annotation,
+// a comma-separated list of "ipv4" and "ipv6". Unknown families are ignored with a warning.
+// Returns nil if the annotation is not set or has no known families.
+func IPFamiliesFromAnnotations(annotations map[string]string, resource string) []string {
+	value, ok := annotations[IPFamiliesKey]
+	if !ok {
+		return nil
+	}
+	var families []string
+	for _, family := range strings.Split(value, ",") {
+		family = strings.ToLower(strings.TrimSpace(family))
+		switch family {
+		case "":
+		case "ipv4", "ipv6":
+			if !slices.Contains(families, family) {
+				families = append(families, family)
+			}
+		default:
+			log.Warnf("%s: %q is not a valid IP family, expected ipv4 or ipv6", resource, family)
+		}
+	}
+	return families
+}
+
+// maxGatewayWeight is the highest weight of a Gateway, the highest weight of weighted records that
+// providers such as Route 53 support.
+const maxGatewayWeight = 255
+
+// GatewayWeightFromAnnotations extracts the weight of the given Gateway's gateway-weight annotation,
+// an integer from 0 to 255. An invalid weight is ignored with a warning. Returns false if the
+// annotation is not set or invalid.
+func GatewayWeightFromAnnotations(annotations map[string]string, resource string) (int64, bool) {
+	value, ok := annotations[GatewayWeightKey]
+	if !ok {
+		return 0, false
+	}
+	weight, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || weight < 0 || weight > maxGatewayWeight {
+		log.Warnf("%s: %q is not a valid gateway weight, expected an integer from 0 to %d", resource, value, maxGatewayWeight)
+		return 0, false
+	}
+	return weight, true
+}
+
+// HostnameOverride holds the targets and TTL that override those of a single hostname of a resource.
+type HostnameOverride struct {
+	Targets endpoint.Targets
+	TTL     endpoint.TTL
+}
+
+// HostnameOverridesFromAnnotations extracts the per-hostname overrides from the given annotations map.
+// The annotation is a JSON object mapping hostnames to their targets and TTL, for example
+// {"a.example.com": {"targets": ["10.0.0.1"], "ttl": "5m"}}. Unlike the simple annotations, a
+// malformed value is rejected with an error instead of being ignored, since it would otherwise
+// silently publish the hostnames at their default targets.
+func HostnameOverridesFromAnnotations(annotations map[string]string) (map[string]HostnameOverride, error) {
+	value, ok := annotations[HostnameOverridesKey]
+	if !ok {
+		return nil, nil
+	}
+	var raw map[string]struct {
+		Targets []string `json:"targets"`
+		TTL     string   `json:"ttl"`
+	}
+	decoder := json.NewDecoder(bytes.NewBufferString(value))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", HostnameOverridesKey, err)
+	}
+	overrides := make(map[string]HostnameOverride, len(raw))
+	for hostname, o := range raw {
+		host := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(hostname), "."))
+		if host == "" {
+			return nil, fmt.Errorf("invalid %s annotation: empty hostname", HostnameOverridesKey)
+		}
+		if _, ok := overrides[host]; ok {
+			return nil, fmt.Errorf("invalid %s annotation: duplicate hostname %q", HostnameOverridesKey, host)
+		}
+		if len(o.Targets) == 0 && o.TTL == "" {
+			return nil, fmt.Errorf("invalid %s annotation: hostname %q overrides neither targets nor TTL", HostnameOverridesKey, host)
+		}
+		var override HostnameOverride
+		for _, target := range o.Targets {
+			target = strings.TrimSuffix(strings.TrimSpace(target), ".")
+			if target == "" {
+				return nil, fmt.Errorf("invalid %s annotation: empty target of hostname %q", HostnameOverridesKey, host)
+			}
+			override.Targets = append(override.Targets, target)
+		}
+		if o.TTL != "" {
+			ttl, err := parseTTL(o.TTL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s annotation: TTL %q of hostname %q: %w", HostnameOverridesKey, o.TTL, host, err)
+			}
+			if ttl < ttlMinimum || ttl > ttlMaximum {
+				return nil, fmt.Errorf("invalid %s annotation: TTL %q of hostname %q must be between [%d, %d]", HostnameOverridesKey, o.TTL, host, ttlMinimum, ttlMaximum)
+			}
+			override.TTL = endpoint.TTL(ttl)
+		}
+		overrides[host] = override
+	}
+	return overrides, nil
+}
+
 // parseTTL parses TTL from string, returning duration in seconds.
 // parseTTL supports both integers like "600" and durations based
 // on Go Duration like "10m", hence "600" and "10m" represent the same value.
@@ -67,6 +237,21 @@ func parseTTL(s string) (int64, error) {
 	return int64(ttlDuration.Seconds()), nil
 }
 
+// RecordTypeFromAnnotations extracts the forced record type from the annotations of the given resource.
+// Returns an empty string if the annotation is not set or its value is not a known record type.
+func RecordTypeFromAnnotations(annotations map[string]string, resource string) string {
+	recordType, ok := annotations[RecordTypeKey]
+	if !ok {
+		return ""
+	}
+	recordType = strings.ToUpper(strings.TrimSpace(recordType))
+	if recordType != endpoint.RecordTypeCNAME && !slices.Contains(endpoint.KnownRecordTypes, recordType) {
+		log.Warnf("%s: %q is not a valid record type", resource, annotations[RecordTypeKey])
+		return ""
+	}
+	return recordType
+}
+
 // ParseFilter parses an annotation filter string into a labels.Selector.
 // Returns nil if the annotation filter is invalid.
 func ParseFilter(annotationFilter string) (labels.Selector, error) {
@@ -84,9 +269,20 @@ func ParseFilter(annotationFilter string) (labels.Selector, error) {
 // TargetsFromTargetAnnotation gets endpoints from optional "target" annotation.
 // Returns empty endpoints array if none are found.
 func TargetsFromTargetAnnotation(annotations map[string]string) endpoint.Targets {
+	return extractTargetsFromAnnotations(annotations, TargetKey)
+}
+
+// TargetsFromListenerTargetAnnotation gets endpoints from the optional "target" annotation
+// of the given Gateway Listener, e.g. "external-dns.alpha.kubernetes.io/target.internal".
+// Returns empty endpoints array if none are found.
+func TargetsFromListenerTargetAnnotation(annotations map[string]string, listener string) endpoint.Targets {
+	return extractTargetsFromAnnotations(annotations, TargetKey+"."+listener)
+}
+
+func extractTargetsFromAnnotations(annotations map[string]string, key string) endpoint.Targets {
 	var targets endpoint.Targets
 	// Get the desired hostname of the ingress from the annotation.
-	targetAnnotation, ok := annotations[TargetKey]
+	targetAnnotation, ok := annotations[key]
 	if ok && targetAnnotation != "" {
 		// splits the hostname annotation and removes the trailing periods
 		targetsList := SplitHostnameAnnotation(targetAnnotation)
@@ -110,6 +306,18 @@ func InternalHostnamesFromAnnotations(input map[string]string) []string {
 	return extractHostnamesFromAnnotations(input, InternalHostnameKey)
 }
 
+// WildcardHostnamesFromAnnotations extracts the hostnames that replace wildcard hostnames from the given annotations map.
+// It returns a slice of hostnames if the WildcardHostnamesKey annotation is present, otherwise it returns nil.
+func WildcardHostnamesFromAnnotations(input map[string]string) []string {
+	return extractHostnamesFromAnnotations(input, WildcardHostnamesKey)
+}
+
+// ExcludeHostnamesFromAnnotations extracts the hostnames that should not be managed from the given annotations map.
+// It returns a slice of hostnames if the ExcludeHostnamesKey annotation is present, otherwise it returns nil.
+func ExcludeHostnamesFromAnnotations(input map[string]string) []string {
+	return extractHostnamesFromAnnotations(input, ExcludeHostnamesKey)
+}
+
 // SplitHostnameAnnotation splits a comma-separated hostname annotation string into a slice of hostnames.
 // It trims any leading or trailing whitespace and removes any spaces within the anno
 func SplitHostnameAnnotation(input string) []string {
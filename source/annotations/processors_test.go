@@ -117,6 +117,41 @@ func TestTargetsFromTargetAnnotation(t *testing.T) {
 	}
 }
 
+func TestTargetsFromListenerTargetAnnotation(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		listener    string
+		expected    endpoint.Targets
+	}{
+		{
+			name: "no listener target annotation",
+			annotations: map[string]string{
+				TargetKey: "example.com",
+			},
+			listener: "internal",
+			expected: endpoint.Targets(nil),
+		},
+		{
+			name: "listener target annotation",
+			annotations: map[string]string{
+				TargetKey:               "example.com",
+				TargetKey + ".internal": "10.0.0.1,10.0.0.2",
+				TargetKey + ".external": "203.0.113.1",
+			},
+			listener: "internal",
+			expected: endpoint.Targets{"10.0.0.1", "10.0.0.2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TargetsFromListenerTargetAnnotation(tt.annotations, tt.listener)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestTTLFromAnnotations(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -194,6 +229,26 @@ func TestTTLFromAnnotations(t *testing.T) {
 			annotations: map[string]string{TtlKey: "20.5s"},
 			expectedTTL: endpoint.TTL(20),
 		},
+		{
+			name:        "TTL annotation value is set correctly using duration (hours)",
+			annotations: map[string]string{TtlKey: "1h30m"},
+			expectedTTL: endpoint.TTL(5400),
+		},
+		{
+			name:        "TTL annotation value with surrounding whitespace",
+			annotations: map[string]string{TtlKey: " 5m "},
+			expectedTTL: endpoint.TTL(300),
+		},
+		{
+			name:        "TTL annotation value is a duration below a second",
+			annotations: map[string]string{TtlKey: "500ms"},
+			expectedTTL: endpoint.TTL(0),
+		},
+		{
+			name:        "TTL annotation value is a negative duration",
+			annotations: map[string]string{TtlKey: "-5m"},
+			expectedTTL: endpoint.TTL(0),
+		},
 	}
 
 	for _, tt := range tests {
@@ -204,6 +259,283 @@ func TestTTLFromAnnotations(t *testing.T) {
 	}
 }
 
+func TestListenerTTLsFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    map[string]endpoint.TTL
+	}{
+		{
+			name:        "no listener TTL annotation",
+			annotations: map[string]string{TtlKey: "60"},
+			expected:    nil,
+		},
+		{
+			name:        "listener TTLs",
+			annotations: map[string]string{ListenerTTLKey: "canary=30s, stable = 600,"},
+			expected:    map[string]endpoint.TTL{"canary": 30, "stable": 600},
+		},
+		{
+			name:        "invalid pairs are ignored",
+			annotations: map[string]string{ListenerTTLKey: "canary, =60, stable=never, beta=-1, http=1m"},
+			expected:    map[string]endpoint.TTL{"http": 60},
+		},
+		{
+			name:        "only invalid pairs",
+			annotations: map[string]string{ListenerTTLKey: "canary"},
+			expected:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ListenerTTLsFromAnnotations(tt.annotations, "gateway/default/test"))
+		})
+	}
+}
+
+func TestListenerAddressesFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    map[string][]string
+	}{
+		{
+			name:        "no listener addresses annotation",
+			annotations: map[string]string{TtlKey: "60"},
+			expected:    nil,
+		},
+		{
+			name:        "listener addresses",
+			annotations: map[string]string{ListenerAddressesKey: "https=10.0.0.2, https = 2001:db8::2, canary=lb.example.com,"},
+			expected:    map[string][]string{"https": {"10.0.0.2", "2001:db8::2"}, "canary": {"lb.example.com"}},
+		},
+		{
+			name:        "invalid pairs are ignored",
+			annotations: map[string]string{ListenerAddressesKey: "10.0.0.1, =10.0.0.2, https=, http=10.0.0.3"},
+			expected:    map[string][]string{"http": {"10.0.0.3"}},
+		},
+		{
+			name:        "only invalid pairs",
+			annotations: map[string]string{ListenerAddressesKey: "10.0.0.1"},
+			expected:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ListenerAddressesFromAnnotations(tt.annotations, "gateway/default/test"))
+		})
+	}
+}
+
+func TestIPFamiliesFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    []string
+	}{
+		{
+			name:        "no IP families annotation",
+			annotations: map[string]string{TtlKey: "60"},
+			expected:    nil,
+		},
+		{
+			name:        "IPv4",
+			annotations: map[string]string{IPFamiliesKey: "ipv4"},
+			expected:    []string{"ipv4"},
+		},
+		{
+			name:        "IPv6",
+			annotations: map[string]string{IPFamiliesKey: " IPv6 "},
+			expected:    []string{"ipv6"},
+		},
+		{
+			name:        "both",
+			annotations: map[string]string{IPFamiliesKey: "ipv4,ipv6,ipv4"},
+			expected:    []string{"ipv4", "ipv6"},
+		},
+		{
+			name:        "unknown families are ignored",
+			annotations: map[string]string{IPFamiliesKey: "ipv5, ipv6,"},
+			expected:    []string{"ipv6"},
+		},
+		{
+			name:        "only unknown families",
+			annotations: map[string]string{IPFamiliesKey: "dualstack"},
+			expected:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IPFamiliesFromAnnotations(tt.annotations, "httproute/default/test"))
+		})
+	}
+}
+
+func TestGatewayWeightFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		expected       int64
+		expectedWeight bool
+	}{
+		{
+			name:        "no gateway weight annotation",
+			annotations: map[string]string{TtlKey: "60"},
+		},
+		{
+			name:           "weight",
+			annotations:    map[string]string{GatewayWeightKey: " 80 "},
+			expected:       80,
+			expectedWeight: true,
+		},
+		{
+			name:           "zero weight",
+			annotations:    map[string]string{GatewayWeightKey: "0"},
+			expected:       0,
+			expectedWeight: true,
+		},
+		{
+			name:        "negative weight",
+			annotations: map[string]string{GatewayWeightKey: "-1"},
+		},
+		{
+			name:        "weight out of range",
+			annotations: map[string]string{GatewayWeightKey: "256"},
+		},
+		{
+			name:        "invalid weight",
+			annotations: map[string]string{GatewayWeightKey: "heavy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weight, ok := GatewayWeightFromAnnotations(tt.annotations, "gateway/default/test")
+			assert.Equal(t, tt.expectedWeight, ok)
+			assert.Equal(t, tt.expected, weight)
+		})
+	}
+}
+
+func TestHostnameOverridesFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    map[string]HostnameOverride
+		expectedErr string
+	}{
+		{
+			name:        "no hostname overrides annotation",
+			annotations: map[string]string{HostnameKey: "a.example.com"},
+			expected:    nil,
+		},
+		{
+			name: "hostname overrides",
+			annotations: map[string]string{HostnameOverridesKey: `{
+				"A.example.com.": {"targets": ["10.0.0.1", "lb.example.com."], "ttl": "5m"},
+				"b.example.com": {"ttl": "60"},
+				"c.example.com": {"targets": ["2001:db8::1"]}
+			}`},
+			expected: map[string]HostnameOverride{
+				"a.example.com": {Targets: endpoint.Targets{"10.0.0.1", "lb.example.com"}, TTL: 300},
+				"b.example.com": {TTL: 60},
+				"c.example.com": {Targets: endpoint.Targets{"2001:db8::1"}},
+			},
+		},
+		{
+			name:        "malformed JSON",
+			annotations: map[string]string{HostnameOverridesKey: "a.example.com=10.0.0.1"},
+			expectedErr: "invalid external-dns.alpha.kubernetes.io/hostname-overrides annotation",
+		},
+		{
+			name:        "unknown field",
+			annotations: map[string]string{HostnameOverridesKey: `{"a.example.com": {"target": "10.0.0.1"}}`},
+			expectedErr: `unknown field "target"`,
+		},
+		{
+			name:        "empty hostname",
+			annotations: map[string]string{HostnameOverridesKey: `{" ": {"ttl": "60"}}`},
+			expectedErr: "empty hostname",
+		},
+		{
+			name:        "duplicate hostname",
+			annotations: map[string]string{HostnameOverridesKey: `{"a.example.com": {"ttl": "60"}, "a.example.com.": {"ttl": "30"}}`},
+			expectedErr: `duplicate hostname "a.example.com"`,
+		},
+		{
+			name:        "no override",
+			annotations: map[string]string{HostnameOverridesKey: `{"a.example.com": {}}`},
+			expectedErr: `hostname "a.example.com" overrides neither targets nor TTL`,
+		},
+		{
+			name:        "empty target",
+			annotations: map[string]string{HostnameOverridesKey: `{"a.example.com": {"targets": [""]}}`},
+			expectedErr: `empty target of hostname "a.example.com"`,
+		},
+		{
+			name:        "invalid TTL",
+			annotations: map[string]string{HostnameOverridesKey: `{"a.example.com": {"ttl": "never"}}`},
+			expectedErr: `TTL "never" of hostname "a.example.com"`,
+		},
+		{
+			name:        "TTL out of range",
+			annotations: map[string]string{HostnameOverridesKey: `{"a.example.com": {"ttl": "0"}}`},
+			expectedErr: `TTL "0" of hostname "a.example.com" must be between`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overrides, err := HostnameOverridesFromAnnotations(tt.annotations)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, overrides)
+		})
+	}
+}
+
+func TestRecordTypeFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    string
+	}{
+		{
+			name:        "no record type annotation",
+			annotations: map[string]string{},
+			expected:    "",
+		},
+		{
+			name:        "cname record type",
+			annotations: map[string]string{RecordTypeKey: "CNAME"},
+			expected:    endpoint.RecordTypeCNAME,
+		},
+		{
+			name:        "lower case record type",
+			annotations: map[string]string{RecordTypeKey: " aaaa "},
+			expected:    endpoint.RecordTypeAAAA,
+		},
+		{
+			name:        "invalid record type",
+			annotations: map[string]string{RecordTypeKey: "ALIAS"},
+			expected:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RecordTypeFromAnnotations(tt.annotations, "test/resource")
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestGetAliasFromAnnotations(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -313,6 +645,62 @@ func TestSplitHostnameAnnotation(t *testing.T) {
 	}
 }
 
+func TestWildcardHostnamesFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    []string
+	}{
+		{
+			name:        "no wildcard hostnames annotation",
+			annotations: map[string]string{},
+			expected:    nil,
+		},
+		{
+			name: "multiple wildcard hostnames",
+			annotations: map[string]string{
+				WildcardHostnamesKey: "foo.example.com, bar.example.com",
+			},
+			expected: []string{"foo.example.com", "bar.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := WildcardHostnamesFromAnnotations(tt.annotations)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExcludeHostnamesFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    []string
+	}{
+		{
+			name:        "no exclude hostnames annotation",
+			annotations: map[string]string{},
+			expected:    nil,
+		},
+		{
+			name: "multiple exclude hostnames",
+			annotations: map[string]string{
+				ExcludeHostnamesKey: "foo.example.com, *.example.org",
+			},
+			expected: []string{"foo.example.com", "*.example.org"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExcludeHostnamesFromAnnotations(tt.annotations)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestInternalHostnamesFromAnnotations(t *testing.T) {
 	tests := []struct {
 		name        string
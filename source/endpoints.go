@@ -43,37 +43,19 @@ func EndpointsForHostname(hostname string, targets endpoint.Targets, ttl endpoin
 	}
 
 	if len(aTargets) > 0 {
-		epA := endpoint.NewEndpointWithTTL(hostname, endpoint.RecordTypeA, ttl, aTargets...)
-		if epA != nil {
-			epA.ProviderSpecific = providerSpecific
-			epA.SetIdentifier = setIdentifier
-			if resource != "" {
-				epA.Labels[endpoint.ResourceLabelKey] = resource
-			}
+		if epA := endpointForHostnameAndType(hostname, endpoint.RecordTypeA, aTargets, ttl, providerSpecific, setIdentifier, resource); epA != nil {
 			endpoints = append(endpoints, epA)
 		}
 	}
 
 	if len(aaaaTargets) > 0 {
-		epAAAA := endpoint.NewEndpointWithTTL(hostname, endpoint.RecordTypeAAAA, ttl, aaaaTargets...)
-		if epAAAA != nil {
-			epAAAA.ProviderSpecific = providerSpecific
-			epAAAA.SetIdentifier = setIdentifier
-			if resource != "" {
-				epAAAA.Labels[endpoint.ResourceLabelKey] = resource
-			}
+		if epAAAA := endpointForHostnameAndType(hostname, endpoint.RecordTypeAAAA, aaaaTargets, ttl, providerSpecific, setIdentifier, resource); epAAAA != nil {
 			endpoints = append(endpoints, epAAAA)
 		}
 	}
 
 	if len(cnameTargets) > 0 {
-		epCNAME := endpoint.NewEndpointWithTTL(hostname, endpoint.RecordTypeCNAME, ttl, cnameTargets...)
-		if epCNAME != nil {
-			epCNAME.ProviderSpecific = providerSpecific
-			epCNAME.SetIdentifier = setIdentifier
-			if resource != "" {
-				epCNAME.Labels[endpoint.ResourceLabelKey] = resource
-			}
+		if epCNAME := endpointForHostnameAndType(hostname, endpoint.RecordTypeCNAME, cnameTargets, ttl, providerSpecific, setIdentifier, resource); epCNAME != nil {
 			endpoints = append(endpoints, epCNAME)
 		}
 	}
@@ -81,6 +63,21 @@ func EndpointsForHostname(hostname string, targets endpoint.Targets, ttl endpoin
 	return endpoints
 }
 
+// endpointForHostnameAndType returns an endpoint publishing all targets with the given record type,
+// or nil if the endpoint could not be created.
+func endpointForHostnameAndType(hostname, recordType string, targets endpoint.Targets, ttl endpoint.TTL, providerSpecific endpoint.ProviderSpecific, setIdentifier string, resource string) *endpoint.Endpoint {
+	ep := endpoint.NewEndpointWithTTL(hostname, recordType, ttl, targets...)
+	if ep == nil {
+		return nil
+	}
+	ep.ProviderSpecific = providerSpecific
+	ep.SetIdentifier = setIdentifier
+	if resource != "" {
+		ep.Labels[endpoint.ResourceLabelKey] = resource
+	}
+	return ep
+}
+
 func EndpointTargetsFromServices(svcInformer coreinformers.ServiceInformer, namespace string, selector map[string]string) (endpoint.Targets, error) {
 	targets := endpoint.Targets{}
 
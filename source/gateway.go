@@ -17,21 +17,43 @@ limitations under the License.
 package source
 
 import (
+	"cmp"
 	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"maps"
+	"net"
 	"net/netip"
+	"path"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
+	"unicode"
 
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	kubeinformers "k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
+	discoveryinformers "k8s.io/client-go/informers/discovery/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
@@ -40,16 +62,205 @@ import (
 	informers_v1beta1 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1beta1"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
 	"sigs.k8s.io/external-dns/source/annotations"
 	"sigs.k8s.io/external-dns/source/fqdn"
 	"sigs.k8s.io/external-dns/source/informers"
 )
 
 const (
+	// gatewayGroup and gatewayKind are the group and kind of Gateways, unless overridden for
+	// the parents of Routes by the configuration.
 	gatewayGroup = "gateway.networking.k8s.io"
 	gatewayKind  = "Gateway"
+
+	// gatewayAliasProperty is the provider-specific property used to publish alias records.
+	gatewayAliasProperty = "alias"
+	// gatewayCommentProperty is the provider-specific property used to publish record comments.
+	gatewayCommentProperty = annotations.CloudflareRecordCommentKey
+
+	// gatewayParentMatchingIntersection only publishes the hosts of a Route that all of its
+	// parent Gateways match, instead of the union of their hosts.
+	gatewayParentMatchingIntersection = "intersection"
+
+	// gatewayHostLookupTimeout limits the time to look up the IP addresses of a hostname target.
+	gatewayHostLookupTimeout = 5 * time.Second
+)
+
+// gatewayRoutingPolicy holds the names of the provider-specific properties that publish the routing
+// policies of Route records, e.g. weighted, failover, or geolocation records, since each provider names
+// them differently.
+type gatewayRoutingPolicy struct {
+	weight         string
+	failover       string
+	healthCheck    string
+	geoContinent   string
+	geoCountry     string
+	geoSubdivision string
+}
+
+// gatewayRoutingPolicies maps the providers of the routing policy option to their properties. Only the
+// AWS provider publishes them as Route53 routing policies; webhook providers receive the properties
+// with the webhook/ prefix and decide themselves whether they support them.
+var gatewayRoutingPolicies = map[string]gatewayRoutingPolicy{
+	"aws": {
+		weight:         "aws/weight",
+		failover:       "aws/failover",
+		healthCheck:    "aws/health-check-id",
+		geoContinent:   "aws/geolocation-continent-code",
+		geoCountry:     "aws/geolocation-country-code",
+		geoSubdivision: "aws/geolocation-subdivision-code",
+	},
+	"webhook": {
+		weight:         "webhook/weight",
+		failover:       "webhook/failover",
+		healthCheck:    "webhook/health-check-id",
+		geoContinent:   "webhook/geolocation-continent-code",
+		geoCountry:     "webhook/geolocation-country-code",
+		geoSubdivision: "webhook/geolocation-subdivision-code",
+	},
+}
+
+// gatewayRoutingPolicyFor returns the routing policy properties of the provider, defaulting to AWS.
+func gatewayRoutingPolicyFor(provider string) gatewayRoutingPolicy {
+	if policy, ok := gatewayRoutingPolicies[provider]; ok {
+		return policy
+	}
+	return gatewayRoutingPolicies["aws"]
+}
+
+// properties returns the provider-specific properties that select a routing policy, of which a record
+// can only have one.
+func (p gatewayRoutingPolicy) properties() []string {
+	return []string{
+		p.weight,
+		p.failover,
+		p.geoContinent,
+		p.geoCountry,
+		p.geoSubdivision,
+	}
+}
+
+// Reasons for skipping a Route's parent or Listener, used as labels of gatewayRouteSkipsTotal.
+const (
+	gwSkipNoParentRef         = "no-parent-ref"
+	gwSkipGatewayNotFound     = "gateway-not-found"
+	gwSkipNotAccepted         = "not-accepted"
+	gwSkipProtocolMismatch    = "protocol-mismatch"
+	gwSkipNamespaceNotAllowed = "namespace-not-allowed"
+	gwSkipNoMatchingHost      = "no-matching-host"
+	gwSkipNamespaceDeleting   = "namespace-deleting"
+	gwSkipNoBackends          = "no-backends"
+	gwSkipTooManyEndpoints    = "too-many-endpoints"
+)
+
+// Further reasons for skipping a Route, its parents, or Listeners, only used in validation reports.
+const (
+	gwSkipAnnotationFilter     = "annotation-filter"
+	gwSkipControllerMismatch   = "controller-mismatch"
+	gwSkipIgnored              = "ignored"
+	gwSkipUnsupportedParent    = "unsupported-parent"
+	gwSkipGatewayNameMismatch  = "gateway-name-mismatch"
+	gwSkipGatewayClassMismatch = "gateway-class-mismatch"
+	gwSkipNoReferenceGrant     = "no-reference-grant"
+	gwSkipNotProgrammed        = "not-programmed"
+	gwSkipListenerNotFound     = "listener-not-found"
+	gwSkipPortMismatch         = "port-mismatch"
+	gwSkipNonDefaultPort       = "non-default-port"
+	gwSkipInvalidSelector      = "invalid-namespace-selector"
+	gwSkipNamespaceNotFound    = "namespace-not-found"
+	gwSkipKindNotAllowed       = "kind-not-allowed"
+	gwSkipNoMatchingParent     = "no-matching-parent"
+	gwSkipNoTargets            = "no-targets"
+	gwSkipNotAttached          = "not-attached"
+)
+
+var gatewayRouteSkipsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "source",
+		Name:      "gateway_route_skips_total",
+		Help:      "Number of times Gateway API Routes were skipped, partitioned by route kind and reason (vector).",
+	},
+	[]string{"kind", "reason"},
+)
+
+// Types of errors listing the resources of a Gateway API Route source.
+const (
+	gwListErrorTransient = "transient"
+	gwListErrorFatal     = "fatal"
+)
+
+var gatewayListErrorsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "source",
+		Name:      "gateway_list_errors_total",
+		Help:      "Number of errors listing Gateway API resources, partitioned by route kind and error type (vector).",
+	},
+	[]string{"kind", "type"},
+)
+
+var gatewayRouteErrorsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "source",
+		Name:      "gateway_route_errors_total",
+		Help:      "Number of Gateway API Routes that failed to generate endpoints, partitioned by route kind (vector).",
+	},
+	[]string{"kind"},
+)
+
+var gatewayInvalidHostnamesTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "source",
+		Name:      "gateway_invalid_hostnames_total",
+		Help:      "Number of invalid hostnames of Gateway API Routes that were ignored, partitioned by route kind (vector).",
+	},
+	[]string{"kind"},
+)
+
+var gatewayTTLConflictsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "source",
+		Name:      "gateway_ttl_conflicts_total",
+		Help:      "Number of hosts of Gateway API Routes whose TTL annotation differs from that of their Gateways, partitioned by route kind (vector).",
+	},
+	[]string{"kind"},
 )
 
+var gatewayDeniedHostnamesTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "source",
+		Name:      "gateway_denied_hostnames_total",
+		Help:      "Number of hostnames of Gateway API Routes that were dropped because they're denied, partitioned by route kind (vector).",
+	},
+	[]string{"kind"},
+)
+
+var gatewayMissingSectionsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "source",
+		Name:      "gateway_missing_sections_total",
+		Help:      "Number of parentRefs of Gateway API Routes whose sectionName names no Listener of their Gateway, partitioned by route kind (vector).",
+	},
+	[]string{"kind"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(gatewayRouteSkipsTotal)
+	metrics.RegisterMetric.MustRegister(gatewayTTLConflictsTotal)
+	metrics.RegisterMetric.MustRegister(gatewayListErrorsTotal)
+	metrics.RegisterMetric.MustRegister(gatewayRouteErrorsTotal)
+	metrics.RegisterMetric.MustRegister(gatewayInvalidHostnamesTotal)
+	metrics.RegisterMetric.MustRegister(gatewayMissingSectionsTotal)
+	metrics.RegisterMetric.MustRegister(gatewayDeniedHostnamesTotal)
+}
+
 type gatewayRoute interface {
 	// Object returns the underlying route object to be used by templates.
 	Object() kubeObject
@@ -63,6 +274,8 @@ type gatewayRoute interface {
 	Protocol() v1.ProtocolType
 	// RouteStatus returns the route's common status.
 	RouteStatus() v1.RouteStatus
+	// BackendRefs returns the backends referenced by all of the route's rules.
+	BackendRefs() []v1.BackendRef
 }
 
 type newGatewayRouteInformerFunc func(gwinformers.SharedInformerFactory) gatewayRouteInformer
@@ -72,7 +285,7 @@ type gatewayRouteInformer interface {
 	Informer() cache.SharedIndexInformer
 }
 
-func newGatewayInformerFactory(client gateway.Interface, namespace string, labelSelector labels.Selector) gwinformers.SharedInformerFactory {
+func newGatewayInformerFactory(client gateway.Interface, namespace string, labelSelector labels.Selector, fieldSelector fields.Selector) gwinformers.SharedInformerFactory {
 	var opts []gwinformers.SharedInformerOption
 	if namespace != "" {
 		opts = append(opts, gwinformers.WithNamespace(namespace))
@@ -83,31 +296,150 @@ func newGatewayInformerFactory(client gateway.Interface, namespace string, label
 			o.LabelSelector = lbls
 		}))
 	}
+	if fieldSelector != nil && !fieldSelector.Empty() {
+		flds := fieldSelector.String()
+		opts = append(opts, gwinformers.WithTweakListOptions(func(o *metav1.ListOptions) {
+			o.FieldSelector = flds
+		}))
+	}
 	return gwinformers.NewSharedInformerFactoryWithOptions(client, 0, opts...)
 }
 
+// gwRouteSelectableFields lists the fields that field selectors of Routes support. Routes are
+// custom resources, whose field selectors only support their name and namespace, since the
+// Gateway API doesn't declare further selectable fields.
+var gwRouteSelectableFields = []string{"metadata.name", "metadata.namespace"}
+
+// gatewayRouteFieldSelector parses the field selector of Routes, which may only select fields
+// that the API server supports for Routes of the kind.
+func gatewayRouteFieldSelector(selector, kind string) (fields.Selector, error) {
+	if selector == "" {
+		return fields.Everything(), nil
+	}
+	parsed, err := fields.ParseSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field selector %q of %ss: %w", selector, kind, err)
+	}
+	for _, req := range parsed.Requirements() {
+		if !slices.Contains(gwRouteSelectableFields, req.Field) {
+			return nil, fmt.Errorf("invalid field selector %q of %ss: field %q is not supported, only %s are", selector, kind, req.Field, strings.Join(gwRouteSelectableFields, " and "))
+		}
+	}
+	return parsed, nil
+}
+
 type gatewayRouteSource struct {
 	gwName      string
 	gwNamespace string
 	gwLabels    labels.Selector
+	gwClasses   []string
 	gwInformer  informers_v1beta1.GatewayInformer
 
 	rtKind        string
 	rtNamespace   string
 	rtLabels      labels.Selector
+	rtFields      fields.Selector
 	rtAnnotations labels.Selector
 	rtInformer    gatewayRouteInformer
 
+	controllerValue string
+
+	// nsMu guards nsInformer and nsHandler, since the Namespace informer may be started on demand
+	// by nsFactory when a Listener first selects Routes by namespace labels.
+	nsMu       sync.Mutex
 	nsInformer coreinformers.NamespaceInformer
+	nsFactory  kubeinformers.SharedInformerFactory
+	nsHandler  cache.ResourceEventHandler
+	stopCh     <-chan struct{}
+	rgInformer informers_v1beta1.ReferenceGrantInformer
+	gcInformer informers_v1beta1.GatewayClassInformer
+	// configMaps reads the parameters ConfigMaps of GatewayClasses on demand, since watching
+	// all ConfigMaps of the cluster to resolve a few of them is too costly.
+	configMaps  typedcorev1.ConfigMapsGetter
+	secInformer coreinformers.SecretInformer
+	svcInformer coreinformers.ServiceInformer
+	ndInformer  coreinformers.NodeInformer
+	// esMu guards the EndpointSlice informers of the namespaces of the Routes' backend Services, which
+	// are started on demand by esClient once a Route references a Service in their namespace, so that
+	// the EndpointSlices of the whole cluster aren't watched.
+	esMu        sync.Mutex
+	esClient    kubernetes.Interface
+	esInformers map[string]discoveryinformers.EndpointSliceInformer
+	esHandler   cache.ResourceEventHandler
 
 	fqdnTemplate             *template.Template
+	setIdentifierTemplate    *template.Template
+	resourceTemplate         *template.Template
+	clusterName              string
 	combineFQDNAnnotation    bool
 	ignoreHostnameAnnotation bool
+
+	requireReferenceGrant  bool
+	weightedTargets        bool
+	routingPolicy          gatewayRoutingPolicy
+	requireProgrammed      bool
+	strictListenerPorts    bool
+	strictProtocols        bool
+	wildcardApex           bool
+	nsAnnotationSelector   bool
+	multiLabelWildcards    bool
+	ignoreL4FQDNTemplate   bool
+	eventDebounce          time.Duration
+	nsTTLs                 map[string]endpoint.TTL
+	intersectParents       bool
+	parentlessRoutes       bool
+	filterHostnames        bool
+	transformTarget        GatewayTargetTransformer
+	routeLabels            bool
+	labelFallback          bool
+	createPTR              bool
+	requireAttachedRoutes  bool
+	requireBackends        bool
+	strictHostnames        bool
+	includeUnaccepted      bool
+	backendZone            string
+	backendHostnames       bool
+	parentGroupKind        schema.GroupKind
+	preferredAddressType   v1.AddressType
+	targetCIDRs            []netip.Prefix
+	hostSuffixes           []string
+	warnUnsuffixedHosts    bool
+	deniedHosts            []string
+	fallbackTargets        gatewayTargets
+	resolveConcurrency     int
+	maxRouteEndpoints      int
+	resolveHostnameTargets bool
+	hostResolver           gatewayHostResolver
+	listenerRecordTypes    map[string][]string
+	listBackoff            wait.Backoff
+
+	gwCache   *gatewayListenersCache
+	readiness gatewayReadiness
+	// events is nil unless Events are emitted on Routes.
+	events *gatewayRouteEvents
+	// deletionGrace is nil unless the endpoints of deleted Routes are published for a grace period.
+	deletionGrace *gatewayDeletionGrace
+	// lastEndpoints keeps the endpoints of Routes that fail to generate endpoints.
+	lastEndpoints gatewayLastEndpoints
 }
 
-func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string, newInformerFn newGatewayRouteInformerFunc) (Source, error) {
-	ctx := context.TODO()
+// GatewayTargetTransformer transforms a target of a Route's host before its endpoint is created,
+// e.g. to map the hostname of an internal load balancer to an alias. It returns the transformed
+// target, or false to drop the target. It is set programmatically through Config, not by flags.
+type GatewayTargetTransformer func(host, target string, meta *metav1.ObjectMeta) (string, bool)
+
+// gatewayNoopTargetTransformer is the default GatewayTargetTransformer, which keeps all targets as they are.
+func gatewayNoopTargetTransformer(_, target string, _ *metav1.ObjectMeta) (string, bool) {
+	return target, true
+}
 
+// newGatewayRouteSourceFromConfig returns a source of the given Route kind with the options of
+// the config, but without any informers. Features that depend on informers are disabled until
+// the caller sets them.
+func newGatewayRouteSourceFromConfig(config *Config, kind string) (*gatewayRouteSource, error) {
+	if err := validateGatewayName(config.GatewayName); err != nil {
+		return nil, err
+	}
 	gwLabels, err := getLabelSelector(config.GatewayLabelFilter)
 	if err != nil {
 		return nil, err
@@ -116,7 +448,11 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 	if rtLabels == nil {
 		rtLabels = labels.Everything()
 	}
-	rtAnnotations, err := getLabelSelector(config.AnnotationFilter)
+	rtFields, err := gatewayRouteFieldSelector(config.GatewayRouteFieldSelector, kind)
+	if err != nil {
+		return nil, err
+	}
+	rtAnnotations, err := getAnnotationSelector(config.AnnotationFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -124,19 +460,130 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 	if err != nil {
 		return nil, err
 	}
+	setIDTmpl, err := fqdn.ParseTemplate(config.GatewaySetIdentifierTemplate)
+	if err != nil {
+		return nil, err
+	}
+	resourceTmpl, err := fqdn.ParseTemplate(config.GatewayResourceTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse resource template %q: %w", config.GatewayResourceTemplate, err)
+	}
+	nsTTLs, err := gatewayNamespaceTTLs(config.GatewayNamespaceTTL)
+	if err != nil {
+		return nil, err
+	}
+	targetCIDRs, err := gatewayTargetCIDRs(config.GatewayTargetCIDRAllowlist)
+	if err != nil {
+		return nil, err
+	}
+	listenerRecordTypes, err := gatewayListenerRecordTypes(config.GatewayListenerRecordTypes)
+	if err != nil {
+		return nil, err
+	}
+	// Routes may reference the Gateways of implementations that ship them under another group or kind.
+	parentGroupKind := schema.GroupKind{
+		Group: cmp.Or(config.GatewayParentGroup, gatewayGroup),
+		Kind:  cmp.Or(config.GatewayParentKind, gatewayKind),
+	}
+	if config.GatewayLabelFallback && gwLabels.Empty() {
+		log.Warn("Ignoring the Gateway label fallback because no Gateway label filter is specified")
+	}
+	if config.GatewayBackendZone != "" && !config.GatewayResolveBackends {
+		log.Warn("Ignoring the Gateway backend zone because backend resolution is disabled")
+	}
+	if config.GatewayBackendHostnames && !config.GatewayResolveBackends {
+		log.Warn("Ignoring the hostnames of Gateway backends because backend resolution is disabled")
+	}
+
+	return &gatewayRouteSource{
+		gwName:      config.GatewayName,
+		gwNamespace: config.GatewayNamespace,
+		gwLabels:    gwLabels,
+		gwClasses:   gatewayClassFilter(config.GatewayClassFilter),
+
+		rtKind:        kind,
+		rtNamespace:   config.Namespace,
+		rtLabels:      rtLabels,
+		rtFields:      rtFields,
+		rtAnnotations: rtAnnotations,
+
+		controllerValue: gatewayControllerValue(config.GatewayControllerValue),
+
+		fqdnTemplate:             tmpl,
+		setIdentifierTemplate:    setIDTmpl,
+		resourceTemplate:         resourceTmpl,
+		clusterName:              config.GatewayClusterName,
+		combineFQDNAnnotation:    config.CombineFQDNAndAnnotation,
+		ignoreHostnameAnnotation: config.IgnoreHostnameAnnotation,
+
+		requireReferenceGrant:  config.GatewayRequireReferenceGrant,
+		weightedTargets:        config.GatewayWeightedTargets,
+		routingPolicy:          gatewayRoutingPolicyFor(config.GatewayRoutingPolicyProvider),
+		requireProgrammed:      config.GatewayRequireProgrammed,
+		strictListenerPorts:    config.GatewayStrictListenerPorts,
+		strictProtocols:        config.GatewayStrictProtocols,
+		wildcardApex:           config.GatewayWildcardApex,
+		nsAnnotationSelector:   config.GatewayNamespaceAnnotations,
+		multiLabelWildcards:    config.GatewayMultiLabelWildcards,
+		ignoreL4FQDNTemplate:   config.GatewayIgnoreL4FQDNTemplate,
+		eventDebounce:          config.GatewayEventDebounce,
+		deletionGrace:          newGatewayDeletionGrace(config.GatewayDeletionGracePeriod),
+		nsTTLs:                 nsTTLs,
+		intersectParents:       config.GatewayParentMatching == gatewayParentMatchingIntersection,
+		parentlessRoutes:       config.GatewayParentlessRoutes,
+		filterHostnames:        config.GatewayFilterHostnames,
+		transformTarget:        config.GatewayTargetTransformer,
+		routeLabels:            config.GatewayRouteLabels,
+		labelFallback:          config.GatewayLabelFallback && !gwLabels.Empty(),
+		createPTR:              config.GatewayCreatePTR,
+		requireAttachedRoutes:  config.GatewayRequireAttachedRoutes,
+		requireBackends:        config.GatewayRequireBackends,
+		strictHostnames:        config.GatewayStrictHostnames,
+		includeUnaccepted:      config.GatewayIncludeUnaccepted,
+		backendZone:            config.GatewayBackendZone,
+		backendHostnames:       config.GatewayBackendHostnames && config.GatewayResolveBackends,
+		parentGroupKind:        parentGroupKind,
+		preferredAddressType:   v1.AddressType(config.GatewayPreferredAddressType),
+		targetCIDRs:            targetCIDRs,
+		hostSuffixes:           gatewayHostSuffixes(config.GatewayHostnameSuffixes),
+		warnUnsuffixedHosts:    config.GatewayWarnUnsuffixedHosts,
+		deniedHosts:            gatewayDeniedHosts(config.GatewayDeniedHostnames),
+		fallbackTargets:        gatewayFallbackTargets(config.GatewayFallbackTargets),
+		resolveConcurrency:     config.GatewayResolveConcurrency,
+		maxRouteEndpoints:      config.GatewayMaxRouteEndpoints,
+		resolveHostnameTargets: config.GatewayResolveHostnameTargets,
+		hostResolver:           net.DefaultResolver,
+		listenerRecordTypes:    listenerRecordTypes,
+		listBackoff: wait.Backoff{
+			Duration: config.GatewayListBackoff,
+			Factor:   2,
+			Jitter:   0.1,
+			Steps:    config.GatewayListRetries,
+		},
+
+		gwCache: newGatewayListenersCache(),
+	}, nil
+}
+
+func newGatewayRouteSource(ctx context.Context, clients ClientGenerator, config *Config, kind string, newInformerFn newGatewayRouteInformerFunc) (Source, error) {
+	src, err := newGatewayRouteSourceFromConfig(config, kind)
+	if err != nil {
+		return nil, err
+	}
 
 	client, err := clients.GatewayClient()
 	if err != nil {
 		return nil, err
 	}
 
-	informerFactory := newGatewayInformerFactory(client, config.GatewayNamespace, gwLabels)
-	gwInformer := informerFactory.Gateway().V1beta1().Gateways() // TODO: Gateway informer should be shared across gateway sources.
-	gwInformer.Informer()                                        // Register with factory before starting.
+	// Informer factories are shared with the other Gateway sources using the same clients.
+	informerFactory, gwStopCh := sharedGatewayInformerFactory(ctx, client, config.GatewayNamespace, src.gwLabels, nil)
+	gwInformer := informerFactory.Gateway().V1beta1().Gateways()
+	gwInformer.Informer() // Register with factory before starting.
 
-	rtInformerFactory := informerFactory
-	if config.Namespace != config.GatewayNamespace || !selectorsEqual(rtLabels, gwLabels) {
-		rtInformerFactory = newGatewayInformerFactory(client, config.Namespace, rtLabels)
+	rtInformerFactory, rtStopCh := informerFactory, gwStopCh
+	if config.Namespace != config.GatewayNamespace || !selectorsEqual(src.rtLabels, src.gwLabels) || !src.rtFields.Empty() {
+		rtInformerFactory, rtStopCh = sharedGatewayInformerFactory(ctx, client, config.Namespace, src.rtLabels, src.rtFields)
 	}
 	rtInformer := newInformerFn(rtInformerFactory)
 	rtInformer.Informer() // Register with factory before starting.
@@ -146,19 +593,82 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 		return nil, err
 	}
 
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
-	nsInformer := kubeInformerFactory.Core().V1().Namespaces() // TODO: Namespace informer should be shared across gateway sources.
-	nsInformer.Informer()                                      // Register with factory before starting.
+	kubeInformerFactory, kubeStopCh := sharedKubeInformerFactory(ctx, kubeClient)
+	var nsInformer coreinformers.NamespaceInformer
+	var nsFactory kubeinformers.SharedInformerFactory
+	if config.GatewayOnDemandNamespaces {
+		nsFactory = kubeInformerFactory
+	} else {
+		nsInformer = kubeInformerFactory.Core().V1().Namespaces()
+		nsInformer.Informer() // Register with factory before starting.
+	}
+
+	var secInformer coreinformers.SecretInformer
+	if config.GatewayCertificateHostnames {
+		secInformer = kubeInformerFactory.Core().V1().Secrets()
+		secInformer.Informer() // Register with factory before starting.
+	}
+
+	var svcInformer coreinformers.ServiceInformer
+	if config.GatewayResolveBackends {
+		svcInformer = kubeInformerFactory.Core().V1().Services()
+		svcInformer.Informer() // Register with factory before starting.
+	}
+
+	var ndInformer coreinformers.NodeInformer
+	if config.GatewayResolveNodes {
+		ndInformer = kubeInformerFactory.Core().V1().Nodes()
+		ndInformer.Informer() // Register with factory before starting.
+	}
+
+	// GatewayClasses are cluster-scoped and aren't expected to carry the Gateway labels.
+	var gcInformerFactory gwinformers.SharedInformerFactory
+	var gcStopCh <-chan struct{}
+	var gcInformer informers_v1beta1.GatewayClassInformer
+	if config.GatewayClassParameters {
+		gcInformerFactory, gcStopCh = sharedGatewayInformerFactory(ctx, client, "", nil, nil)
+		gcInformer = gcInformerFactory.Gateway().V1beta1().GatewayClasses()
+		gcInformer.Informer() // Register with factory before starting.
+	}
+
+	// ReferenceGrants live in the namespace of the object they grant access to,
+	// but they aren't expected to carry the Gateway labels.
+	var rgInformerFactory gwinformers.SharedInformerFactory
+	var rgStopCh <-chan struct{}
+	var rgInformer informers_v1beta1.ReferenceGrantInformer
+	if config.GatewayRequireReferenceGrant || config.GatewayCertificateHostnames || config.GatewayResolveBackends {
+		rgNamespace := config.GatewayNamespace
+		if config.GatewayCertificateHostnames || config.GatewayResolveBackends {
+			rgNamespace = "" // Secrets and Services may be referenced from any namespace.
+		}
+		rgInformerFactory, rgStopCh = sharedGatewayInformerFactory(ctx, client, rgNamespace, nil, nil)
+		rgInformer = rgInformerFactory.Gateway().V1beta1().ReferenceGrants()
+		rgInformer.Informer() // Register with factory before starting.
+	}
 
-	informerFactory.Start(wait.NeverStop)
-	kubeInformerFactory.Start(wait.NeverStop)
+	informerFactory.Start(gwStopCh)
+	kubeInformerFactory.Start(kubeStopCh)
 	if rtInformerFactory != informerFactory {
-		rtInformerFactory.Start(wait.NeverStop)
+		rtInformerFactory.Start(rtStopCh)
 
 		if err := informers.WaitForCacheSync(ctx, rtInformerFactory); err != nil {
 			return nil, err
 		}
 	}
+	if rgInformerFactory != nil {
+		rgInformerFactory.Start(rgStopCh)
+
+		if err := informers.WaitForCacheSync(ctx, rgInformerFactory); err != nil {
+			return nil, err
+		}
+	}
+	if gcInformerFactory != nil {
+		gcInformerFactory.Start(gcStopCh)
+
+		if err := informers.WaitForCacheSync(ctx, gcInformerFactory); err != nil {
+			return nil, err
+		}
+	}
 	if err := informers.WaitForCacheSync(ctx, informerFactory); err != nil {
 		return nil, err
 	}
@@ -166,269 +676,2462 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 		return nil, err
 	}
 
-	src := &gatewayRouteSource{
-		gwName:      config.GatewayName,
-		gwNamespace: config.GatewayNamespace,
-		gwLabels:    gwLabels,
-		gwInformer:  gwInformer,
-
-		rtKind:        kind,
-		rtNamespace:   config.Namespace,
-		rtLabels:      rtLabels,
-		rtAnnotations: rtAnnotations,
-		rtInformer:    rtInformer,
-
-		nsInformer: nsInformer,
-
-		fqdnTemplate:             tmpl,
-		combineFQDNAnnotation:    config.CombineFQDNAndAnnotation,
-		ignoreHostnameAnnotation: config.IgnoreHostnameAnnotation,
+	src.gwInformer, src.rtInformer = gwInformer, rtInformer
+	src.nsInformer, src.nsFactory, src.stopCh = nsInformer, nsFactory, kubeStopCh
+	src.rgInformer, src.gcInformer = rgInformer, gcInformer
+	if gcInformer != nil {
+		src.configMaps = kubeClient.CoreV1()
+	}
+	src.secInformer, src.svcInformer, src.ndInformer = secInformer, svcInformer, ndInformer
+	if config.GatewayResolveBackends && config.GatewayBackendZone != "" {
+		src.esClient = kubeClient
+	}
+	if config.GatewayRouteEvents {
+		src.events = newGatewayRouteEvents(ctx, kubeClient)
+	}
+	gwInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: src.gwCache.onDelete})
+	if secInformer != nil {
+		// The certificate hostnames of Listeners depend on Secrets and the ReferenceGrants that allow access to them.
+		secInformer.Informer().AddEventHandler(eventHandlerFunc(src.gwCache.reset))
+		rgInformer.Informer().AddEventHandler(eventHandlerFunc(src.gwCache.reset))
 	}
 	return src, nil
 }
 
 func (src *gatewayRouteSource) AddEventHandler(ctx context.Context, handler func()) {
 	log.Debugf("Adding event handlers for %s", src.rtKind)
-	eventHandler := eventHandlerFunc(handler)
+	eventHandler := eventHandlerFunc(gwDebounce(ctx, handler, src.eventDebounce))
 	src.gwInformer.Informer().AddEventHandler(eventHandler)
 	src.rtInformer.Informer().AddEventHandler(eventHandler)
-	src.nsInformer.Informer().AddEventHandler(eventHandler)
-}
-
-func (src *gatewayRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
-	var endpoints []*endpoint.Endpoint
-	routes, err := src.rtInformer.List(src.rtNamespace, src.rtLabels)
-	if err != nil {
-		return nil, err
+	src.nsMu.Lock()
+	src.nsHandler = eventHandler
+	if src.nsInformer != nil {
+		src.nsInformer.Informer().AddEventHandler(eventHandler)
 	}
-	gateways, err := src.gwInformer.Lister().Gateways(src.gwNamespace).List(src.gwLabels)
-	if err != nil {
-		return nil, err
+	src.nsMu.Unlock()
+	if src.rgInformer != nil {
+		src.rgInformer.Informer().AddEventHandler(eventHandler)
 	}
-	namespaces, err := src.nsInformer.Lister().List(labels.Everything())
-	if err != nil {
-		return nil, err
+	if src.gcInformer != nil {
+		src.gcInformer.Informer().AddEventHandler(eventHandler)
 	}
-	kind := strings.ToLower(src.rtKind)
-	resolver := newGatewayRouteResolver(src, gateways, namespaces)
-	for _, rt := range routes {
-		// Filter by annotations.
-		meta := rt.Metadata()
-		annots := meta.Annotations
-		if !src.rtAnnotations.Matches(labels.Set(annots)) {
-			continue
-		}
+	if src.secInformer != nil {
+		src.secInformer.Informer().AddEventHandler(eventHandler)
+	}
+	if src.svcInformer != nil {
+		src.svcInformer.Informer().AddEventHandler(eventHandler)
+	}
+	src.esMu.Lock()
+	src.esHandler = eventHandler
+	for _, esInformer := range src.esInformers {
+		esInformer.Informer().AddEventHandler(eventHandler)
+	}
+	src.esMu.Unlock()
+	if src.ndInformer != nil {
+		src.ndInformer.Informer().AddEventHandler(eventHandler)
+	}
+}
 
-		// Check controller annotation to see if we are responsible.
-		if v, ok := annots[controllerAnnotationKey]; ok && v != controllerAnnotationValue {
-			log.Debugf("Skipping %s %s/%s because controller value does not match, found: %s, required: %s",
-				src.rtKind, meta.Namespace, meta.Name, v, controllerAnnotationValue)
-			continue
+// gatewayControllerValue returns the value of the controller annotation that marks resources
+// as ours, defaulting to the value shared by all sources.
+func gatewayControllerValue(value string) string {
+	if value == "" {
+		return controllerAnnotationValue
+	}
+	return value
+}
+
+// gatewayNamespaceTTLs parses the default TTLs of namespaces, which must be durations of at least a second.
+func gatewayNamespaceTTLs(ttls map[string]string) (map[string]endpoint.TTL, error) {
+	result := make(map[string]endpoint.TTL, len(ttls))
+	for namespace, value := range ttls {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TTL %q of namespace %q: %w", value, namespace, err)
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("invalid TTL %q of namespace %q: must be at least 1s", value, namespace)
 		}
+		result[namespace] = endpoint.TTL(d.Seconds())
+	}
+	return result, nil
+}
 
-		// Get Route hostnames and their targets.
-		hostTargets, err := resolver.resolve(rt)
+// gatewayTargetCIDRs parses the CIDRs that IP targets must be within.
+func gatewayTargetCIDRs(cidrs []string) ([]netip.Prefix, error) {
+	result := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("invalid target CIDR %q: %w", cidr, err)
 		}
-		if len(hostTargets) == 0 {
-			log.Debugf("No endpoints could be generated from %s %s/%s", src.rtKind, meta.Namespace, meta.Name)
-			continue
+		result = append(result, prefix.Masked())
+	}
+	return result, nil
+}
+
+// gatewayListenerRecordTypes parses the additional record types of Listeners, which are given in the
+// form PROTOCOL/LISTENER=TYPE, and returns them keyed by the protocol and name of their Listener.
+func gatewayListenerRecordTypes(values []string) (map[string][]string, error) {
+	result := make(map[string][]string, len(values))
+	for _, value := range values {
+		listener, recordType, ok := strings.Cut(value, "=")
+		protocol, name, hasName := strings.Cut(listener, "/")
+		if !ok || !hasName || protocol == "" || name == "" {
+			return nil, fmt.Errorf("invalid listener record type %q: must be of the form PROTOCOL/LISTENER=TYPE", value)
+		}
+		recordType = strings.ToUpper(recordType)
+		if recordType != endpoint.RecordTypeSRV {
+			return nil, fmt.Errorf("invalid listener record type %q: only SRV records are supported", value)
 		}
+		key := gwListenerKey(v1.ProtocolType(strings.ToUpper(protocol)), v1.SectionName(name))
+		if !slices.Contains(result[key], recordType) {
+			result[key] = append(result[key], recordType)
+		}
+	}
+	return result, nil
+}
+
+// gwListenerKey returns the key of a Listener in the additional record types of Listeners.
+func gwListenerKey(protocol v1.ProtocolType, name v1.SectionName) string {
+	return string(protocol) + "/" + string(name)
+}
 
-		// Create endpoints from hostnames and targets.
-		var routeEndpoints []*endpoint.Endpoint
-		resource := fmt.Sprintf("%s/%s/%s", kind, meta.Namespace, meta.Name)
-		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(annots)
-		ttl := annotations.TTLFromAnnotations(annots, resource)
-		for host, targets := range hostTargets {
-			routeEndpoints = append(routeEndpoints, EndpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)...)
+// gatewayHostSuffixes returns the canonical form of the hostname suffixes that hosts must have,
+// ignoring any leading wildcard label or dot.
+func gatewayHostSuffixes(suffixes []string) []string {
+	var result []string
+	for _, suffix := range suffixes {
+		suffix = strings.TrimPrefix(strings.TrimPrefix(suffix, "*"), ".")
+		if suffix = gwCanonicalHost(suffix); suffix != "" {
+			result = append(result, suffix)
 		}
-		log.Debugf("Endpoints generated from %s %s/%s: %v", src.rtKind, meta.Namespace, meta.Name, routeEndpoints)
+	}
+	return result
+}
 
-		endpoints = append(endpoints, routeEndpoints...)
+// gatewayDeniedHosts returns the canonical form of the denied hostnames, without empty ones.
+func gatewayDeniedHosts(hostnames []string) []string {
+	var result []string
+	for _, host := range hostnames {
+		if host = gwCanonicalHost(strings.TrimSpace(host)); host != "" {
+			result = append(result, host)
+		}
 	}
-	return endpoints, nil
+	return result
 }
 
-func namespacedName(namespace, name string) types.NamespacedName {
-	return types.NamespacedName{Namespace: namespace, Name: name}
+// gatewayFallbackTargets returns the fallback targets by record type.
+func gatewayFallbackTargets(values []string) gatewayTargets {
+	targets := make(gatewayTargets)
+	for _, target := range values {
+		if target = strings.TrimSpace(target); target != "" {
+			recordType := suitableType(target)
+			targets[recordType] = append(targets[recordType], target)
+		}
+	}
+	return targets
 }
 
-type gatewayRouteResolver struct {
-	src *gatewayRouteSource
-	gws map[types.NamespacedName]gatewayListeners
-	nss map[string]*corev1.Namespace
+// gwHostHasSuffix returns whether the host is one of the suffixes or a subdomain of one, or there
+// are no suffixes. Since the check respects label boundaries, a wildcard host only has a suffix if
+// all hosts it covers have it, e.g. *.example.com has the suffix example.com but not a.example.com.
+func gwHostHasSuffix(host string, suffixes []string) bool {
+	if len(suffixes) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(suffixes, func(suffix string) bool {
+		return host == suffix || strings.HasSuffix(host, "."+suffix)
+	})
 }
 
-type gatewayListeners struct {
-	gateway   *v1beta1.Gateway
-	listeners map[v1.SectionName][]v1.Listener
+// gwDebounce returns a function that coalesces all calls made within the given window into a
+// single call of handler at the end of the window. A non-positive window disables debouncing.
+func gwDebounce(ctx context.Context, handler func(), window time.Duration) func() {
+	if window <= 0 {
+		return handler
+	}
+	var (
+		mu      sync.Mutex
+		pending bool
+	)
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if pending {
+			return
+		}
+		pending = true
+		time.AfterFunc(window, func() {
+			mu.Lock()
+			pending = false
+			mu.Unlock()
+			if ctx.Err() == nil {
+				handler()
+			}
+		})
+	}
 }
 
-func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gateway, namespaces []*corev1.Namespace) *gatewayRouteResolver {
-	// Create Gateway Listener lookup table.
-	gws := make(map[types.NamespacedName]gatewayListeners, len(gateways))
-	for _, gw := range gateways {
-		lss := make(map[v1.SectionName][]v1.Listener, len(gw.Spec.Listeners)+1)
-		for i, lis := range gw.Spec.Listeners {
-			lss[lis.Name] = gw.Spec.Listeners[i : i+1]
+// listWithBackoff lists the resources of the source. Transient errors, i.e. informers that haven't
+// synced yet, are retried with the configured backoff before they're returned.
+func (src *gatewayRouteSource) listWithBackoff(ctx context.Context) ([]gatewayRoute, *gatewayRouteResolver, error) {
+	backoff := src.listBackoff
+	for {
+		routes, resolver, err := src.list(ctx)
+		if err == nil {
+			return routes, resolver, nil
 		}
-		lss[""] = gw.Spec.Listeners
-		gws[namespacedName(gw.Namespace, gw.Name)] = gatewayListeners{
-			gateway:   gw,
-			listeners: lss,
+		if !errors.Is(err, errGatewayNotSynced) {
+			gatewayListErrorsTotal.CounterVec.WithLabelValues(src.rtKind, gwListErrorFatal).Inc()
+			return nil, nil, err
+		}
+		gatewayListErrorsTotal.CounterVec.WithLabelValues(src.rtKind, gwListErrorTransient).Inc()
+		if backoff.Steps <= 0 {
+			return nil, nil, err
+		}
+		delay := backoff.Step()
+		log.Debugf("Retrying to list %ss in %s: %v", src.rtKind, delay, err)
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(delay):
 		}
 	}
-	// Create Namespace lookup table.
-	nss := make(map[string]*corev1.Namespace, len(namespaces))
-	for _, ns := range namespaces {
-		nss[ns.Name] = ns
+}
+
+// list returns the Routes and a resolver for the current state of the informers, or
+// errGatewayNotSynced if any of them hasn't synced yet.
+func (src *gatewayRouteSource) list(ctx context.Context) ([]gatewayRoute, *gatewayRouteResolver, error) {
+	if err := src.synced(); err != nil {
+		return nil, nil, err
+	}
+	// Take the cache view before listing, so that entries built from stale listings are discarded.
+	gwCache := src.gwCache.view()
+	routes, err := src.rtInformer.List(src.rtNamespace, src.rtLabels)
+	if err != nil {
+		return nil, nil, err
+	}
+	gateways, err := src.gwInformer.Lister().Gateways(src.gwNamespace).List(src.gwLabels)
+	if err != nil {
+		return nil, nil, err
 	}
-	return &gatewayRouteResolver{
-		src: src,
-		gws: gws,
-		nss: nss,
+	resolver, err := src.resolver(ctx, gwCache, routes, gateways)
+	if err != nil {
+		return nil, nil, err
 	}
+	return routes, resolver, nil
 }
 
-func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Targets, error) {
-	rtHosts, err := c.hosts(rt)
+// resolver returns a resolver of the Routes against the Gateways for the current state of the
+// informers, which must have synced.
+func (src *gatewayRouteSource) resolver(ctx context.Context, gwCache gatewayListenersCacheView, routes []gatewayRoute, gateways []*v1beta1.Gateway) (*gatewayRouteResolver, error) {
+	nsInformer, err := src.namespaceInformer(ctx, gateways)
 	if err != nil {
 		return nil, err
 	}
-	hostTargets := make(map[string]endpoint.Targets)
-
-	routeParentRefs := rt.ParentRefs()
-
-	if len(routeParentRefs) == 0 {
-		log.Debugf("No parent references found for %s %s/%s", c.src.rtKind, rt.Metadata().Namespace, rt.Metadata().Name)
-		return hostTargets, nil
-	}
-
-	meta := rt.Metadata()
-	for _, rps := range rt.RouteStatus().Parents {
-		// Confirm the Parent is the standard Gateway kind.
-		ref := rps.ParentRef
-		namespace := strVal((*string)(ref.Namespace), meta.Namespace)
-		// Ensure that the parent reference is in the routeParentRefs list
-		if !gwRouteHasParentRef(routeParentRefs, ref, meta) {
-			log.Debugf("Parent reference %s/%s not found in routeParentRefs for %s %s/%s", namespace, string(ref.Name), c.src.rtKind, meta.Namespace, meta.Name)
-			continue
+	var namespaces []*corev1.Namespace
+	if nsInformer != nil {
+		namespaces, err = nsInformer.Lister().List(labels.Everything())
+		if err != nil {
+			return nil, err
 		}
-
-		group := strVal((*string)(ref.Group), gatewayGroup)
-		kind := strVal((*string)(ref.Kind), gatewayKind)
-		if group != gatewayGroup || kind != gatewayKind {
-			log.Debugf("Unsupported parent %s/%s for %s %s/%s", group, kind, c.src.rtKind, meta.Namespace, meta.Name)
-			continue
+	}
+	var grants []*v1beta1.ReferenceGrant
+	if src.rgInformer != nil {
+		grants, err = src.rgInformer.Lister().List(labels.Everything())
+		if err != nil {
+			return nil, err
 		}
-		// Lookup the Gateway and its Listeners.
-		gw, ok := c.gws[namespacedName(namespace, string(ref.Name))]
-		if !ok {
-			log.Debugf("Gateway %s/%s not found for %s %s/%s", namespace, ref.Name, c.src.rtKind, meta.Namespace, meta.Name)
-			continue
+	}
+	var nodes []*corev1.Node
+	if src.ndInformer != nil {
+		nodes, err = src.ndInformer.Lister().List(labels.Everything())
+		if err != nil {
+			return nil, err
 		}
-		// Confirm the Gateway has the correct name, if specified.
-		if c.src.gwName != "" && c.src.gwName != gw.gateway.Name {
-			log.Debugf("Gateway %s/%s does not match %s %s/%s", namespace, ref.Name, c.src.gwName, meta.Namespace, meta.Name)
-			continue
+	}
+	esInformers, err := src.endpointSliceInformers(ctx, routes)
+	if err != nil {
+		return nil, err
+	}
+	var classTargets map[string]endpoint.Targets
+	if src.gcInformer != nil {
+		classTargets, err = src.gatewayClassTargets(ctx, gateways)
+		if err != nil {
+			return nil, err
 		}
+	}
+	resolver := newGatewayRouteResolver(src, gwCache, gateways, namespaces, grants, nodes, classTargets)
+	resolver.esInformers = esInformers
+	return resolver, nil
+}
 
-		// Confirm the Gateway has accepted the Route.
-		if !gwRouteIsAccepted(rps.Conditions) {
-			log.Debugf("Gateway %s/%s has not accepted the current generation %s %s/%s", namespace, ref.Name, c.src.rtKind, meta.Namespace, meta.Name)
-			continue
+// endpointSliceInformers returns the EndpointSlice informers of the namespaces of the Services that
+// the Routes reference as backends, if the backend zone is configured. Informers of namespaces that
+// no Route referenced before are started, and their caches are awaited without holding esMu.
+func (src *gatewayRouteSource) endpointSliceInformers(ctx context.Context, routes []gatewayRoute) (map[string]discoveryinformers.EndpointSliceInformer, error) {
+	if src.esClient == nil {
+		return nil, nil
+	}
+	esInformers := make(map[string]discoveryinformers.EndpointSliceInformer)
+	src.esMu.Lock()
+	for _, rt := range routes {
+		for _, ref := range rt.BackendRefs() {
+			if strVal((*string)(ref.Group), "") != "" || strVal((*string)(ref.Kind), "Service") != "Service" {
+				continue
+			}
+			namespace := strVal((*string)(ref.Namespace), rt.Metadata().Namespace)
+			if _, ok := esInformers[namespace]; ok {
+				continue
+			}
+			esInformer, ok := src.esInformers[namespace]
+			if !ok {
+				log.Debugf("Watching EndpointSlices in namespace %s for the backends of %ss", namespace, src.rtKind)
+				factory := kubeinformers.NewSharedInformerFactoryWithOptions(src.esClient, 0, kubeinformers.WithNamespace(namespace))
+				esInformer = factory.Discovery().V1().EndpointSlices()
+				// Calling Informer registers it with the factory, so it must precede Start.
+				informer := esInformer.Informer()
+				if src.esHandler != nil {
+					informer.AddEventHandler(src.esHandler)
+				}
+				factory.Start(src.stopCh)
+				if src.esInformers == nil {
+					src.esInformers = make(map[string]discoveryinformers.EndpointSliceInformer)
+				}
+				src.esInformers[namespace] = esInformer
+			}
+			esInformers[namespace] = esInformer
+		}
+	}
+	src.esMu.Unlock()
+	for _, esInformer := range esInformers {
+		if !cache.WaitForCacheSync(ctx.Done(), esInformer.Informer().HasSynced) {
+			return nil, ctx.Err()
+		}
+	}
+	return esInformers, nil
+}
+
+// namespaceInformer returns the Namespace informer. If it's started on demand, it's started once
+// any of the Gateways has a Listener that selects Routes by namespace labels, and nil is returned
+// until then. Its cache is awaited without holding nsMu, so that concurrent lists don't block on it.
+func (src *gatewayRouteSource) namespaceInformer(ctx context.Context, gateways []*v1beta1.Gateway) (coreinformers.NamespaceInformer, error) {
+	src.nsMu.Lock()
+	nsInformer := src.nsInformer
+	if nsInformer == nil && src.nsFactory != nil && slices.ContainsFunc(gateways, gwSelectsNamespaces) {
+		log.Infof("Watching Namespaces for %ss because a Gateway Listener selects Routes by namespace labels", src.rtKind)
+		nsInformer = src.nsFactory.Core().V1().Namespaces()
+		// Calling Informer registers it with the factory, so it must precede Start.
+		informer := nsInformer.Informer()
+		if src.nsHandler != nil {
+			informer.AddEventHandler(src.nsHandler)
+		}
+		src.nsFactory.Start(src.stopCh)
+		src.nsInformer = nsInformer
+	}
+	src.nsMu.Unlock()
+	if nsInformer == nil {
+		return nil, nil
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), nsInformer.Informer().HasSynced) {
+		return nil, ctx.Err()
+	}
+	return nsInformer, nil
+}
+
+// gwSelectsNamespaces returns whether any Listener of the Gateway selects Routes by namespace labels.
+func gwSelectsNamespaces(gw *v1beta1.Gateway) bool {
+	return slices.ContainsFunc(gw.Spec.Listeners, func(lis v1.Listener) bool {
+		allow := lis.AllowedRoutes
+		return allow != nil && allow.Namespaces != nil && allow.Namespaces.From != nil && *allow.Namespaces.From == v1.NamespacesFromSelector
+	})
+}
+
+// filterReason returns why the Route is filtered out or ignored by its annotations, if it is.
+func (src *gatewayRouteSource) filterReason(rt gatewayRoute) string {
+	meta := rt.Metadata()
+	annots := meta.Annotations
+	// Filter by annotations.
+	if !src.rtAnnotations.Matches(labels.Set(annots)) {
+		return gwSkipAnnotationFilter
+	}
+	// Check controller annotation to see if we are responsible.
+	if v, ok := annots[controllerAnnotationKey]; ok && v != src.controllerValue {
+		src.routeLog(rt).Debugf("Skipping %s %s/%s because controller value does not match, found: %s, required: %s",
+			src.rtKind, meta.Namespace, meta.Name, v, src.controllerValue)
+		return gwSkipControllerMismatch
+	}
+	// The ignore annotation pauses the Route without removing its other annotations.
+	if annots[annotations.IgnoreKey] == "true" {
+		src.routeLog(rt).Debugf("Skipping %s %s/%s because it is ignored", src.rtKind, meta.Namespace, meta.Name)
+		return gwSkipIgnored
+	}
+	return ""
+}
+
+func (src *gatewayRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := src.endpoints(ctx)
+	src.readiness.observe(err)
+	return endpoints, err
+}
+
+func (src *gatewayRouteSource) endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	routes, resolver, err := src.listWithBackoff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	routeEndpoints, routeErrs, err := src.resolveRoutes(ctx, resolver, routes)
+	if err != nil {
+		return nil, err
+	}
+	src.events.forget(routes)
+	src.lastEndpoints.forget(routes)
+	endpoints := append(src.joinRouteEndpoints(routes, routeEndpoints, routeErrs), src.deletionGrace.endpoints(time.Now(), src.rtKind, routes, routeEndpoints)...)
+	return gwMergeEndpoints(endpoints), nil
+}
+
+// resolveRoutes returns the endpoints of the Routes, or the errors that prevented them from generating
+// any. Routes are resolved by a bounded number of workers. The lookup tables of the resolver are only
+// read, and each worker stores the result of a Route at the Route's index, so that the result doesn't
+// depend on the order in which Routes are resolved.
+func (src *gatewayRouteSource) resolveRoutes(ctx context.Context, resolver *gatewayRouteResolver, routes []gatewayRoute) ([][]*endpoint.Endpoint, []error, error) {
+	routeEndpoints := make([][]*endpoint.Endpoint, len(routes))
+	routeErrs := make([]error, len(routes))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(src.resolveConcurrency, 1))
+	for i, rt := range routes {
+		// Stop early if the caller is no longer interested in the result.
+		if gctx.Err() != nil {
+			break
+		}
+		if src.filterReason(rt) != "" {
+			continue
+		}
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			endpoints, skipReason, err := src.routeEndpoints(ctx, resolver, rt)
+			src.events.observe(rt, endpoints, skipReason, err)
+			routeEndpoints[i], routeErrs[i] = endpoints, err
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	return routeEndpoints, routeErrs, nil
+}
+
+// joinRouteEndpoints returns the endpoints of all Routes. Errors of a single Route don't block the
+// endpoints of all others: the Route keeps the endpoints it last generated, so that the sync policy
+// doesn't delete its records, or is skipped if it hasn't generated any since ExternalDNS started.
+func (src *gatewayRouteSource) joinRouteEndpoints(routes []gatewayRoute, routeEndpoints [][]*endpoint.Endpoint, routeErrs []error) []*endpoint.Endpoint {
+	endpoints, kept, skipped := src.lastEndpoints.join(routes, routeEndpoints, routeErrs)
+	for _, err := range slices.Concat(kept, skipped) {
+		src.routeError(err)
+	}
+	if len(kept) > 0 {
+		log.Warnf("Kept the previous endpoints of %d %ss that failed to generate endpoints: %v", len(kept), src.rtKind, errors.Join(kept...))
+	}
+	if len(skipped) > 0 {
+		log.Warnf("Skipped %d %ss that failed to generate endpoints: %v", len(skipped), src.rtKind, errors.Join(skipped...))
+	}
+	return endpoints
+}
+
+// routeEndpoints returns the endpoints of the Route or, if there are none, the reason why the Route
+// was skipped. It's safe for concurrent use.
+func (src *gatewayRouteSource) routeEndpoints(ctx context.Context, resolver *gatewayRouteResolver, rt gatewayRoute) ([]*endpoint.Endpoint, string, error) {
+	meta := rt.Metadata()
+	annots := meta.Annotations
+
+	// Get Route hostnames and their targets.
+	hostTargets, err := resolver.resolve(ctx, rt)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(hostTargets) == 0 {
+		src.routeLog(rt).Debugf("No endpoints could be generated from %s %s/%s", src.rtKind, meta.Namespace, meta.Name)
+		return nil, gwSkipNoMatchingParent, nil
+	}
+
+	// Create endpoints from hostnames and targets.
+	var routeEndpoints []*endpoint.Endpoint
+	resource := fmt.Sprintf("%s/%s/%s", strings.ToLower(src.rtKind), meta.Namespace, meta.Name)
+	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(annots)
+	setIdentifier, err = src.renderSetIdentifier(rt, setIdentifier)
+	if err != nil {
+		return nil, "", err
+	}
+	resourceLabel, err := src.renderResource(rt, resource)
+	if err != nil {
+		return nil, "", err
+	}
+	failoverProps, setIdentifier := gwFailoverProperties(annots, providerSpecific, setIdentifier, resource, src.routingPolicy)
+	providerSpecific = append(providerSpecific, failoverProps...)
+	// Failover records can't be weighted as well.
+	if weight, ok := gwBackendWeight(rt.BackendRefs()); ok && src.weightedTargets && len(failoverProps) == 0 {
+		if setIdentifier == "" {
+			src.routeLog(rt).Debugf("Ignoring backend weights of %s %s/%s without a set identifier", src.rtKind, meta.Namespace, meta.Name)
+		} else {
+			providerSpecific = append(providerSpecific, endpoint.ProviderSpecificProperty{
+				Name:  src.routingPolicy.weight,
+				Value: strconv.FormatInt(weight, 10),
+			})
+		}
+	}
+	providerSpecific = append(providerSpecific, gwGeoProperties(annots, providerSpecific, setIdentifier, resource, src.routingPolicy)...)
+	providerSpecific = append(providerSpecific, gwCommentProperties(annots, providerSpecific, resource)...)
+	_, rtAlias := annots[aliasAnnotationKey]
+	rtTTL := annotations.TTLFromAnnotations(annots, resource)
+	forcedType := annotations.RecordTypeFromAnnotations(annots, resource)
+	nameservers := gwNameservers(annots, resource)
+	for host, h := range hostTargets {
+		// The Route's TTL always takes precedence over the TTL of its Gateways, and the TTL of its
+		// hostname overrides over the Route's TTL.
+		ttl := rtTTL
+		if h.routeTTL.IsConfigured() {
+			ttl = h.routeTTL
+		}
+		if !ttl.IsConfigured() {
+			ttl = h.ttl
+		} else if h.ttl.IsConfigured() && h.ttl != ttl {
+			gatewayTTLConflictsTotal.CounterVec.WithLabelValues(src.rtKind).Inc()
+			src.metaLog(meta).gateway(h.ttlGateway.Namespace, h.ttlGateway.Name).logf(log.DebugLevel, log.Fields{
+				"host":       host,
+				"routeTTL":   int64(ttl),
+				"gatewayTTL": int64(h.ttl),
+			}, "Conflicting TTLs of %s and gateway/%s, using %d of the Route", resource, h.ttlGateway, ttl)
+		}
+		// The default TTL of the Route's namespace only applies if neither specifies one.
+		if !ttl.IsConfigured() {
+			ttl = src.nsTTLs[meta.Namespace]
+		}
+		// Likewise, the Route's provider-specific and alias annotations take precedence over those of its Gateways.
+		hostProviderSpecific := gwMergeProviderSpecific(providerSpecific, h.providerSpecific)
+		if h.alias && !rtAlias {
+			hostProviderSpecific = append(slices.Clone(hostProviderSpecific), endpoint.ProviderSpecificProperty{
+				Name:  gatewayAliasProperty,
+				Value: "true",
+			})
+		}
+		var hostEndpoints []*endpoint.Endpoint
+		var records []gatewayHostRecords
+		switch {
+		// Delegated hosts are only published as NS records, since other records can't share their name.
+		case len(nameservers) > 0 && strings.HasPrefix(host, "*."):
+			src.routeLog(rt).Warnf("Skipping wildcard host %s of %s %s/%s, which can't be delegated", host, src.rtKind, meta.Namespace, meta.Name)
+		case len(nameservers) > 0:
+			if ep := endpointForHostnameAndType(host, endpoint.RecordTypeNS, nameservers, ttl, nil, "", resourceLabel); ep != nil {
+				hostEndpoints = append(hostEndpoints, ep)
+			}
+		default:
+			// SRV records aren't aliases, so they don't request alias records either.
+			records = []gatewayHostRecords{{
+				targets:                 src.transformTargets(host, h.targets, meta),
+				providerSpecific:        hostProviderSpecific,
+				serviceProviderSpecific: gwMergeProviderSpecific(providerSpecific, h.providerSpecific),
+				setIdentifier:           setIdentifier,
+			}}
+			if weighted := src.weightedRecords(rt, host, h, records[0]); len(weighted) > 0 {
+				records = weighted
+			}
+		}
+		for _, r := range records {
+			for recordType, tgts := range r.targets.withRecordType(forcedType) {
+				if ep := endpointForHostnameAndType(host, recordType, tgts, ttl, r.providerSpecific, r.setIdentifier, resourceLabel); ep != nil {
+					hostEndpoints = append(hostEndpoints, ep)
+				}
+			}
+			for _, svc := range h.services {
+				if ep := gwServiceEndpoint(host, svc, r.targets, ttl, r.serviceProviderSpecific, r.setIdentifier, resourceLabel); ep != nil {
+					hostEndpoints = append(hostEndpoints, ep)
+				}
+			}
+		}
+		for _, ep := range hostEndpoints {
+			if src.routeLabels {
+				ep.WithLabel(endpoint.RouteKindLabelKey, src.rtKind).
+					WithLabel(endpoint.RouteNamespaceLabelKey, meta.Namespace).
+					WithLabel(endpoint.RouteNameLabelKey, meta.Name)
+			}
+			if h.provisional {
+				ep.WithLabel(endpoint.ProvisionalLabelKey, "true")
+			}
+			if h.fallback {
+				ep.WithLabel(endpoint.FallbackLabelKey, "true")
+			}
+		}
+		routeEndpoints = append(routeEndpoints, hostEndpoints...)
+	}
+	if src.createPTR {
+		routeEndpoints = append(routeEndpoints, gwPTREndpoints(routeEndpoints)...)
+	}
+	src.routeLog(rt).Debugf("Endpoints generated from %s %s/%s: %v", src.rtKind, meta.Namespace, meta.Name, routeEndpoints)
+
+	if len(routeEndpoints) == 0 {
+		return nil, gwSkipNoTargets, nil
+	}
+	// Guard the provider against runaway templates or hostname lists, if limited.
+	if src.maxRouteEndpoints > 0 && len(routeEndpoints) > src.maxRouteEndpoints {
+		src.routeLog(rt).Errorf("Skipping %s %s/%s because its %d endpoints exceed the maximum of %d", src.rtKind, meta.Namespace, meta.Name, len(routeEndpoints), src.maxRouteEndpoints)
+		gatewayRouteSkipsTotal.CounterVec.WithLabelValues(src.rtKind, gwSkipTooManyEndpoints).Inc()
+		return nil, gwSkipTooManyEndpoints, nil
+	}
+	return routeEndpoints, "", nil
+}
+
+// gwNameservers returns the nameservers of the Route's nameservers annotation, a comma-separated list
+// of hostnames. Invalid hostnames, including IP addresses, are skipped with a warning.
+func gwNameservers(annots map[string]string, resource string) endpoint.Targets {
+	value, ok := annots[annotations.NameserversKey]
+	if !ok {
+		return nil
+	}
+	var nameservers endpoint.Targets
+	for _, ns := range strings.Split(value, ",") {
+		if ns = gwCanonicalHost(strings.TrimSpace(ns)); ns == "" {
+			continue
+		}
+		if isIPAddr(ns) || !isDNS1123Domain(ns) {
+			log.Warnf("Ignoring invalid nameserver %q of %s, expected a hostname", ns, resource)
+			continue
+		}
+		if !slices.Contains(nameservers, ns) {
+			nameservers = append(nameservers, ns)
+		}
+	}
+	return nameservers
+}
+
+// gatewayHostRecords holds the targets and properties of the records of a host.
+type gatewayHostRecords struct {
+	targets                 gatewayTargets
+	providerSpecific        endpoint.ProviderSpecific
+	serviceProviderSpecific endpoint.ProviderSpecific
+	setIdentifier           string
+}
+
+// weightedRecords returns the records of the host per Gateway with a weight, each with the targets
+// of that Gateway, its weight, and the Gateway's namespace and name appended to the set identifier.
+// Returns nil if the host isn't weighted, because Gateways without a weight publish it as well, its
+// records select another routing policy, or none of the Gateways' targets remain, e.g. because the
+// Route overrides them. Since this is evaluated at every sync, ignored weights are logged at debug level.
+func (src *gatewayRouteSource) weightedRecords(rt gatewayRoute, host string, h *gatewayHost, records gatewayHostRecords) []gatewayHostRecords {
+	if len(h.weighted) == 0 {
+		return nil
+	}
+	meta := rt.Metadata()
+	if h.unweighted {
+		src.routeLog(rt).Debugf("Ignoring Gateway weights of host %s of %s %s/%s, which Gateways without a weight publish as well", host, src.rtKind, meta.Namespace, meta.Name)
+		return nil
+	}
+	if i := slices.IndexFunc(records.providerSpecific, func(p endpoint.ProviderSpecificProperty) bool {
+		return slices.Contains(src.routingPolicy.properties(), p.Name)
+	}); i >= 0 {
+		src.routeLog(rt).Debugf("Ignoring Gateway weights of host %s of %s %s/%s because it sets the provider-specific property %s", host, src.rtKind, meta.Namespace, meta.Name, records.providerSpecific[i].Name)
+		return nil
+	}
+	keys := slices.SortedFunc(maps.Keys(h.weighted), func(a, b types.NamespacedName) int {
+		return cmp.Compare(a.String(), b.String())
+	})
+	var weighted []gatewayHostRecords
+	for _, key := range keys {
+		w := h.weighted[key]
+		// Only the targets the host still has are published, e.g. after filtering its IP families.
+		kept := make(gatewayTargets, len(w.targets))
+		for recordType, tgts := range w.targets {
+			for _, target := range tgts {
+				if slices.Contains(h.targets[recordType], target) {
+					kept[recordType] = append(kept[recordType], target)
+				}
+			}
+		}
+		targets := src.transformTargets(host, kept, meta)
+		if len(targets) == 0 {
+			continue
+		}
+		weight := endpoint.ProviderSpecificProperty{Name: src.routingPolicy.weight, Value: strconv.FormatInt(w.weight, 10)}
+		setIdentifier := key.Namespace + "-" + key.Name
+		if records.setIdentifier != "" {
+			setIdentifier = records.setIdentifier + "-" + setIdentifier
+		}
+		weighted = append(weighted, gatewayHostRecords{
+			targets:                 targets,
+			providerSpecific:        append(slices.Clone(records.providerSpecific), weight),
+			serviceProviderSpecific: append(slices.Clone(records.serviceProviderSpecific), weight),
+			setIdentifier:           setIdentifier,
+		})
+	}
+	return weighted
+}
+
+// gwServiceEndpoint returns the SRV record of the service for the host, e.g. _ldap._tcp.example.com
+// with the target "0 0 389 example.com". Since the target of an SRV record must not be an alias, it
+// points at the hostname targets of the host, if any, instead of the host itself. Wildcard hosts
+// have no SRV records.
+func gwServiceEndpoint(host string, svc gatewayService, targets gatewayTargets, ttl endpoint.TTL, providerSpecific endpoint.ProviderSpecific, setIdentifier, resource string) *endpoint.Endpoint {
+	if strings.HasPrefix(host, "*.") {
+		return nil
+	}
+	srvHosts := targets[endpoint.RecordTypeCNAME]
+	if len(srvHosts) == 0 {
+		srvHosts = endpoint.Targets{host}
+	}
+	srvTargets := make(endpoint.Targets, 0, len(srvHosts))
+	for _, srvHost := range srvHosts {
+		srvTargets = append(srvTargets, fmt.Sprintf("0 0 %d %s", svc.port, srvHost))
+	}
+	name := fmt.Sprintf("_%s._%s.%s", svc.name, svc.protocol, host)
+	return endpointForHostnameAndType(name, endpoint.RecordTypeSRV, srvTargets, ttl, providerSpecific, setIdentifier, resource)
+}
+
+// routeError counts an error that prevented a Route from generating endpoints and returns it.
+func (src *gatewayRouteSource) routeError(err error) error {
+	gatewayRouteErrorsTotal.CounterVec.WithLabelValues(src.rtKind).Inc()
+	return err
+}
+
+// gwProviderSpecific returns the provider-specific properties of the Gateway's annotations, sorted by
+// name. Alias records are requested separately, and set identifiers only apply to Routes.
+func gwProviderSpecific(annots map[string]string) endpoint.ProviderSpecific {
+	providerSpecific, _ := annotations.ProviderSpecificAnnotations(annots)
+	providerSpecific = slices.DeleteFunc(providerSpecific, func(p endpoint.ProviderSpecificProperty) bool {
+		return p.Name == gatewayAliasProperty
+	})
+	slices.SortFunc(providerSpecific, func(a, b endpoint.ProviderSpecificProperty) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return providerSpecific
+}
+
+// gwMergeProviderSpecific returns the provider-specific properties with those of extra appended,
+// unless a property of the same name is already set.
+func gwMergeProviderSpecific(providerSpecific, extra endpoint.ProviderSpecific) endpoint.ProviderSpecific {
+	// Clipping ensures that appending doesn't modify the properties shared with other hosts.
+	merged := slices.Clip(providerSpecific)
+	for _, p := range extra {
+		if !slices.ContainsFunc(merged, func(q endpoint.ProviderSpecificProperty) bool { return q.Name == p.Name }) {
+			merged = append(merged, p)
+		}
+	}
+	return merged
+}
+
+// gwPTREndpoints returns PTR endpoints that map the reverse names of the IP targets of the given
+// A and AAAA endpoints to their DNS names, with the same TTL and labels. Wildcard DNS names are skipped.
+func gwPTREndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	var ptrs []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeA && ep.RecordType != endpoint.RecordTypeAAAA {
+			continue
+		}
+		if strings.HasPrefix(ep.DNSName, "*.") {
+			continue
+		}
+		for _, target := range ep.Targets {
+			reverse, err := dns.ReverseAddr(target)
+			if err != nil {
+				log.Debugf("Skipping PTR record of %s target %s: %v", ep.DNSName, target, err)
+				continue
+			}
+			ptr := endpoint.NewEndpointWithTTL(strings.TrimSuffix(reverse, "."), endpoint.RecordTypePTR, ep.RecordTTL, ep.DNSName)
+			if ptr == nil {
+				continue
+			}
+			ptr.Labels = maps.Clone(ep.Labels)
+			ptrs = append(ptrs, ptr)
+		}
+	}
+	return ptrs
+}
+
+// gatewayRouteTemplateData is the data that the set identifier and resource templates are rendered
+// against. The metadata of the Route is embedded, so that e.g. {{ .Name }} refers to the Route's name.
+type gatewayRouteTemplateData struct {
+	*metav1.ObjectMeta
+	// Kind is the kind of the Route.
+	Kind string
+	// Cluster is the name of the cluster given by the configuration.
+	Cluster string
+	// SetIdentifier is the set identifier of the Route's annotation, if any. It's only set for the
+	// set identifier template.
+	SetIdentifier string
+	// Route is the Route itself.
+	Route kubeObject
+}
+
+// renderSetIdentifier returns the set identifier rendered by the set identifier template, if any,
+// or the given set identifier of the Route's annotation.
+func (src *gatewayRouteSource) renderSetIdentifier(rt gatewayRoute, setIdentifier string) (string, error) {
+	if src.setIdentifierTemplate == nil {
+		return setIdentifier, nil
+	}
+	meta := rt.Metadata()
+	var buf strings.Builder
+	err := src.setIdentifierTemplate.Execute(&buf, gatewayRouteTemplateData{
+		ObjectMeta:    meta,
+		Kind:          src.rtKind,
+		Cluster:       src.clusterName,
+		SetIdentifier: setIdentifier,
+		Route:         rt.Object(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to apply set identifier template on %s %s/%s: %w", src.rtKind, meta.Namespace, meta.Name, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// renderResource returns the resource label of the Route's records rendered by the resource template,
+// if any, or the given default resource. Since the TXT registry persists the labels as comma-separated
+// KEY=VALUE pairs, the rendered resource must not be empty or contain commas, equals signs, quotes,
+// or whitespace.
+func (src *gatewayRouteSource) renderResource(rt gatewayRoute, resource string) (string, error) {
+	if src.resourceTemplate == nil {
+		return resource, nil
+	}
+	meta := rt.Metadata()
+	var buf strings.Builder
+	err := src.resourceTemplate.Execute(&buf, gatewayRouteTemplateData{
+		ObjectMeta: meta,
+		Kind:       src.rtKind,
+		Cluster:    src.clusterName,
+		Route:      rt.Object(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to apply resource template on %s %s/%s: %w", src.rtKind, meta.Namespace, meta.Name, err)
+	}
+	rendered := strings.TrimSpace(buf.String())
+	if rendered == "" || strings.ContainsFunc(rendered, func(r rune) bool {
+		return r == ',' || r == '=' || r == '"' || unicode.IsSpace(r)
+	}) {
+		return "", fmt.Errorf("resource template rendered the invalid resource %q for %s %s/%s", rendered, src.rtKind, meta.Namespace, meta.Name)
+	}
+	return rendered, nil
+}
+
+// transformTargets applies the target transformer to the targets of a Route's host. Transformed
+// targets are published with the record type suitable for their new value.
+func (src *gatewayRouteSource) transformTargets(host string, targets gatewayTargets, meta *metav1.ObjectMeta) gatewayTargets {
+	transform := src.transformTarget
+	if transform == nil {
+		transform = gatewayNoopTargetTransformer
+	}
+	result := make(gatewayTargets, len(targets))
+	for recordType, tgts := range targets {
+		for _, target := range tgts {
+			transformed, ok := transform(host, target, meta)
+			if !ok {
+				src.metaLog(meta).logf(log.DebugLevel, log.Fields{"host": host, "target": target},
+					"Dropping target %s of host %s of %s %s/%s", target, host, src.rtKind, meta.Namespace, meta.Name)
+				continue
+			}
+			if transformed != target {
+				recordType := suitableType(transformed)
+				result[recordType] = append(result[recordType], transformed)
+				continue
+			}
+			result[recordType] = append(result[recordType], target)
+		}
+	}
+	for recordType, tgts := range result {
+		result[recordType] = uniqueTargets(tgts)
+	}
+	return result
+}
+
+// gwMergeEndpoints merges the endpoints of Routes that share the same DNS name, record type,
+// and set identifier by unioning their targets. If their TTLs or provider-specific properties
+// conflict, the endpoint of the Route with the lowest resource label wins. The result is sorted by
+// DNS name, record type, and set identifier, so that it's stable across calls.
+func gwMergeEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		return endpoints[i].Labels[endpoint.ResourceLabelKey] < endpoints[j].Labels[endpoint.ResourceLabelKey]
+	})
+	merged := make(map[endpoint.EndpointKey]*endpoint.Endpoint, len(endpoints))
+	result := endpoints[:0]
+	for _, ep := range endpoints {
+		key := ep.Key()
+		m, ok := merged[key]
+		if !ok {
+			merged[key] = ep
+			result = append(result, ep)
+			continue
+		}
+		winner, loser := m.Labels[endpoint.ResourceLabelKey], ep.Labels[endpoint.ResourceLabelKey]
+		if m.RecordTTL != ep.RecordTTL {
+			log.Warnf("Conflicting TTLs for %s record %s from %s and %s, using %d of %s", m.RecordType, m.DNSName, winner, loser, m.RecordTTL, winner)
+		}
+		if !slices.Equal(m.ProviderSpecific, ep.ProviderSpecific) {
+			log.Warnf("Conflicting provider-specific properties for %s record %s from %s and %s, using those of %s", m.RecordType, m.DNSName, winner, loser, winner)
+		}
+		m.Targets = uniqueTargets(append(m.Targets, ep.Targets...))
+	}
+	slices.SortFunc(result, func(a, b *endpoint.Endpoint) int {
+		return cmp.Or(
+			strings.Compare(a.DNSName, b.DNSName),
+			strings.Compare(a.RecordType, b.RecordType),
+			strings.Compare(a.SetIdentifier, b.SetIdentifier),
+		)
+	})
+	return result
+}
+
+func namespacedName(namespace, name string) types.NamespacedName {
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+type gatewayRouteResolver struct {
+	src   *gatewayRouteSource
+	gws   map[types.NamespacedName]gatewayListeners
+	nss   map[string]*corev1.Namespace
+	rgs   map[string][]*v1beta1.ReferenceGrant
+	nodes []*corev1.Node
+	// nds maps the addresses of Nodes to their Nodes.
+	nds map[string]*corev1.Node
+	// nsGws lists the names of the Gateways in each namespace, sorted by name.
+	nsGws map[string][]string
+	// gcTargets holds the default targets of GatewayClasses by name.
+	gcTargets map[string]endpoint.Targets
+	// esInformers holds the EndpointSlice informers of the namespaces of the Routes' backends.
+	esInformers map[string]discoveryinformers.EndpointSliceInformer
+	// hostAddrs memoizes the lookups of hostname targets. It's guarded by hostMu, while the lookups
+	// themselves run outside of it, so that looking up one hostname doesn't block the others.
+	hostMu    sync.Mutex
+	hostAddrs map[string]*gatewayHostLookup
+	// report collects the diagnostics of the Route being resolved, if validating.
+	report *GatewayRouteReport
+}
+
+type gatewayListeners struct {
+	gateway   *v1beta1.Gateway
+	listeners map[v1.SectionName][]v1.Listener
+	// ttl is the TTL of the Gateway's annotations.
+	ttl endpoint.TTL
+	// listenerTTLs holds the TTLs of the Gateway's annotations that override ttl for individual Listeners.
+	listenerTTLs map[string]endpoint.TTL
+	// listenerAddrs holds the addresses of the Gateway's annotations that individual Listeners bind to.
+	listenerAddrs map[string][]string
+	// excludedHosts holds the hostnames of the Gateway's annotations that its Listeners don't publish.
+	excludedHosts []string
+	// alias is true if the Gateway's annotations request alias records.
+	alias bool
+	// certHosts holds the certificate SANs of Listeners that don't specify a hostname.
+	certHosts map[v1.SectionName][]string
+	// defaultHost is the hostname of the Gateway's annotations that Listeners without one default to.
+	defaultHost string
+	// providerSpecific holds the provider-specific properties of the Gateway's annotations.
+	providerSpecific endpoint.ProviderSpecific
+	// weight is the weight of the Gateway's annotation, if weighted is true.
+	weight   int64
+	weighted bool
+}
+
+// listenerTTL returns the TTL of the Listener's hosts, which defaults to the TTL of the Gateway.
+func (gw gatewayListeners) listenerTTL(lis *v1.Listener) endpoint.TTL {
+	if ttl, ok := gw.listenerTTLs[string(lis.Name)]; ok {
+		return ttl
+	}
+	return gw.ttl
+}
+
+// listenerHosts returns the hostnames that Routes attached to the Listener must overlap.
+// An empty hostname matches any Route hostname.
+func (gw gatewayListeners) listenerHosts(lis *v1.Listener) []string {
+	if lis.Hostname != nil {
+		return []string{string(*lis.Hostname)}
+	}
+	if gw.defaultHost != "" {
+		return []string{gw.defaultHost}
+	}
+	if hosts, ok := gw.certHosts[lis.Name]; ok {
+		return hosts
+	}
+	return []string{""}
+}
+
+func newGatewayRouteResolver(src *gatewayRouteSource, gwCache gatewayListenersCacheView, gateways []*v1beta1.Gateway, namespaces []*corev1.Namespace, grants []*v1beta1.ReferenceGrant, nodes []*corev1.Node, classTargets map[string]endpoint.Targets) *gatewayRouteResolver {
+	// Create Namespace lookup table.
+	nss := make(map[string]*corev1.Namespace, len(namespaces))
+	for _, ns := range namespaces {
+		nss[ns.Name] = ns
+	}
+	// Create ReferenceGrant lookup table.
+	rgs := make(map[string][]*v1beta1.ReferenceGrant)
+	for _, rg := range grants {
+		rgs[rg.Namespace] = append(rgs[rg.Namespace], rg)
+	}
+	// Create Node lookup table.
+	nds := make(map[string]*corev1.Node)
+	for _, nd := range nodes {
+		for _, addr := range nd.Status.Addresses {
+			if addr.Type == corev1.NodeInternalIP || addr.Type == corev1.NodeExternalIP {
+				nds[addr.Address] = nd
+			}
+		}
+	}
+	c := &gatewayRouteResolver{
+		src:       src,
+		nss:       nss,
+		rgs:       rgs,
+		nodes:     nodes,
+		nds:       nds,
+		gcTargets: classTargets,
+	}
+	// Create Gateway Listener lookup table.
+	c.gws = make(map[types.NamespacedName]gatewayListeners, len(gateways))
+	for _, gw := range gateways {
+		key := namespacedName(gw.Namespace, gw.Name)
+		if gwl, ok := gwCache.get(gw); ok {
+			c.gws[key] = gwl
+			continue
+		}
+		defaultHost := gwDefaultListenerHost(gw)
+		lss := make(map[v1.SectionName][]v1.Listener, len(gw.Spec.Listeners)+1)
+		var certHosts map[v1.SectionName][]string
+		for i, lis := range gw.Spec.Listeners {
+			lss[lis.Name] = gw.Spec.Listeners[i : i+1]
+			// Passthrough Listeners don't reference certificates, since they don't terminate TLS.
+			if src.secInformer == nil || lis.Hostname != nil || defaultHost != "" || lis.TLS == nil || gwListenerIsPassthrough(&gw.Spec.Listeners[i]) {
+				continue
+			}
+			if hosts := c.certificateHosts(gw, &gw.Spec.Listeners[i]); len(hosts) > 0 {
+				if certHosts == nil {
+					certHosts = make(map[v1.SectionName][]string)
+				}
+				certHosts[lis.Name] = hosts
+			}
+		}
+		lss[""] = gw.Spec.Listeners
+		weight, weighted := annotations.GatewayWeightFromAnnotations(gw.Annotations, fmt.Sprintf("gateway/%s/%s", gw.Namespace, gw.Name))
+		gwl := gatewayListeners{
+			gateway:   gw,
+			listeners: lss,
+			ttl:       annotations.TTLFromAnnotations(gw.Annotations, fmt.Sprintf("gateway/%s/%s", gw.Namespace, gw.Name)),
+			alias:     gw.Annotations[aliasAnnotationKey] == "true",
+			certHosts: certHosts,
+
+			listenerTTLs:     annotations.ListenerTTLsFromAnnotations(gw.Annotations, fmt.Sprintf("gateway/%s/%s", gw.Namespace, gw.Name)),
+			listenerAddrs:    annotations.ListenerAddressesFromAnnotations(gw.Annotations, fmt.Sprintf("gateway/%s/%s", gw.Namespace, gw.Name)),
+			excludedHosts:    annotations.ExcludeHostnamesFromAnnotations(gw.Annotations),
+			defaultHost:      defaultHost,
+			providerSpecific: gwProviderSpecific(gw.Annotations),
+			weight:           weight,
+			weighted:         weighted,
+		}
+		gwCache.put(gw, gwl)
+		c.gws[key] = gwl
+	}
+	if src.labelFallback {
+		c.nsGws = make(map[string][]string)
+		for key := range c.gws {
+			c.nsGws[key.Namespace] = append(c.nsGws[key.Namespace], key.Name)
+		}
+		for _, names := range c.nsGws {
+			slices.Sort(names)
+		}
+	}
+	return c
+}
+
+// gwDefaultListenerHost returns the hostname of the Gateway's listener-hostname annotation, which
+// Listeners without a hostname default to, or an empty string if there's none or it's invalid.
+func gwDefaultListenerHost(gw *v1beta1.Gateway) string {
+	value, ok := gw.Annotations[annotations.ListenerHostnameKey]
+	if !ok {
+		return ""
+	}
+	host, ok := gwHost(strings.TrimSpace(value))
+	if !ok {
+		log.Warnf("Ignoring invalid listener hostname %q of Gateway %s/%s", value, gw.Namespace, gw.Name)
+		return ""
+	}
+	return host
+}
+
+// labelSelectedGateway returns the Gateway selected by the Gateway label filter in the namespace
+// of a parent reference whose Gateway wasn't found. If several Gateways are selected, none is
+// used, since any of them may not be the Gateway the Route actually attached to.
+func (c *gatewayRouteResolver) labelSelectedGateway(namespace string, ref v1.ParentReference, rt gatewayRoute) (gatewayListeners, bool) {
+	meta := rt.Metadata()
+	names := c.nsGws[namespace]
+	switch len(names) {
+	case 0:
+		return gatewayListeners{}, false
+	case 1:
+		c.src.routeLog(rt).gateway(namespace, names[0]).Debugf("Gateway %s/%s not found for %s %s/%s, using Gateway %s/%s selected by label",
+			namespace, ref.Name, c.src.rtKind, meta.Namespace, meta.Name, namespace, names[0])
+		return c.gws[namespacedName(namespace, names[0])], true
+	default:
+		c.src.routeLog(rt).gateway(namespace, string(ref.Name)).Infof("Gateway %s/%s not found for %s %s/%s, skipping it since %d Gateways are selected by label: %s",
+			namespace, ref.Name, c.src.rtKind, meta.Namespace, meta.Name, len(names), strings.Join(names, ", "))
+		return gatewayListeners{}, false
+	}
+}
+
+// gatewayListenersCache caches the Listener lookup tables of Gateways across Endpoints calls.
+// Entries are reused as long as the resource version of their Gateway is unchanged.
+// Cached lookup tables are shared between calls and must not be modified.
+type gatewayListenersCache struct {
+	mu         sync.Mutex
+	generation uint64
+	entries    map[types.NamespacedName]gatewayListenersCacheEntry
+}
+
+type gatewayListenersCacheEntry struct {
+	resourceVersion string
+	listeners       gatewayListeners
+}
+
+func newGatewayListenersCache() *gatewayListenersCache {
+	return &gatewayListenersCache{entries: make(map[types.NamespacedName]gatewayListenersCacheEntry)}
+}
+
+// view returns a view of the cache that only stores entries if the cache isn't reset in the meantime.
+func (c *gatewayListenersCache) view() gatewayListenersCacheView {
+	if c == nil {
+		return gatewayListenersCacheView{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return gatewayListenersCacheView{cache: c, generation: c.generation}
+}
+
+// reset drops all entries, e.g. because state outside of the Gateways changed.
+func (c *gatewayListenersCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	clear(c.entries)
+}
+
+// onDelete drops the entry of a deleted Gateway.
+func (c *gatewayListenersCache) onDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	gw, ok := obj.(*v1beta1.Gateway)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, namespacedName(gw.Namespace, gw.Name))
+}
+
+type gatewayListenersCacheView struct {
+	cache      *gatewayListenersCache
+	generation uint64
+}
+
+func (v gatewayListenersCacheView) get(gw *v1beta1.Gateway) (gatewayListeners, bool) {
+	// Objects without a resource version, e.g. from fake clients, can't be cached.
+	if v.cache == nil || gw.ResourceVersion == "" {
+		return gatewayListeners{}, false
+	}
+	v.cache.mu.Lock()
+	defer v.cache.mu.Unlock()
+	e, ok := v.cache.entries[namespacedName(gw.Namespace, gw.Name)]
+	if !ok || e.resourceVersion != gw.ResourceVersion {
+		return gatewayListeners{}, false
+	}
+	return e.listeners, true
+}
+
+func (v gatewayListenersCacheView) put(gw *v1beta1.Gateway, gwl gatewayListeners) {
+	if v.cache == nil || gw.ResourceVersion == "" {
+		return
+	}
+	v.cache.mu.Lock()
+	defer v.cache.mu.Unlock()
+	if v.cache.generation != v.generation {
+		return
+	}
+	v.cache.entries[namespacedName(gw.Namespace, gw.Name)] = gatewayListenersCacheEntry{
+		resourceVersion: gw.ResourceVersion,
+		listeners:       gwl,
+	}
+}
+
+// certificateHosts returns the SANs of the certificates referenced by the Listener.
+func (c *gatewayRouteResolver) certificateHosts(gw *v1beta1.Gateway, lis *v1.Listener) []string {
+	var hosts []string
+	for _, ref := range lis.TLS.CertificateRefs {
+		group := strVal((*string)(ref.Group), "")
+		kind := strVal((*string)(ref.Kind), "Secret")
+		if group != "" || kind != "Secret" {
+			log.Debugf("Gateway %s/%s section %q has unsupported certificate reference %s/%s", gw.Namespace, gw.Name, lis.Name, group, kind)
+			continue
+		}
+		namespace := strVal((*string)(ref.Namespace), gw.Namespace)
+		if namespace != gw.Namespace && !c.referenceIsGranted(
+			c.src.parentGroupKind, gw.Namespace,
+			schema.GroupKind{Kind: kind}, namespacedName(namespace, string(ref.Name)),
+		) {
+			log.Debugf("No ReferenceGrant allows Gateway %s/%s to reference Secret %s/%s", gw.Namespace, gw.Name, namespace, ref.Name)
+			continue
+		}
+		secret, err := c.src.secInformer.Lister().Secrets(namespace).Get(string(ref.Name))
+		if err != nil {
+			log.Debugf("Failed to get Secret %s/%s for Gateway %s/%s section %q: %v", namespace, ref.Name, gw.Namespace, gw.Name, lis.Name, err)
+			continue
+		}
+		names, err := certificateDNSNames(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			log.Warnf("Failed to parse certificate of Secret %s/%s for Gateway %s/%s section %q: %v", namespace, ref.Name, gw.Namespace, gw.Name, lis.Name, err)
+			continue
+		}
+		hosts = append(hosts, names...)
+	}
+	return hosts
+}
+
+// gatewayTargets maps record types to the targets that should be published with them.
+type gatewayTargets map[string]endpoint.Targets
+
+// withRecordType returns the targets of all record types as targets of the given record type.
+// If the record type is empty, the targets are returned unchanged.
+func (t gatewayTargets) withRecordType(recordType string) gatewayTargets {
+	if recordType == "" {
+		return t
+	}
+	var targets endpoint.Targets
+	for _, tgts := range t {
+		targets = append(targets, tgts...)
+	}
+	return gatewayTargets{recordType: uniqueTargets(targets)}
+}
+
+// gwListenerTargets returns the targets of the Gateway Listener by record type. The Listener's
+// target annotation takes precedence over the Gateway's target annotation, which in turn takes
+// precedence over the Gateway's status addresses, of which those of the preferred type are used if any.
+// If any addresses are pinned, only the status addresses among them are used. Likewise, if any of the
+// addresses that the Listener binds to are in the status, only those are used.
+func gwListenerTargets(gw *v1beta1.Gateway, lis *v1.Listener, lisAddrs []string, access string, preferred v1.AddressType, pinned []string) gatewayTargets {
+	targets := make(gatewayTargets)
+	// The annotations of the Gateway's metadata take precedence over those of its infrastructure.
+	override := gwTargetOverride(gw.Annotations, lis)
+	if len(override) == 0 && gw.Spec.Infrastructure != nil && len(gw.Spec.Infrastructure.Annotations) > 0 {
+		infraAnnots := make(map[string]string, len(gw.Spec.Infrastructure.Annotations))
+		for k, v := range gw.Spec.Infrastructure.Annotations {
+			infraAnnots[string(k)] = string(v)
+		}
+		override = gwTargetOverride(infraAnnots, lis)
+	}
+	for _, target := range override {
+		recordType := suitableType(target)
+		targets[recordType] = append(targets[recordType], target)
+	}
+	if len(override) == 0 {
+		scoped := gwListenerAddresses(gw, lisAddrs)
+		var addrs []v1.GatewayStatusAddress
+		for _, addr := range gw.Status.Addresses {
+			value := gwAddressValue(addr.Value)
+			if len(scoped) > 0 && !slices.Contains(scoped, value) {
+				continue
+			}
+			if gwAddressHasAccess(addr, access) && (len(pinned) == 0 || slices.Contains(pinned, value)) {
+				addrs = append(addrs, addr)
+			}
+		}
+		for _, addr := range gwPreferredAddresses(addrs, preferred) {
+			recordType := gwAddressRecordType(addr)
+			targets[recordType] = append(targets[recordType], addr.Value)
+		}
+	}
+	return targets
+}
+
+// gwPreferredAddresses returns the Gateway addresses of the preferred type. If none of the addresses
+// are of that type, or no type is preferred, all addresses are returned.
+func gwPreferredAddresses(addrs []v1.GatewayStatusAddress, preferred v1.AddressType) []v1.GatewayStatusAddress {
+	if preferred == "" {
+		return addrs
+	}
+	var result []v1.GatewayStatusAddress
+	for _, addr := range addrs {
+		// The type of an address defaults to IPAddress.
+		typ := v1.IPAddressType
+		if addr.Type != nil {
+			typ = *addr.Type
+		}
+		if typ == preferred {
+			result = append(result, addr)
+		}
+	}
+	if len(result) == 0 {
+		return addrs
+	}
+	return result
+}
+
+// gwTargetOverride returns the targets of the Listener's target annotation, falling back to
+// the targets of the Gateway's target annotation.
+func gwTargetOverride(annots map[string]string, lis *v1.Listener) endpoint.Targets {
+	if override := annotations.TargetsFromListenerTargetAnnotation(annots, string(lis.Name)); len(override) > 0 {
+		return override
+	}
+	return annotations.TargetsFromTargetAnnotation(annots)
+}
+
+// parentlessHosts returns the hosts of a Route without parentRefs, which point at the targets of
+// its target annotation instead of the addresses of Gateways. Without Listeners to narrow them
+// down, all valid hostnames of the Route with an allowed suffix are published.
+func (c *gatewayRouteResolver) parentlessHosts(ctx context.Context, rt gatewayRoute, rtHosts []string, override endpoint.Targets) map[string]*gatewayHost {
+	targets := c.hostTargets(ctx, gwTargetsByType(override))
+	hostTargets := make(map[string]*gatewayHost)
+	for _, rtHost := range rtHosts {
+		host, ok := gwHost(rtHost)
+		if !ok || host == "" {
+			continue
+		}
+		if !gwHostHasSuffix(host, c.src.hostSuffixes) {
+			c.skipUnsuffixedHost(rt, host)
+			continue
+		}
+		h := &gatewayHost{targets: make(gatewayTargets)}
+		h.addTargets(targets, false)
+		hostTargets[host] = h
+	}
+	return hostTargets
+}
+
+// gwTargetsByType groups the targets by the record type suitable for each of them.
+func gwTargetsByType(targets endpoint.Targets) gatewayTargets {
+	result := make(gatewayTargets)
+	for _, target := range targets {
+		recordType := suitableType(target)
+		result[recordType] = append(result[recordType], target)
+	}
+	return result
+}
+
+// overrideHosts applies the per-hostname overrides of the Route's annotation to its hosts. Overrides
+// of hostnames the Route doesn't publish are ignored.
+func (c *gatewayRouteResolver) overrideHosts(ctx context.Context, rt gatewayRoute, overrides map[string]annotations.HostnameOverride, hostTargets map[string]*gatewayHost) {
+	meta := rt.Metadata()
+	for host, override := range overrides {
+		h, ok := hostTargets[host]
+		if !ok {
+			c.src.routeLog(rt).Debugf("Ignoring override of host %s of %s %s/%s, which it doesn't publish", host, c.src.rtKind, meta.Namespace, meta.Name)
+			continue
+		}
+		if len(override.Targets) > 0 {
+			h.targets = c.hostTargets(ctx, gwTargetsByType(override.Targets))
+			h.fallback = false
+			// The overridden targets don't belong to any Gateway, so they aren't weighted.
+			h.weighted = nil
+		}
+		if override.TTL.IsConfigured() {
+			h.routeTTL = override.TTL
+		}
+	}
+}
+
+// filterIPFamilies removes the IP targets of the hosts that aren't of the IP families of the Route's
+// ip-families annotation. Hostname targets are kept, and hosts without any remaining targets are removed.
+func (c *gatewayRouteResolver) filterIPFamilies(rt gatewayRoute, hostTargets map[string]*gatewayHost) {
+	meta := rt.Metadata()
+	families := annotations.IPFamiliesFromAnnotations(meta.Annotations, fmt.Sprintf("%s/%s/%s", strings.ToLower(c.src.rtKind), meta.Namespace, meta.Name))
+	if len(families) == 0 {
+		return
+	}
+	for host, h := range hostTargets {
+		for recordType, tgts := range h.targets {
+			tgts = slices.DeleteFunc(slices.Clone(tgts), func(target string) bool {
+				family, ok := gwIPFamily(target)
+				return ok && !slices.Contains(families, family)
+			})
+			if len(tgts) == 0 {
+				delete(h.targets, recordType)
+				continue
+			}
+			h.targets[recordType] = tgts
+		}
+		if len(h.targets) == 0 {
+			c.src.routeLog(rt).Debugf("Skipping host %s of %s %s/%s without targets of IP families %q", host, c.src.rtKind, meta.Namespace, meta.Name, families)
+			delete(hostTargets, host)
+		}
+	}
+}
+
+// denyHosts removes the hosts covered by the denied hostnames, which Routes must never publish. It runs
+// last, so that it also covers the hosts added for wildcard apexes and wildcard hostnames, along with
+// the hosts of Routes without parents. Denied hosts are only counted and logged outside of validation,
+// whose report lists them instead.
+func (c *gatewayRouteResolver) denyHosts(rt gatewayRoute, hostTargets map[string]*gatewayHost) {
+	if len(c.src.deniedHosts) == 0 {
+		return
+	}
+	meta := rt.Metadata()
+	for host := range hostTargets {
+		if !gwHostExcluded(host, c.src.deniedHosts, c.src.multiLabelWildcards) {
+			continue
+		}
+		delete(hostTargets, host)
+		if c.report != nil {
+			c.report.DeniedHosts = append(c.report.DeniedHosts, host)
+			continue
+		}
+		gatewayDeniedHostnamesTotal.CounterVec.WithLabelValues(c.src.rtKind).Inc()
+		c.src.routeLog(rt).Warnf("Skipping denied host %s of %s %s/%s", host, c.src.rtKind, meta.Namespace, meta.Name)
+	}
+	if c.report != nil {
+		slices.Sort(c.report.DeniedHosts)
+	}
+}
+
+// gwIPFamily returns the IP family of the target, "ipv4" or "ipv6", or false if it isn't an IP address.
+// IPv4-mapped IPv6 addresses are of the IPv4 family.
+func gwIPFamily(target string) (string, bool) {
+	ip, err := netip.ParseAddr(target)
+	if err != nil {
+		return "", false
+	}
+	if ip.Unmap().Is4() {
+		return "ipv4", true
+	}
+	return "ipv6", true
+}
+
+// classTargets returns the default targets of the Gateway's GatewayClass, if its parameters are
+// resolved. They're taken from the target annotation of the ConfigMap referenced by the
+// GatewayClass's parametersRef; other parameters are ignored.
+func (c *gatewayRouteResolver) classTargets(gw *v1beta1.Gateway) gatewayTargets {
+	if c.src.gcInformer == nil {
+		return nil
+	}
+	targets := make(gatewayTargets)
+	for _, target := range c.gcTargets[string(gw.Spec.GatewayClassName)] {
+		recordType := suitableType(target)
+		targets[recordType] = append(targets[recordType], target)
+	}
+	return targets
+}
+
+// gatewayHostResolver looks up the IP addresses of hostnames. It's implemented by net.Resolver.
+type gatewayHostResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// gatewayHostLookup is the lookup of the IP addresses of a hostname target, which runs once per resolver.
+type gatewayHostLookup struct {
+	once  sync.Once
+	addrs []string
+}
+
+// hostTargets replaces hostname targets with the IP addresses they resolve to, if enabled, for
+// providers that don't allow CNAME records at the apex or chained CNAME records. Hostnames that
+// fail to resolve are kept, so that they're still published as CNAME records.
+func (c *gatewayRouteResolver) hostTargets(ctx context.Context, targets gatewayTargets) gatewayTargets {
+	if !c.src.resolveHostnameTargets || len(targets[endpoint.RecordTypeCNAME]) == 0 {
+		return targets
+	}
+	result := make(gatewayTargets, len(targets))
+	for recordType, tgts := range targets {
+		if recordType != endpoint.RecordTypeCNAME {
+			result[recordType] = append(result[recordType], tgts...)
+			continue
+		}
+		for _, target := range tgts {
+			addrs := c.hostAddresses(ctx, target)
+			if len(addrs) == 0 {
+				result[recordType] = append(result[recordType], target)
+				continue
+			}
+			for _, addr := range addrs {
+				addrType := suitableType(addr)
+				result[addrType] = append(result[addrType], addr)
+			}
+		}
+	}
+	return result
+}
+
+// hostAddresses returns the sorted IP addresses of the hostname, looking them up once per resolver.
+// Concurrent callers of the same hostname wait for its lookup, while other hostnames are looked up
+// concurrently.
+func (c *gatewayRouteResolver) hostAddresses(ctx context.Context, host string) []string {
+	c.hostMu.Lock()
+	if c.hostAddrs == nil {
+		c.hostAddrs = make(map[string]*gatewayHostLookup)
+	}
+	lookup, ok := c.hostAddrs[host]
+	if !ok {
+		lookup = &gatewayHostLookup{}
+		c.hostAddrs[host] = lookup
+	}
+	c.hostMu.Unlock()
+	lookup.once.Do(func() {
+		lookup.addrs = c.lookupHost(ctx, host)
+	})
+	return lookup.addrs
+}
+
+// lookupHost looks up the sorted IP addresses of the hostname, giving up after the lookup timeout.
+func (c *gatewayRouteResolver) lookupHost(ctx context.Context, host string) []string {
+	ctx, cancel := context.WithTimeout(ctx, gatewayHostLookupTimeout)
+	defer cancel()
+	ipAddrs, err := c.src.hostResolver.LookupIPAddr(ctx, strings.TrimSuffix(host, "."))
+	if err != nil {
+		log.Warnf("Failed to resolve hostname target %s, publishing it as a CNAME record: %v", host, err)
+	}
+	var addrs []string
+	for _, ipAddr := range ipAddrs {
+		if ip, ok := netip.AddrFromSlice(ipAddr.IP); ok {
+			addrs = append(addrs, ip.Unmap().String())
+		}
+	}
+	slices.Sort(addrs)
+	return slices.Compact(addrs)
+}
+
+// gatewayClassTargets resolves the parameters of the GatewayClasses of the Gateways to their default
+// targets by GatewayClass name. The parameters ConfigMaps are read on demand, once per GatewayClass
+// and sync. GatewayClasses and parameters that don't exist or aren't supported have no default
+// targets; other errors reading a ConfigMap are returned, so that a sync doesn't drop the targets.
+func (src *gatewayRouteSource) gatewayClassTargets(ctx context.Context, gateways []*v1beta1.Gateway) (map[string]endpoint.Targets, error) {
+	classTargets := make(map[string]endpoint.Targets)
+	for _, gw := range gateways {
+		name := string(gw.Spec.GatewayClassName)
+		if _, ok := classTargets[name]; ok {
+			continue
+		}
+		targets, err := src.gatewayClassParameterTargets(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		classTargets[name] = targets
+	}
+	return classTargets, nil
+}
+
+// gatewayClassParameterTargets returns the targets of the parameters ConfigMap of the GatewayClass.
+func (src *gatewayRouteSource) gatewayClassParameterTargets(ctx context.Context, name string) (endpoint.Targets, error) {
+	gc, err := src.gcInformer.Lister().Get(name)
+	if err != nil {
+		log.Debugf("Failed to get GatewayClass %s: %v", name, err)
+		return nil, nil
+	}
+	ref := gc.Spec.ParametersRef
+	if ref == nil {
+		return nil, nil
+	}
+	if ref.Group != "" || ref.Kind != "ConfigMap" {
+		log.Debugf("Ignoring unsupported parameters %s/%s of GatewayClass %s", ref.Group, ref.Kind, name)
+		return nil, nil
+	}
+	if ref.Namespace == nil {
+		log.Debugf("Ignoring parameters ConfigMap %s of GatewayClass %s without a namespace", ref.Name, name)
+		return nil, nil
+	}
+	cm, err := src.configMaps.ConfigMaps(string(*ref.Namespace)).Get(ctx, ref.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		log.Debugf("Ignoring missing parameters ConfigMap %s/%s of GatewayClass %s", *ref.Namespace, ref.Name, name)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parameters ConfigMap %s/%s of GatewayClass %s: %w", *ref.Namespace, ref.Name, name, err)
+	}
+	return annotations.TargetsFromTargetAnnotation(cm.Annotations), nil
+}
+
+// gatewayHost holds the targets of a Route hostname and the settings it inherits from its Gateways.
+type gatewayHost struct {
+	targets gatewayTargets
+	// ttl is the TTL of the Gateways' annotations, used if the Route doesn't specify one.
+	ttl endpoint.TTL
+	// ttlGateway is the Gateway whose TTL is used.
+	ttlGateway types.NamespacedName
+	// routeTTL is the TTL of the host in the Route's hostname overrides, which takes precedence over the Route's TTL.
+	routeTTL endpoint.TTL
+	// alias is true if any of the Gateways request alias records, used if the Route doesn't specify it.
+	alias bool
+	// providerSpecific holds the provider-specific properties of the Gateways, of which the first
+	// Gateway setting a property takes precedence. Properties the Route sets take precedence over them.
+	providerSpecific endpoint.ProviderSpecific
+	// provisional is true if none of the Gateways have accepted the Route.
+	provisional bool
+	// fallback is true if the targets are the fallback targets, because none of the Gateways has any.
+	fallback bool
+	// services holds the services of the Listeners matching the host that are published as SRV records.
+	services []gatewayService
+	// weighted holds the targets that each Gateway with a weight contributes, which are published as
+	// weighted records per Gateway, unless unweighted is true because other Gateways contribute targets too.
+	weighted   map[types.NamespacedName]gatewayWeightedTargets
+	unweighted bool
+}
+
+// gatewayWeightedTargets holds the targets of a Gateway with a weight.
+type gatewayWeightedTargets struct {
+	weight  int64
+	targets gatewayTargets
+}
+
+// addGatewayTargets tracks the targets that the Gateway contributes to the host. Fallback targets
+// aren't tracked, since they're the same for all Gateways.
+func (h *gatewayHost) addGatewayTargets(gw gatewayListeners, targets gatewayTargets, fallback bool) {
+	switch {
+	case len(targets) == 0 || fallback:
+		return
+	case !gw.weighted:
+		h.unweighted = true
+		return
+	}
+	if h.weighted == nil {
+		h.weighted = make(map[types.NamespacedName]gatewayWeightedTargets)
+	}
+	h.addWeightedTargets(namespacedName(gw.gateway.Namespace, gw.gateway.Name), gatewayWeightedTargets{weight: gw.weight, targets: targets})
+}
+
+// addWeightedTargets adds the targets of a weighted Gateway to the host. The targets are copied,
+// since the hosts of wildcard apexes share them.
+func (h *gatewayHost) addWeightedTargets(key types.NamespacedName, w gatewayWeightedTargets) {
+	merged := make(gatewayTargets, len(w.targets))
+	for recordType, tgts := range h.weighted[key].targets {
+		merged[recordType] = slices.Clone(tgts)
+	}
+	for recordType, tgts := range w.targets {
+		merged[recordType] = append(merged[recordType], tgts...)
+	}
+	h.weighted[key] = gatewayWeightedTargets{weight: w.weight, targets: merged}
+}
+
+// gatewayService is the service of a Listener, published as an SRV record of each host it matches.
+type gatewayService struct {
+	name     string
+	protocol string
+	port     v1.PortNumber
+}
+
+// addService adds the service to the host, unless it has it already.
+func (h *gatewayHost) addService(svc gatewayService) {
+	if !slices.Contains(h.services, svc) {
+		h.services = append(h.services, svc)
+	}
+}
+
+// gwListenerServices returns the services of the Listener, if its record types include SRV records.
+// Listeners of UDPRoutes serve UDP, while all other protocols are based on TCP.
+func gwListenerServices(recordTypes map[string][]string, lis *v1.Listener) []gatewayService {
+	if !slices.Contains(recordTypes[gwListenerKey(lis.Protocol, lis.Name)], endpoint.RecordTypeSRV) {
+		return nil
+	}
+	protocol := "tcp"
+	if lis.Protocol == v1.UDPProtocolType {
+		protocol = "udp"
+	}
+	return []gatewayService{{name: string(lis.Name), protocol: protocol, port: lis.Port}}
+}
+
+// addTargets adds the targets to the host. Fallback targets are only added as long as the host has
+// no other targets, and are replaced once it has any.
+func (h *gatewayHost) addTargets(targets gatewayTargets, fallback bool) {
+	if len(targets) == 0 {
+		return
+	}
+	switch {
+	case fallback && !h.fallback && len(h.targets) > 0:
+		return
+	case !fallback && h.fallback:
+		clear(h.targets)
+	}
+	h.fallback = fallback
+	for recordType, tgts := range targets {
+		h.targets[recordType] = append(h.targets[recordType], tgts...)
+	}
+}
+
+func (c *gatewayRouteResolver) resolve(ctx context.Context, rt gatewayRoute) (map[string]*gatewayHost, error) {
+	rtHosts, listenerTemplate, err := c.hosts(rt)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := annotations.HostnameOverridesFromAnnotations(rt.Metadata().Annotations)
+	if err != nil {
+		return nil, err
+	}
+	hostTargets := make(map[string]*gatewayHost)
+
+	rlog := c.src.routeLog(rt)
+	meta := rt.Metadata()
+
+	// Routes without parents may still point at the targets of their target annotation, if enabled.
+	parentless := len(rt.ParentRefs()) == 0
+	override := annotations.TargetsFromTargetAnnotation(meta.Annotations)
+	if parentless && (!c.src.parentlessRoutes || len(override) == 0) {
+		rlog.Debugf("No parent references found for %s %s/%s", c.src.rtKind, meta.Namespace, meta.Name)
+		c.skip(c.report, gwSkipNoParentRef)
+		return hostTargets, nil
+	}
+
+	// Don't recreate the records of Routes that are going away along with their namespace.
+	if ns, ok := c.nss[meta.Namespace]; ok && ns.DeletionTimestamp != nil {
+		rlog.Debugf("Skipping %s %s/%s because its namespace is %s since %s", c.src.rtKind, meta.Namespace, meta.Name, ns.Status.Phase, ns.DeletionTimestamp)
+		c.skip(c.report, gwSkipNamespaceDeleting)
+		return hostTargets, nil
+	}
+	// Don't publish Routes that can't forward requests anywhere, if required.
+	if c.src.requireBackends && len(rt.BackendRefs()) == 0 {
+		rlog.Debugf("Skipping %s %s/%s because it has no backendRefs", c.src.rtKind, meta.Namespace, meta.Name)
+		c.skip(c.report, gwSkipNoBackends)
+		return hostTargets, nil
+	}
+	if parentless {
+		rlog.Debugf("No parent references found for %s %s/%s, publishing its hosts at the targets of its annotation", c.src.rtKind, meta.Namespace, meta.Name)
+		hostTargets = c.parentlessHosts(ctx, rt, rtHosts, override)
+	} else if hostTargets, err = c.parentHosts(ctx, rt, rtHosts, listenerTemplate); err != nil {
+		return nil, err
+	}
+	c.addWildcardApexes(rt, hostTargets)
+	c.expandWildcards(rt, hostTargets)
+	c.overrideHosts(ctx, rt, overrides, hostTargets)
+	c.filterIPFamilies(rt, hostTargets)
+	c.denyHosts(rt, hostTargets)
+	// If a Gateway has multiple matching Listeners for the same host, then we'll
+	// add its IPs to the target list multiple times and should dedupe them.
+	for _, h := range hostTargets {
+		for recordType, tgts := range h.targets {
+			h.targets[recordType] = uniqueTargets(tgts)
+		}
+	}
+	return hostTargets, nil
+}
+
+// parentHosts returns the hosts of the Route that its parent Gateways publish, along with the
+// targets of each host.
+func (c *gatewayRouteResolver) parentHosts(ctx context.Context, rt gatewayRoute, rtHosts []string, listenerTemplate bool) (map[string]*gatewayHost, error) {
+	hostTargets := make(map[string]*gatewayHost)
+	routeParentRefs := rt.ParentRefs()
+	rlog := c.src.routeLog(rt)
+	meta := rt.Metadata()
+	var err error
+
+	access := getAccessFromAnnotations(meta.Annotations)
+	// Internal hostnames point at the private addresses of the Gateways, while the other hostnames
+	// of the Route point at their public addresses, unless its access annotation says otherwise.
+	internalHosts := c.internalHosts(rt)
+	if len(internalHosts) > 0 && access == "" {
+		access = "public"
+	}
+	endpointsType := c.endpointsType(rt)
+	protocol := c.routeProtocol(rt)
+	// Track which of the pinned addresses the Gateways have, to warn about those none of them has.
+	pinned := gwPinnedAddresses(meta.Annotations)
+	if len(pinned) > 0 && !c.parentsHaveAnyAddress(routeParentRefs, meta, pinned) {
+		rlog.Warnf("None of the gateway addresses %s of %s %s/%s are addresses of its Gateways, publishing all of their addresses",
+			strings.Join(pinned, ","), c.src.rtKind, meta.Namespace, meta.Name)
+		pinned = nil
+	}
+	pinnedFound := make(map[string]bool, len(pinned))
+
+	// Track the hosts without an allowed suffix, to warn about each of them once.
+	unsuffixed := make(map[string]bool)
+
+	// In intersection mode, track which Gateways match each host.
+	var hostGateways map[string]map[types.NamespacedName]bool
+	if c.src.intersectParents {
+		hostGateways = make(map[string]map[types.NamespacedName]bool)
+	}
+
+	// Gateways without addresses may fall back to the addresses of the Route's backends, if enabled.
+	var backends gatewayTargets
+	if c.src.svcInformer != nil {
+		backends = c.nodeTargets(c.backendTargets(rt, endpointsType), endpointsType)
+	}
+	parents := rt.RouteStatus().Parents
+	if c.src.includeUnaccepted {
+		parents = append(slices.Clone(parents), gwUnreportedParents(routeParentRefs, parents, meta, c.src.parentGroupKind)...)
+	}
+	for _, rps := range parents {
+		// Confirm the Parent is the standard Gateway kind.
+		ref := rps.ParentRef
+		namespace := strVal((*string)(ref.Namespace), meta.Namespace)
+		pr := c.reportParent(namespace, ref)
+		glog := rlog.gateway(namespace, string(ref.Name))
+		// Ensure that the parent reference is in the routeParentRefs list
+		if !gwRouteHasParentRef(routeParentRefs, ref, meta, c.src.parentGroupKind) {
+			glog.Debugf("Parent reference %s/%s not found in routeParentRefs for %s %s/%s", namespace, string(ref.Name), c.src.rtKind, meta.Namespace, meta.Name)
+			c.skip(pr, gwSkipNoParentRef)
+			continue
+		}
+
+		if gk := gwParentRefGroupKind(ref, c.src.parentGroupKind); gk != c.src.parentGroupKind {
+			glog.Debugf("Unsupported parent %s/%s for %s %s/%s", gk.Group, gk.Kind, c.src.rtKind, meta.Namespace, meta.Name)
+			pr.reject(gwSkipUnsupportedParent)
+			continue
+		}
+		// Lookup the Gateway and its Listeners, falling back to those selected by label, if enabled.
+		gw, ok := c.gws[namespacedName(namespace, string(ref.Name))]
+		if !ok && c.src.labelFallback {
+			gw, ok = c.labelSelectedGateway(namespace, ref, rt)
+		}
+		if !ok {
+			glog.Debugf("Gateway %s/%s not found for %s %s/%s", namespace, ref.Name, c.src.rtKind, meta.Namespace, meta.Name)
+			c.skip(pr, gwSkipGatewayNotFound)
+			continue
+		}
+		// Confirm the Gateway has the correct name, if specified.
+		if !gatewayNameMatches(c.src.gwName, gw.gateway.Name) {
+			glog.Debugf("Gateway %s/%s does not match %s %s/%s", namespace, ref.Name, c.src.gwName, meta.Namespace, meta.Name)
+			pr.reject(gwSkipGatewayNameMismatch)
+			continue
+		}
+		// Confirm the Gateway has one of the GatewayClasses, if specified.
+		if len(c.src.gwClasses) > 0 && !slices.Contains(c.src.gwClasses, string(gw.gateway.Spec.GatewayClassName)) {
+			glog.Debugf("Gateway %s/%s of GatewayClass %s does not match %s %s/%s", namespace, ref.Name, gw.gateway.Spec.GatewayClassName, c.src.rtKind, meta.Namespace, meta.Name)
+			pr.reject(gwSkipGatewayClassMismatch)
+			continue
+		}
+		// Confirm a ReferenceGrant allows the Route to attach to a Gateway in another namespace, if required.
+		if c.src.requireReferenceGrant && !c.routeIsGranted(gw.gateway, rt) {
+			glog.Debugf("No ReferenceGrant allows %s %s/%s to reference Gateway %s/%s", c.src.rtKind, meta.Namespace, meta.Name, namespace, ref.Name)
+			pr.reject(gwSkipNoReferenceGrant)
+			continue
 		}
 
-		// Match the Route to all possible Listeners.
-		match := false
 		section := sectionVal(ref.SectionName, "")
 		listeners := gw.listeners[section]
+		// A section that names no Listener is most likely a typo or a removed Listener, rather than
+		// a Listener that legitimately doesn't match the Route, so it's reported as such. Since the
+		// Gateway doesn't accept such a Route, it's reported regardless of its acceptance, but only
+		// outside of validation, which must not affect the metrics.
+		if section != "" && len(listeners) == 0 && c.report == nil {
+			gatewayMissingSectionsTotal.CounterVec.WithLabelValues(c.src.rtKind).Inc()
+			glog.withSection(section).Warnf("Gateway %s/%s has no listener named %q referenced by %s %s/%s", namespace, ref.Name, section, c.src.rtKind, meta.Namespace, meta.Name)
+		}
+
+		// Confirm the Gateway has accepted the Route, unless unaccepted Routes are included.
+		accepted := gwRouteIsAccepted(rps.Conditions)
+		if !accepted && !c.src.includeUnaccepted {
+			glog.Debugf("Gateway %s/%s has not accepted the current generation %s %s/%s", namespace, ref.Name, c.src.rtKind, meta.Namespace, meta.Name)
+			c.skip(pr, gwSkipNotAccepted)
+			continue
+		}
+		if !accepted {
+			glog.Debugf("Gateway %s/%s has not accepted %s %s/%s, publishing its hosts as provisional", namespace, ref.Name, c.src.rtKind, meta.Namespace, meta.Name)
+		}
+
+		// Confirm the Gateway has been programmed, if required.
+		if c.src.requireProgrammed && !gwIsProgrammed(gw.gateway.Status.Conditions) {
+			glog.Debugf("Gateway %s/%s has not been programmed for %s %s/%s", namespace, ref.Name, c.src.rtKind, meta.Namespace, meta.Name)
+			pr.reject(gwSkipNotProgrammed)
+			continue
+		}
+
+		// Match the Route to all possible Listeners.
+		match, matchHosts := false, false
 		for i := range listeners {
 			lis := &listeners[i]
+			lr := pr.reportListener(lis.Name)
 			// Confirm that the Listener and Route protocols match.
-			if !gwProtocolMatches(rt.Protocol(), lis.Protocol) {
+			if !gwListenerProtocolMatches(protocol, lis, c.src.strictProtocols) {
+				c.skip(lr, gwSkipProtocolMismatch)
 				continue
 			}
 			// Confirm that the Listener and Route ports match, if specified.
 			// EXPERIMENTAL: https://gateway-api.sigs.k8s.io/geps/gep-957/
 			if ref.Port != nil && *ref.Port != lis.Port {
+				lr.reject(gwSkipPortMismatch)
+				continue
+			}
+			// Confirm that the Listener uses the well-known port of its protocol if the Route
+			// specifies neither a section nor a port, if strict listener ports are enabled.
+			if c.src.strictListenerPorts && section == "" && ref.Port == nil && !gwListenerOnDefaultPort(lis) {
+				lr.reject(gwSkipNonDefaultPort)
 				continue
 			}
 			// Confirm that the Listener allows the Route (based on namespace and kind).
-			if !c.routeIsAllowed(gw.gateway, lis, rt) {
+			if !c.routeIsAllowed(gw.gateway, lis, rt, lr) {
+				continue
+			}
+			// Confirm that the Gateway's status reports Routes of this kind attached to the Listener, if required.
+			if c.src.requireAttachedRoutes && !gwListenerHasAttachedRoutes(gw.gateway, lis.Name, c.src.routeGroupKind(rt)) {
+				glog.withSection(lis.Name).Debugf("Gateway %s/%s section %q reports no attached routes of %s %s/%s", namespace, ref.Name, lis.Name, c.src.rtKind, meta.Namespace, meta.Name)
+				lr.reject(gwSkipNotAttached)
+				continue
+			}
+			// Gateways without targets may fall back to the Route's backends, if enabled.
+			lisAddrs := gw.listenerAddrs[string(lis.Name)]
+			lisTargets := c.nodeTargets(gwListenerTargets(gw.gateway, lis, lisAddrs, access, c.src.preferredAddressType, pinned), endpointsType)
+			var internalTargets gatewayTargets
+			if len(internalHosts) > 0 {
+				internalTargets = c.nodeTargets(gwListenerTargets(gw.gateway, lis, lisAddrs, "private", c.src.preferredAddressType, pinned), endpointsType)
+			}
+			if len(pinned) > 0 {
+				for _, addr := range gw.gateway.Status.Addresses {
+					pinnedFound[gwAddressValue(addr.Value)] = true
+				}
+			}
+			if len(lisTargets) == 0 && len(gw.gateway.Status.Addresses) == 0 {
+				lisTargets = c.classTargets(gw.gateway)
+			}
+			if len(lisTargets) == 0 && len(gw.gateway.Status.Addresses) == 0 {
+				lisTargets = backends
+			}
+			// Until the Gateway has addresses, its hosts may point at the fallback targets, if any.
+			lisFallback := false
+			if len(lisTargets) == 0 && len(gw.gateway.Status.Addresses) == 0 && len(c.src.fallbackTargets) > 0 {
+				lisTargets, lisFallback = c.src.fallbackTargets, true
+			}
+			lisTargets = gwAllowedTargets(gw.gateway, c.hostTargets(ctx, lisTargets), c.src.targetCIDRs)
+			// Gateways without addresses can't be classified, so their internal hostnames share the targets above.
+			if len(gw.gateway.Status.Addresses) == 0 {
+				internalTargets = lisTargets
+			} else {
+				internalTargets = gwAllowedTargets(gw.gateway, c.hostTargets(ctx, internalTargets), c.src.targetCIDRs)
+			}
+			matchHosts = true
+			lisRtHosts := rtHosts
+			if listenerTemplate {
+				lisRtHosts, err = c.listenerTemplateHosts(rt, lis, rtHosts)
+				if err != nil {
+					return nil, err
+				}
+			}
+			// Find all overlapping hostnames between the Route and Listener.
+			// For {TCP,UDP}Routes, all annotation-generated hostnames should match since the Listener doesn't specify a hostname.
+			// For {HTTP,TLS}Routes, hostnames (including any annotation-generated) will be required to match any Listeners specified hostname.
+			// Listeners without a hostname may also be limited to the SANs of their certificates, if enabled.
+			for _, gwHost := range gw.listenerHosts(lis) {
+				for _, rtHost := range append(slices.Clone(lisRtHosts), internalHosts...) {
+					if gwHost == "" && rtHost == "" {
+						// For {HTTP,TLS}Routes, this means the Route and the Listener both allow _any_ hostnames.
+						// For {TCP,UDP}Routes, this should always happen since neither specifies hostnames.
+						continue
+					}
+					host, ok := gwMatchingHost(gwHost, rtHost, c.src.multiLabelWildcards)
+					if !ok {
+						continue
+					}
+					if !gwHostHasSuffix(host, c.src.hostSuffixes) {
+						if !unsuffixed[host] {
+							unsuffixed[host] = true
+							c.skipUnsuffixedHost(rt, host)
+						}
+						continue
+					}
+					// Over-broad wildcard Listeners may be kept from publishing some of the hosts they match.
+					if gwHostExcluded(host, gw.excludedHosts, c.src.multiLabelWildcards) {
+						glog.Debugf("Skipping host %s of %s %s/%s excluded by Gateway %s/%s", host, c.src.rtKind, meta.Namespace, meta.Name, namespace, ref.Name)
+						continue
+					}
+					h, ok := hostTargets[host]
+					if !ok {
+						h = &gatewayHost{targets: make(gatewayTargets), provisional: true}
+						hostTargets[host] = h
+					}
+					h.provisional = h.provisional && !accepted
+					// If the host is published by multiple Gateways or Listeners, use the lowest of their TTLs.
+					if lisTTL := gw.listenerTTL(lis); lisTTL.IsConfigured() && (!h.ttl.IsConfigured() || lisTTL < h.ttl) {
+						h.ttl = lisTTL
+						h.ttlGateway = namespacedName(gw.gateway.Namespace, gw.gateway.Name)
+					}
+					h.alias = h.alias || gw.alias
+					h.providerSpecific = gwMergeProviderSpecific(h.providerSpecific, gw.providerSpecific)
+					targets := lisTargets
+					if slices.Contains(internalHosts, rtHost) {
+						targets = internalTargets
+					}
+					h.addTargets(targets, lisFallback)
+					h.addGatewayTargets(gw, targets, lisFallback)
+					for _, svc := range gwListenerServices(c.src.listenerRecordTypes, lis) {
+						h.addService(svc)
+					}
+					if hostGateways != nil {
+						if hostGateways[host] == nil {
+							hostGateways[host] = make(map[types.NamespacedName]bool)
+						}
+						hostGateways[host][namespacedName(namespace, string(ref.Name))] = true
+					}
+					lr.addHost(host)
+					match = true
+				}
+			}
+			if lr != nil && len(lr.Hosts) == 0 {
+				lr.reject(gwSkipNoMatchingHost)
+			}
+		}
+		if !match {
+			glog.withSection(section).Debugf("Gateway %s/%s section %q does not match %s %s/%s hostnames %q", namespace, ref.Name, section, c.src.rtKind, meta.Namespace, meta.Name, rtHosts)
+			switch {
+			case matchHosts:
+				c.skip(pr, gwSkipNoMatchingHost)
+			case len(listeners) == 0:
+				pr.reject(gwSkipListenerNotFound)
+			}
+		}
+	}
+	for _, value := range pinned {
+		if !pinnedFound[value] {
+			rlog.Warnf("Ignoring gateway address %s of %s %s/%s, which none of its Gateways has", value, c.src.rtKind, meta.Namespace, meta.Name)
+		}
+	}
+	if hostGateways != nil {
+		// Only keep the hosts matched by every Gateway the Route references, regardless of
+		// whether those Gateways exist, have accepted the Route, or pass the Gateway filters.
+		parents := gwParentGateways(routeParentRefs, meta, c.src.parentGroupKind)
+		for host := range hostTargets {
+			if len(hostGateways[host]) < len(parents) {
+				rlog.Debugf("Host %s of %s %s/%s is not matched by all of its Gateways", host, c.src.rtKind, meta.Namespace, meta.Name)
+				delete(hostTargets, host)
+			}
+		}
+	}
+	return hostTargets, nil
+}
+
+// skipUnsuffixedHost logs that the host of the Route is dropped because it's outside of the domains
+// of the hostname suffixes, e.g. the zones that ExternalDNS manages, whose providers would reject it.
+// It's a debug message unless warnings are enabled.
+func (c *gatewayRouteResolver) skipUnsuffixedHost(rt gatewayRoute, host string) {
+	level := log.DebugLevel
+	if c.src.warnUnsuffixedHosts {
+		level = log.WarnLevel
+	}
+	meta := rt.Metadata()
+	c.src.routeLog(rt).logf(level, nil, "Skipping host %s of %s %s/%s without an allowed hostname suffix", host, c.src.rtKind, meta.Namespace, meta.Name)
+}
+
+// addWildcardApexes adds the apex of each wildcard host with the same targets, e.g. example.com for
+// *.example.com, if enabled and the apex isn't a host already. Since most providers don't allow
+// CNAME records at the apex of a zone, apexes with hostname targets request alias records.
+func (c *gatewayRouteResolver) addWildcardApexes(rt gatewayRoute, hostTargets map[string]*gatewayHost) {
+	if !c.src.wildcardApex {
+		return
+	}
+	meta := rt.Metadata()
+	for host, h := range hostTargets {
+		apex, ok := strings.CutPrefix(host, "*.")
+		if !ok {
+			continue
+		}
+		if _, ok := hostTargets[apex]; ok || !gwHostHasSuffix(apex, c.src.hostSuffixes) {
+			continue
+		}
+		c.src.routeLog(rt).Debugf("Adding apex %s of wildcard host %s of %s %s/%s", apex, host, c.src.rtKind, meta.Namespace, meta.Name)
+		ah := *h
+		ah.targets = make(gatewayTargets, len(h.targets))
+		for recordType, tgts := range h.targets {
+			ah.targets[recordType] = slices.Clone(tgts)
+		}
+		ah.weighted = maps.Clone(h.weighted)
+		ah.alias = h.alias || len(h.targets[endpoint.RecordTypeCNAME]) > 0
+		hostTargets[apex] = &ah
+	}
+}
+
+// endpointsType returns the endpoints type requested by the Route's annotation, if Nodes are resolved.
+func (c *gatewayRouteResolver) endpointsType(rt gatewayRoute) string {
+	meta := rt.Metadata()
+	endpointsType := getEndpointsTypeFromAnnotations(meta.Annotations)
+	switch {
+	case endpointsType == "":
+		return ""
+	case endpointsType != EndpointsTypeNodeExternalIP && endpointsType != EndpointsTypeHostIP:
+		c.src.routeLog(rt).Warnf("Ignoring unsupported endpoints type %q of %s %s/%s", endpointsType, c.src.rtKind, meta.Namespace, meta.Name)
+		return ""
+	case c.src.ndInformer == nil:
+		c.src.routeLog(rt).Debugf("Ignoring endpoints type %q of %s %s/%s because Nodes aren't resolved", endpointsType, c.src.rtKind, meta.Namespace, meta.Name)
+		return ""
+	}
+	return endpointsType
+}
+
+// nodeTargets replaces the targets that are addresses of a Node with the addresses of that Node
+// selected by the endpoints type: its ExternalIPs for NodeExternalIP and its InternalIPs for HostIP.
+// Other targets are kept as they are.
+func (c *gatewayRouteResolver) nodeTargets(targets gatewayTargets, endpointsType string) gatewayTargets {
+	if endpointsType == "" {
+		return targets
+	}
+	result := make(gatewayTargets, len(targets))
+	for recordType, tgts := range targets {
+		for _, target := range tgts {
+			nd, ok := c.nds[target]
+			if !ok {
+				result[recordType] = append(result[recordType], target)
+				continue
+			}
+			for _, addr := range gwNodeAddresses(nd, endpointsType) {
+				addrType := suitableType(addr)
+				result[addrType] = append(result[addrType], addr)
+			}
+		}
+	}
+	return result
+}
+
+// gwNodeAddresses returns the addresses of the Node selected by the endpoints type.
+func gwNodeAddresses(nd *corev1.Node, endpointsType string) []string {
+	addrType := corev1.NodeInternalIP
+	if endpointsType == EndpointsTypeNodeExternalIP {
+		addrType = corev1.NodeExternalIP
+	}
+	var addrs []string
+	for _, addr := range nd.Status.Addresses {
+		if addr.Type == addrType {
+			addrs = append(addrs, addr.Address)
+		}
+	}
+	return addrs
+}
+
+// allNodeAddresses returns the addresses of all Nodes selected by the endpoints type.
+func (c *gatewayRouteResolver) allNodeAddresses(endpointsType string) endpoint.Targets {
+	var addrs endpoint.Targets
+	for _, nd := range c.nodes {
+		addrs = append(addrs, gwNodeAddresses(nd, endpointsType)...)
+	}
+	return addrs
+}
+
+// backendTargets returns the addresses of the Services referenced by the Route's backendRefs.
+// LoadBalancer Services contribute their ingress addresses and ClusterIP Services their cluster IP.
+// If the Route requests an endpoints type, NodePort Services contribute the selected addresses of all Nodes.
+func (c *gatewayRouteResolver) backendTargets(rt gatewayRoute, endpointsType string) gatewayTargets {
+	targets := make(gatewayTargets)
+	for _, svc := range c.backendServices(rt) {
+		var addrs endpoint.Targets
+		switch svc.Spec.Type {
+		case corev1.ServiceTypeLoadBalancer:
+			addrs = extractLoadBalancerTargets(svc, false)
+		case corev1.ServiceTypeClusterIP:
+			if addrs = c.zoneTargets(rt, svc); len(addrs) == 0 {
+				addrs = extractServiceIps(svc)
+			}
+		case corev1.ServiceTypeNodePort:
+			if endpointsType != "" {
+				addrs = c.allNodeAddresses(endpointsType)
+			}
+		}
+		for _, addr := range addrs {
+			recordType := suitableType(addr)
+			targets[recordType] = append(targets[recordType], addr)
+		}
+	}
+	return targets
+}
+
+// backendServices returns the Services referenced by the Route's backendRefs that it may reference.
+func (c *gatewayRouteResolver) backendServices(rt gatewayRoute) []*corev1.Service {
+	meta := rt.Metadata()
+	rlog := c.src.routeLog(rt)
+	var services []*corev1.Service
+	for _, ref := range rt.BackendRefs() {
+		group := strVal((*string)(ref.Group), "")
+		kind := strVal((*string)(ref.Kind), "Service")
+		if group != "" || kind != "Service" {
+			rlog.Debugf("Unsupported backend %s/%s for %s %s/%s", group, kind, c.src.rtKind, meta.Namespace, meta.Name)
+			continue
+		}
+		namespace := strVal((*string)(ref.Namespace), meta.Namespace)
+		if namespace != meta.Namespace && !c.referenceIsGranted(
+			c.src.routeGroupKind(rt), meta.Namespace,
+			schema.GroupKind{Kind: kind}, namespacedName(namespace, string(ref.Name)),
+		) {
+			rlog.Debugf("No ReferenceGrant allows %s %s/%s to reference Service %s/%s", c.src.rtKind, meta.Namespace, meta.Name, namespace, ref.Name)
+			continue
+		}
+		svc, err := c.src.svcInformer.Lister().Services(namespace).Get(string(ref.Name))
+		if err != nil {
+			rlog.Debugf("Failed to get Service %s/%s for %s %s/%s: %v", namespace, ref.Name, c.src.rtKind, meta.Namespace, meta.Name, err)
+			continue
+		}
+		services = append(services, svc)
+	}
+	return services
+}
+
+// zoneTargets returns the addresses of the Service's ready endpoints whose topology hints are for
+// the backend zone, if one is configured. Like kube-proxy, the hints are only used if all ready
+// endpoints have them; otherwise nil is returned and the Service's own address should be used.
+func (c *gatewayRouteResolver) zoneTargets(rt gatewayRoute, svc *corev1.Service) endpoint.Targets {
+	rlog := c.src.routeLog(rt)
+	svcFields := log.Fields{"serviceNamespace": svc.Namespace, "serviceName": svc.Name}
+	esInformer, ok := c.esInformers[svc.Namespace]
+	if !ok {
+		return nil
+	}
+	selector := labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: svc.Name})
+	endpointSlices, err := esInformer.Lister().EndpointSlices(svc.Namespace).List(selector)
+	if err != nil {
+		rlog.logf(log.DebugLevel, svcFields, "Failed to list EndpointSlices of Service %s/%s: %v", svc.Namespace, svc.Name, err)
+		return nil
+	}
+	var targets endpoint.Targets
+	for _, es := range endpointSlices {
+		if es.AddressType != discoveryv1.AddressTypeIPv4 && es.AddressType != discoveryv1.AddressTypeIPv6 {
+			continue
+		}
+		for _, ep := range es.Endpoints {
+			// A nil condition means that the endpoint is ready.
+			if ep.Conditions.Ready != nil && !*ep.Conditions.Ready {
+				continue
+			}
+			if ep.Hints == nil || len(ep.Hints.ForZones) == 0 {
+				rlog.logf(log.DebugLevel, svcFields, "Ignoring the topology hints of Service %s/%s because EndpointSlice %s has endpoints without hints", svc.Namespace, svc.Name, es.Name)
+				return nil
+			}
+			if slices.ContainsFunc(ep.Hints.ForZones, func(z discoveryv1.ForZone) bool { return z.Name == c.src.backendZone }) {
+				targets = append(targets, ep.Addresses...)
+			}
+		}
+	}
+	if len(targets) == 0 {
+		rlog.logf(log.DebugLevel, svcFields, "No ready endpoints of Service %s/%s are hinted for zone %s", svc.Namespace, svc.Name, c.src.backendZone)
+	}
+	return targets
+}
+
+// gatewayListenerTemplateRoute is implemented by Routes whose FQDN template is executed for each
+// matched Listener, so that it may refer to the Listener's {{ .Port }} and {{ .Protocol }}.
+type gatewayListenerTemplateRoute interface {
+	// listenerTemplateObject returns the Route extended with the Port and Protocol of the Listener.
+	listenerTemplateObject(lis *v1.Listener) kubeObject
+}
+
+// gatewayFilterHostnamesRoute is implemented by Routes whose filters may redirect or rewrite
+// requests to other hostnames.
+type gatewayFilterHostnamesRoute interface {
+	// FilterHostnames returns the hostnames of the Route's filters.
+	FilterHostnames() []v1.PreciseHostname
+}
+
+// gwIsAbsoluteHost returns whether the hostname of a filter names a specific host of a domain,
+// rather than being empty, a wildcard, or a single label that clients resolve against their search domains.
+func gwIsAbsoluteHost(host string) bool {
+	host = strings.TrimSuffix(host, ".")
+	return host != "" && !strings.Contains(host, "*") && strings.Contains(host, ".")
+}
+
+// hosts returns the hostnames of the Route. If the FQDN template applies to the Route but is
+// executed for each matched Listener instead, its hostnames are left out and true is returned.
+func (c *gatewayRouteResolver) hosts(rt gatewayRoute) ([]string, bool, error) {
+	var hostnames []string
+	for _, name := range rt.Hostnames() {
+		hostnames = append(hostnames, string(name))
+	}
+	// Like the other sources, the hostname annotation is ignored regardless of whether an FQDN
+	// template is set, and the template only applies to Routes without other hostnames unless
+	// the template and annotation hostnames are combined.
+	if !c.src.ignoreHostnameAnnotation {
+		hostnames = append(hostnames, annotations.HostnamesFromAnnotations(rt.Metadata().Annotations)...)
+		// The hostname annotations of backend Services are treated like that of the Route, if enabled.
+		if c.src.backendHostnames {
+			for _, svc := range c.backendServices(rt) {
+				hostnames = append(hostnames, annotations.HostnamesFromAnnotations(svc.Annotations)...)
+			}
+		}
+	}
+	listenerTemplate := false
+	if c.src.fqdnTemplate != nil && (len(hostnames) == 0 || c.src.combineFQDNAnnotation) && !c.ignoreTemplate(rt) {
+		if _, ok := rt.(gatewayListenerTemplateRoute); ok {
+			listenerTemplate = true
+		} else {
+			hosts, err := fqdn.ExecTemplate(c.src.fqdnTemplate, rt.Object())
+			if err != nil {
+				return nil, false, err
+			}
+			hostnames = append(hostnames, hosts...)
+		}
+	}
+	adopt := c.adoptListenerHosts(rt, len(hostnames) > 0 || listenerTemplate)
+	// The hostnames that the Route's filters redirect or rewrite requests to are published in
+	// addition to the others, so they don't stop the Route from adopting the Listeners' hostnames.
+	if fr, ok := rt.(gatewayFilterHostnamesRoute); ok && c.src.filterHostnames {
+		for _, name := range fr.FilterHostnames() {
+			if host := string(name); gwIsAbsoluteHost(host) {
+				hostnames = append(hostnames, host)
+			}
+		}
+	}
+	hostnames = c.validHosts(rt, c.excludeHosts(rt, gwCanonicalHosts(hostnames)))
+	// This means that the route doesn't specify a hostname and should use any provided by
+	// attached Gateway Listeners. This is only useful for {HTTP,TLS}Routes, but it doesn't
+	// break {TCP,UDP}Routes.
+	if adopt {
+		hostnames = append(hostnames, "")
+	}
+	return hostnames, listenerTemplate, nil
+}
+
+// adoptListenerHosts returns whether the Route adopts the hostnames of the Listeners it matches,
+// which is the case if its spec has no hostnames. With strict hostnames, {HTTP,TLS}Routes whose
+// hostnames come from annotations or the FQDN template only publish those, while {TCP,UDP}Routes,
+// which can't have hostnames in their spec, still adopt those of their Listeners.
+func (c *gatewayRouteResolver) adoptListenerHosts(rt gatewayRoute, annotated bool) bool {
+	if len(rt.Hostnames()) > 0 {
+		return false
+	}
+	if !c.src.strictHostnames || !annotated {
+		return true
+	}
+	protocol := rt.Protocol()
+	return protocol == v1.TCPProtocolType || protocol == v1.UDPProtocolType
+}
+
+// internalHosts returns the hostnames of the Route's internal-hostname annotation, which is ignored
+// along with the hostname annotation.
+func (c *gatewayRouteResolver) internalHosts(rt gatewayRoute) []string {
+	if c.src.ignoreHostnameAnnotation {
+		return nil
+	}
+	hostnames := annotations.InternalHostnamesFromAnnotations(rt.Metadata().Annotations)
+	if len(hostnames) == 0 {
+		return nil
+	}
+	return c.validHosts(rt, c.excludeHosts(rt, gwCanonicalHosts(hostnames)))
+}
+
+// listenerTemplateHosts returns the hostnames of the Route along with those of the FQDN template
+// executed for the Listener.
+func (c *gatewayRouteResolver) listenerTemplateHosts(rt gatewayRoute, lis *v1.Listener, rtHosts []string) ([]string, error) {
+	hosts, err := fqdn.ExecTemplate(c.src.fqdnTemplate, rt.(gatewayListenerTemplateRoute).listenerTemplateObject(lis))
+	if err != nil {
+		return nil, err
+	}
+	return gwCanonicalHosts(append(slices.Clone(rtHosts), c.excludeHosts(rt, gwCanonicalHosts(hosts))...)), nil
+}
+
+// validHosts removes the invalid hostnames of the Route, which can't match any Listener, and
+// reports why each of them is invalid.
+func (c *gatewayRouteResolver) validHosts(rt gatewayRoute, hostnames []string) []string {
+	var errs []error
+	hostnames = slices.DeleteFunc(hostnames, func(hostname string) bool {
+		err := gwHostError(hostname)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		return err != nil
+	})
+	if len(errs) == 0 {
+		return hostnames
+	}
+	if c.report != nil {
+		for _, err := range errs {
+			c.report.InvalidHosts = append(c.report.InvalidHosts, err.Error())
+		}
+		return hostnames
+	}
+	meta := rt.Metadata()
+	gatewayInvalidHostnamesTotal.CounterVec.WithLabelValues(c.src.rtKind).Add(float64(len(errs)))
+	c.src.routeLog(rt).Debugf("Ignoring %d invalid hostnames of %s %s/%s: %v", len(errs), c.src.rtKind, meta.Namespace, meta.Name, errors.Join(errs...))
+	return hostnames
+}
+
+// excludeHosts removes the hostnames covered by the Route's exclude-hostnames annotation, whose
+// wildcard entries match like those of Listeners.
+func (c *gatewayRouteResolver) excludeHosts(rt gatewayRoute, hostnames []string) []string {
+	meta := rt.Metadata()
+	excluded := annotations.ExcludeHostnamesFromAnnotations(meta.Annotations)
+	if len(excluded) == 0 {
+		return hostnames
+	}
+	rlog := c.src.routeLog(rt)
+	return slices.DeleteFunc(hostnames, func(hostname string) bool {
+		name, ok := gwHost(hostname)
+		if !ok || !gwHostExcluded(name, excluded, c.src.multiLabelWildcards) {
+			return false
+		}
+		rlog.Debugf("Excluding hostname %s of %s %s/%s", hostname, c.src.rtKind, meta.Namespace, meta.Name)
+		return true
+	})
+}
+
+// gwHostExcluded returns whether any of the excluded hostnames covers the host, whose wildcard
+// entries match like those of Listeners.
+func gwHostExcluded(host string, excluded []string, multiLabelWildcards bool) bool {
+	for _, e := range excluded {
+		if e == "" {
+			continue
+		}
+		if match, ok := gwMatchingHost(e, host, multiLabelWildcards); ok && match == host {
+			return true
+		}
+	}
+	return false
+}
+
+// expandWildcards replaces the wildcard hosts of a Route with the concrete hostnames listed in its
+// wildcard-hostnames annotation, for providers that don't support wildcard records. Listed hostnames
+// that don't fall under any of the Route's wildcard hosts are dropped.
+func (c *gatewayRouteResolver) expandWildcards(rt gatewayRoute, hostTargets map[string]*gatewayHost) {
+	meta := rt.Metadata()
+	names := annotations.WildcardHostnamesFromAnnotations(meta.Annotations)
+	if len(names) == 0 {
+		return
+	}
+	expanded := make(map[string]bool, len(names))
+	for wildcard, h := range hostTargets {
+		if !strings.HasPrefix(wildcard, "*.") {
+			continue
+		}
+		replaced := false
+		for _, name := range names {
+			if strings.Contains(name, "*") {
+				continue
+			}
+			host, ok := gwMatchingHost(wildcard, name, c.src.multiLabelWildcards)
+			if !ok {
 				continue
 			}
-			// Find all overlapping hostnames between the Route and Listener.
-			// For {TCP,UDP}Routes, all annotation-generated hostnames should match since the Listener doesn't specify a hostname.
-			// For {HTTP,TLS}Routes, hostnames (including any annotation-generated) will be required to match any Listeners specified hostname.
-			gwHost := ""
-			if lis.Hostname != nil {
-				gwHost = string(*lis.Hostname)
-			}
-			for _, rtHost := range rtHosts {
-				if gwHost == "" && rtHost == "" {
-					// For {HTTP,TLS}Routes, this means the Route and the Listener both allow _any_ hostnames.
-					// For {TCP,UDP}Routes, this should always happen since neither specifies hostnames.
-					continue
-				}
-				host, ok := gwMatchingHost(gwHost, rtHost)
-				if !ok {
-					continue
-				}
-				override := annotations.TargetsFromTargetAnnotation(gw.gateway.Annotations)
-				hostTargets[host] = append(hostTargets[host], override...)
-				if len(override) == 0 {
-					for _, addr := range gw.gateway.Status.Addresses {
-						hostTargets[host] = append(hostTargets[host], addr.Value)
-					}
+			eh, ok := hostTargets[host]
+			if !ok {
+				eh = &gatewayHost{targets: make(gatewayTargets), provisional: true}
+				hostTargets[host] = eh
+			}
+			eh.provisional = eh.provisional && h.provisional
+			if h.ttl.IsConfigured() && (!eh.ttl.IsConfigured() || h.ttl < eh.ttl) {
+				eh.ttl = h.ttl
+				eh.ttlGateway = h.ttlGateway
+			}
+			eh.alias = eh.alias || h.alias
+			eh.providerSpecific = gwMergeProviderSpecific(eh.providerSpecific, h.providerSpecific)
+			eh.addTargets(h.targets, h.fallback)
+			eh.unweighted = eh.unweighted || h.unweighted
+			for key, w := range h.weighted {
+				if eh.weighted == nil {
+					eh.weighted = make(map[types.NamespacedName]gatewayWeightedTargets)
 				}
-				match = true
+				eh.addWeightedTargets(key, w)
+			}
+			for _, svc := range h.services {
+				eh.addService(svc)
 			}
+			expanded[name] = true
+			replaced = true
 		}
-		if !match {
-			log.Debugf("Gateway %s/%s section %q does not match %s %s/%s hostnames %q", namespace, ref.Name, section, c.src.rtKind, meta.Namespace, meta.Name, rtHosts)
+		if replaced {
+			delete(hostTargets, wildcard)
 		}
 	}
-	// If a Gateway has multiple matching Listeners for the same host, then we'll
-	// add its IPs to the target list multiple times and should dedupe them.
-	for host, targets := range hostTargets {
-		hostTargets[host] = uniqueTargets(targets)
+	for _, name := range names {
+		if !expanded[name] {
+			c.src.routeLog(rt).Warnf("Ignoring wildcard hostname %q of %s %s/%s that doesn't fall under any of its wildcard hosts", name, c.src.rtKind, meta.Namespace, meta.Name)
+		}
 	}
-	return hostTargets, nil
 }
 
-func (c *gatewayRouteResolver) hosts(rt gatewayRoute) ([]string, error) {
-	var hostnames []string
-	for _, name := range rt.Hostnames() {
-		hostnames = append(hostnames, string(name))
-	}
-	// TODO: The ignore-hostname-annotation flag help says "valid only when using fqdn-template"
-	// but other sources don't check if fqdn-template is set. Which should it be?
-	if !c.src.ignoreHostnameAnnotation {
-		hostnames = append(hostnames, annotations.HostnamesFromAnnotations(rt.Metadata().Annotations)...)
-	}
-	// TODO: The combine-fqdn-annotation flag is similarly vague.
-	if c.src.fqdnTemplate != nil && (len(hostnames) == 0 || c.src.combineFQDNAnnotation) {
-		hosts, err := fqdn.ExecTemplate(c.src.fqdnTemplate, rt.Object())
-		if err != nil {
-			return nil, err
-		}
-		hostnames = append(hostnames, hosts...)
-	}
-	// This means that the route doesn't specify a hostname and should use any provided by
-	// attached Gateway Listeners. This is only useful for {HTTP,TLS}Routes, but it doesn't
-	// break {TCP,UDP}Routes.
-	if len(rt.Hostnames()) == 0 {
-		hostnames = append(hostnames, "")
+// ignoreTemplate returns whether the FQDN template must not be applied to the Route.
+// {TCP,UDP}Routes don't have hostnames, so a template would apply to all of them, if enabled.
+func (c *gatewayRouteResolver) ignoreTemplate(rt gatewayRoute) bool {
+	if !c.src.ignoreL4FQDNTemplate {
+		return false
 	}
-	return hostnames, nil
+	protocol := rt.Protocol()
+	return protocol == v1.TCPProtocolType || protocol == v1.UDPProtocolType
 }
 
-func (c *gatewayRouteResolver) routeIsAllowed(gw *v1beta1.Gateway, lis *v1.Listener, rt gatewayRoute) bool {
+func (c *gatewayRouteResolver) routeIsAllowed(gw *v1beta1.Gateway, lis *v1.Listener, rt gatewayRoute, lr *GatewayListenerReport) bool {
 	meta := rt.Metadata()
 	allow := lis.AllowedRoutes
+	llog := c.src.routeLog(rt).gateway(gw.Namespace, gw.Name).withSection(lis.Name)
 
 	// Check the route's namespace.
 	from := v1.NamespacesFromSame
@@ -440,53 +3143,228 @@ func (c *gatewayRouteResolver) routeIsAllowed(gw *v1beta1.Gateway, lis *v1.Liste
 		// OK
 	case v1.NamespacesFromSame:
 		if gw.Namespace != meta.Namespace {
+			c.skip(lr, gwSkipNamespaceNotAllowed)
 			return false
 		}
 	case v1.NamespacesFromSelector:
 		selector, err := metav1.LabelSelectorAsSelector(allow.Namespaces.Selector)
 		if err != nil {
-			log.Debugf("Gateway %s/%s section %q has invalid namespace selector: %v", gw.Namespace, gw.Name, lis.Name, err)
+			llog.Debugf("Gateway %s/%s section %q has invalid namespace selector: %v", gw.Namespace, gw.Name, lis.Name, err)
+			lr.reject(gwSkipInvalidSelector)
 			return false
 		}
 		// Get namespace.
 		ns, ok := c.nss[meta.Namespace]
 		if !ok {
-			log.Errorf("Namespace not found for %s %s/%s", c.src.rtKind, meta.Namespace, meta.Name)
+			llog.Errorf("Namespace not found for %s %s/%s", c.src.rtKind, meta.Namespace, meta.Name)
+			lr.reject(gwSkipNamespaceNotFound)
 			return false
 		}
 		if !selector.Matches(labels.Set(ns.Labels)) {
-			return false
+			// Tooling that keys multi-tenancy off annotations may select namespaces by them instead, if enabled.
+			if !c.src.nsAnnotationSelector || !selector.Matches(labels.Set(ns.Annotations)) {
+				c.skip(lr, gwSkipNamespaceNotAllowed)
+				return false
+			}
+			llog.Debugf("Gateway %s/%s section %q allows %s %s/%s by the annotations of its namespace", gw.Namespace, gw.Name, lis.Name, c.src.rtKind, meta.Namespace, meta.Name)
 		}
 	default:
-		log.Debugf("Gateway %s/%s section %q has unknown namespace from %q", gw.Namespace, gw.Name, lis.Name, from)
+		llog.Debugf("Gateway %s/%s section %q has unknown namespace from %q", gw.Namespace, gw.Name, lis.Name, from)
+		lr.reject(gwSkipNamespaceNotAllowed)
 		return false
 	}
 
 	// Check the route's kind, if any are specified by the listener.
 	// TODO: Do we need to consider SupportedKinds in the ListenerStatus instead of the Spec?
-	// We only support core kinds and already check the protocol... Does this matter at all?
 	if allow == nil || len(allow.Kinds) == 0 {
 		return true
 	}
-	gvk := rt.Object().GetObjectKind().GroupVersionKind()
-	for _, gk := range allow.Kinds {
-		group := strVal((*string)(gk.Group), gatewayGroup)
-		if gvk.Group == group && gvk.Kind == string(gk.Kind) {
+	if !gwRouteKindAllowed(allow.Kinds, c.src.routeGroupKind(rt)) {
+		lr.reject(gwSkipKindNotAllowed)
+		return false
+	}
+	return true
+}
+
+// gwListenerHasAttachedRoutes returns whether the status of the Gateway reports Routes attached to
+// the Listener and, if it lists the Listener's supported kinds, whether they include the Route's kind.
+func gwListenerHasAttachedRoutes(gw *v1beta1.Gateway, name v1.SectionName, route schema.GroupKind) bool {
+	for _, ls := range gw.Status.Listeners {
+		if ls.Name != name {
+			continue
+		}
+		if ls.AttachedRoutes == 0 {
+			return false
+		}
+		return len(ls.SupportedKinds) == 0 || gwRouteKindAllowed(ls.SupportedKinds, route)
+	}
+	return false
+}
+
+// routeGroupKind returns the group and kind of the Route. They are taken from the Route's TypeMeta,
+// so that Routes of custom groups are matched by their actual group, and otherwise default to
+// the Gateway API group and the kind of the source.
+func (src *gatewayRouteSource) routeGroupKind(rt gatewayRoute) schema.GroupKind {
+	gk := rt.Object().GetObjectKind().GroupVersionKind().GroupKind()
+	if gk.Kind == "" {
+		return schema.GroupKind{Group: gatewayGroup, Kind: src.rtKind}
+	}
+	return gk
+}
+
+// gwRouteKindAllowed returns whether any of the kinds allowed by a Listener matches the Route's
+// group and kind. Kinds without a group refer to the Gateway API group, as defined by the spec,
+// while an empty group refers to the core group.
+func gwRouteKindAllowed(kinds []v1.RouteGroupKind, route schema.GroupKind) bool {
+	for _, gk := range kinds {
+		group := gatewayGroup
+		if gk.Group != nil {
+			group = string(*gk.Group)
+		}
+		if route.Group == group && route.Kind == string(gk.Kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeLog returns the logger of the Route, which adds the fields identifying it to its logs, so
+// that they can be queried regardless of their message.
+func (src *gatewayRouteSource) routeLog(rt gatewayRoute) gatewayRouteLog {
+	return src.metaLog(rt.Metadata())
+}
+
+// metaLog returns the logger of the Route with the metadata.
+func (src *gatewayRouteSource) metaLog(meta *metav1.ObjectMeta) gatewayRouteLog {
+	return gatewayRouteLog{kind: src.rtKind, namespace: meta.Namespace, name: meta.Name}
+}
+
+// gatewayRouteLog logs with the fields identifying a Route and, optionally, one of its Gateways and
+// Listeners. The log entry is only built for messages of enabled levels, since most of them are
+// debug logs that would otherwise allocate an entry for every Route, parent, and Listener of a sync.
+type gatewayRouteLog struct {
+	kind        string
+	namespace   string
+	name        string
+	gwNamespace string
+	gwName      string
+	section     v1.SectionName
+}
+
+// gateway returns the logger with the fields identifying the Gateway.
+func (l gatewayRouteLog) gateway(namespace, name string) gatewayRouteLog {
+	l.gwNamespace, l.gwName = namespace, name
+	return l
+}
+
+// withSection returns the logger with the field identifying the Listener of the Gateway.
+func (l gatewayRouteLog) withSection(section v1.SectionName) gatewayRouteLog {
+	l.section = section
+	return l
+}
+
+// logf logs the message at the level with the identifying fields and the given ones, if the level is enabled.
+func (l gatewayRouteLog) logf(level log.Level, fields log.Fields, format string, args ...any) {
+	if !log.IsLevelEnabled(level) {
+		return
+	}
+	entry := log.Fields{
+		"routeKind":      l.kind,
+		"routeNamespace": l.namespace,
+		"routeName":      l.name,
+	}
+	if l.gwName != "" {
+		entry["gatewayNamespace"], entry["gatewayName"] = l.gwNamespace, l.gwName
+	}
+	if l.section != "" {
+		entry["section"] = l.section
+	}
+	maps.Copy(entry, fields)
+	log.WithFields(entry).Logf(level, format, args...)
+}
+
+func (l gatewayRouteLog) Debugf(format string, args ...any) {
+	l.logf(log.DebugLevel, nil, format, args...)
+}
+
+func (l gatewayRouteLog) Infof(format string, args ...any) {
+	l.logf(log.InfoLevel, nil, format, args...)
+}
+
+func (l gatewayRouteLog) Warnf(format string, args ...any) {
+	l.logf(log.WarnLevel, nil, format, args...)
+}
+
+func (l gatewayRouteLog) Errorf(format string, args ...any) {
+	l.logf(log.ErrorLevel, nil, format, args...)
+}
+
+// skip records that a Route, parent, or Listener was skipped for the given reason.
+// Skips are only counted outside of validation, which must not affect the metrics.
+func (c *gatewayRouteResolver) skip(r gatewayRejecter, reason string) {
+	r.reject(reason)
+	if c.report == nil {
+		gatewayRouteSkipsTotal.CounterVec.WithLabelValues(c.src.rtKind, reason).Inc()
+	}
+}
+
+// routeIsGranted returns whether the Route may reference the Gateway. References within
+// the same namespace are always allowed, while cross-namespace references require a
+// ReferenceGrant in the Gateway's namespace.
+func (c *gatewayRouteResolver) routeIsGranted(gw *v1beta1.Gateway, rt gatewayRoute) bool {
+	meta := rt.Metadata()
+	if gw.Namespace == meta.Namespace {
+		return true
+	}
+	return c.referenceIsGranted(
+		c.src.routeGroupKind(rt), meta.Namespace,
+		c.src.parentGroupKind, namespacedName(gw.Namespace, gw.Name),
+	)
+}
+
+// referenceIsGranted returns whether a ReferenceGrant in the namespace of the referenced object
+// allows objects of the given kind in the from namespace to reference it.
+func (c *gatewayRouteResolver) referenceIsGranted(from schema.GroupKind, fromNamespace string, to schema.GroupKind, toName types.NamespacedName) bool {
+	for _, rg := range c.rgs[toName.Namespace] {
+		if !gwGrantAllowsFrom(rg.Spec.From, from.Group, from.Kind, fromNamespace) {
+			continue
+		}
+		for _, t := range rg.Spec.To {
+			if string(t.Group) != to.Group || string(t.Kind) != to.Kind {
+				continue
+			}
+			if t.Name == nil || *t.Name == "" || string(*t.Name) == toName.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func gwGrantAllowsFrom(from []v1beta1.ReferenceGrantFrom, group, kind, namespace string) bool {
+	for _, f := range from {
+		if string(f.Group) == group && string(f.Kind) == kind && string(f.Namespace) == namespace {
 			return true
 		}
 	}
 	return false
 }
 
-func gwRouteHasParentRef(routeParentRefs []v1.ParentReference, ref v1.ParentReference, meta *metav1.ObjectMeta) bool {
+// gwParentRefGroupKind returns the group and kind of the parent reference, which default to
+// those of Gateways.
+func gwParentRefGroupKind(ref v1.ParentReference, gateway schema.GroupKind) schema.GroupKind {
+	return schema.GroupKind{
+		Group: strVal((*string)(ref.Group), gateway.Group),
+		Kind:  strVal((*string)(ref.Kind), gateway.Kind),
+	}
+}
+
+func gwRouteHasParentRef(routeParentRefs []v1.ParentReference, ref v1.ParentReference, meta *metav1.ObjectMeta, gateway schema.GroupKind) bool {
 	// Ensure that the parent reference is in the routeParentRefs list
 	namespace := strVal((*string)(ref.Namespace), meta.Namespace)
-	group := strVal((*string)(ref.Group), gatewayGroup)
-	kind := strVal((*string)(ref.Kind), gatewayKind)
+	gk := gwParentRefGroupKind(ref, gateway)
 	for _, rpr := range routeParentRefs {
-		rprGroup := strVal((*string)(rpr.Group), gatewayGroup)
-		rprKind := strVal((*string)(rpr.Kind), gatewayKind)
-		if rprGroup != group || rprKind != kind {
+		if gwParentRefGroupKind(rpr, gateway) != gk {
 			continue
 		}
 		rprNamespace := strVal((*string)(rpr.Namespace), meta.Namespace)
@@ -498,6 +3376,61 @@ func gwRouteHasParentRef(routeParentRefs []v1.ParentReference, ref v1.ParentRefe
 	return false
 }
 
+// gwUnreportedParents returns a status without conditions for each parentRef of the Route that its
+// status doesn't report yet, e.g. because the Gateway controller hasn't caught up with the Route.
+// Since parentRefs of the same Gateway may attach to different Listeners by section or port, a
+// parentRef is only reported by a status of the same Gateway that selects the same Listeners.
+func gwUnreportedParents(routeParentRefs []v1.ParentReference, parents []v1.RouteParentStatus, meta *metav1.ObjectMeta, gateway schema.GroupKind) []v1.RouteParentStatus {
+	var unreported []v1.RouteParentStatus
+	for _, ref := range routeParentRefs {
+		reported := slices.ContainsFunc(parents, func(rps v1.RouteParentStatus) bool {
+			return gwRouteHasParentRef([]v1.ParentReference{rps.ParentRef}, ref, meta, gateway) && gwSameListenerSelection(rps.ParentRef, ref)
+		})
+		if !reported {
+			unreported = append(unreported, v1.RouteParentStatus{ParentRef: ref})
+		}
+	}
+	return unreported
+}
+
+// gwSameListenerSelection returns whether the parentRefs select the same Listeners of a Gateway,
+// i.e. whether they have the same section name and port.
+func gwSameListenerSelection(a, b v1.ParentReference) bool {
+	if sectionVal(a.SectionName, "") != sectionVal(b.SectionName, "") {
+		return false
+	}
+	return (a.Port == nil) == (b.Port == nil) && (a.Port == nil || *a.Port == *b.Port)
+}
+
+// parentsHaveAnyAddress returns whether any of the Gateways referenced by the Route's parents has
+// any of the addresses in its status.
+func (c *gatewayRouteResolver) parentsHaveAnyAddress(routeParentRefs []v1.ParentReference, meta *metav1.ObjectMeta, addrs []string) bool {
+	for key := range gwParentGateways(routeParentRefs, meta, c.src.parentGroupKind) {
+		gw, ok := c.gws[key]
+		if !ok {
+			continue
+		}
+		if slices.ContainsFunc(gw.gateway.Status.Addresses, func(addr v1.GatewayStatusAddress) bool {
+			return slices.Contains(addrs, gwAddressValue(addr.Value))
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// gwParentGateways returns the distinct Gateways referenced by the parentRefs of a Route.
+func gwParentGateways(routeParentRefs []v1.ParentReference, meta *metav1.ObjectMeta, gateway schema.GroupKind) map[types.NamespacedName]bool {
+	gateways := make(map[types.NamespacedName]bool, len(routeParentRefs))
+	for _, ref := range routeParentRefs {
+		if gwParentRefGroupKind(ref, gateway) != gateway {
+			continue
+		}
+		gateways[namespacedName(strVal((*string)(ref.Namespace), meta.Namespace), string(ref.Name))] = true
+	}
+	return gateways
+}
+
 func gwRouteIsAccepted(conds []metav1.Condition) bool {
 	for _, c := range conds {
 		if v1.RouteConditionType(c.Type) == v1.RouteConditionAccepted {
@@ -507,6 +3440,176 @@ func gwRouteIsAccepted(conds []metav1.Condition) bool {
 	return false
 }
 
+// gwListenerOnDefaultPort returns whether the Listener uses the well-known port of its protocol.
+// Listeners of protocols without a well-known port, such as TCP and UDP, always do.
+func gwListenerOnDefaultPort(lis *v1.Listener) bool {
+	switch lis.Protocol {
+	case v1.HTTPProtocolType:
+		return lis.Port == 80
+	case v1.HTTPSProtocolType, v1.TLSProtocolType:
+		return lis.Port == 443
+	default:
+		return true
+	}
+}
+
+func gwIsProgrammed(conds []metav1.Condition) bool {
+	for _, c := range conds {
+		if v1.GatewayConditionType(c.Type) == v1.GatewayConditionProgrammed {
+			return c.Status == metav1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// gwBackendWeight returns the total weight of the backends and whether they're weighted, i.e. there
+// are several of them and any of them specifies a weight. Backends that don't specify a weight default
+// to a weight of 1. The total is the share of the Route among the Routes of the same hostname.
+func gwBackendWeight(refs []v1.BackendRef) (int64, bool) {
+	if len(refs) < 2 {
+		return 0, false
+	}
+	var total int64
+	explicit := false
+	for _, ref := range refs {
+		if ref.Weight == nil {
+			total++
+			continue
+		}
+		total += int64(*ref.Weight)
+		explicit = true
+	}
+	return total, explicit
+}
+
+// gwGeoContinents lists the continent codes of geolocation records.
+var gwGeoContinents = []string{"AF", "AN", "AS", "EU", "NA", "OC", "SA"}
+
+// gwGeoProperties returns the provider-specific properties of the geo annotations of a Route.
+// Continents must be one of gwGeoContinents, countries an ISO 3166-1 alpha-2 code or "*" for the
+// default location, and subdivisions a code of up to three characters that requires a country.
+// Invalid values are skipped. All of them are skipped if the Route has no set identifier, which
+// geolocation records require, or if its records already have a property of a routing policy, e.g. a
+// weight or the properties of provider-specific annotations, since a record has only one. Since the
+// annotations are evaluated at every sync, skipped annotations are only logged at debug level.
+func gwGeoProperties(annots map[string]string, providerSpecific endpoint.ProviderSpecific, setIdentifier, resource string, policy gatewayRoutingPolicy) endpoint.ProviderSpecific {
+	continent, hasContinent := annots[annotations.GeoContinentKey]
+	country, hasCountry := annots[annotations.GeoCountryKey]
+	subdivision, hasSubdivision := annots[annotations.GeoSubdivisionKey]
+	if !hasContinent && !hasCountry && !hasSubdivision {
+		return nil
+	}
+	if setIdentifier == "" {
+		log.Debugf("Ignoring the geo annotations of %s without a set identifier", resource)
+		return nil
+	}
+	if i := slices.IndexFunc(providerSpecific, func(p endpoint.ProviderSpecificProperty) bool {
+		return slices.Contains(policy.properties(), p.Name)
+	}); i >= 0 {
+		log.Debugf("Ignoring the geo annotations of %s because its records have the provider-specific property %s", resource, providerSpecific[i].Name)
+		return nil
+	}
+	var props endpoint.ProviderSpecific
+	add := func(key, name, value string, valid bool) {
+		if !valid {
+			log.Debugf("Ignoring invalid %s annotation %q of %s", key, value, resource)
+			return
+		}
+		props = append(props, endpoint.ProviderSpecificProperty{Name: name, Value: value})
+	}
+	if hasContinent && hasCountry {
+		log.Debugf("Ignoring %s annotation of %s because a country is given", annotations.GeoContinentKey, resource)
+		hasContinent = false
+	}
+	if hasContinent {
+		add(annotations.GeoContinentKey, policy.geoContinent, continent, slices.Contains(gwGeoContinents, continent))
+	}
+	if hasCountry {
+		add(annotations.GeoCountryKey, policy.geoCountry, country, country == "*" || gwGeoCode(country, 2, 2, false))
+	}
+	if hasSubdivision {
+		add(annotations.GeoSubdivisionKey, policy.geoSubdivision, subdivision, hasCountry && country != "*" && gwGeoCode(subdivision, 1, 3, true))
+	}
+	return props
+}
+
+// gwFailoverRoles maps the values of the failover annotation to those of the failover property.
+var gwFailoverRoles = map[string]string{"primary": "PRIMARY", "secondary": "SECONDARY"}
+
+// gwFailoverProperties returns the provider-specific properties of the failover annotations of a Route,
+// along with its set identifier, which defaults to the failover role so that the primary and secondary
+// records of a hostname are told apart. The failover annotation must be "primary" or "secondary", and
+// is skipped if it's invalid, or if the Route selects another routing policy with its provider-specific
+// or geo annotations. Since the annotations are evaluated at every sync, skipped annotations are only
+// logged at debug level. The health check annotation only applies to failover records.
+func gwFailoverProperties(annots map[string]string, providerSpecific endpoint.ProviderSpecific, setIdentifier, resource string, policy gatewayRoutingPolicy) (endpoint.ProviderSpecific, string) {
+	value, ok := annots[annotations.FailoverKey]
+	healthCheck := strings.TrimSpace(annots[annotations.HealthCheckIDKey])
+	if !ok {
+		if healthCheck != "" {
+			log.Debugf("Ignoring %s annotation of %s without the %s annotation", annotations.HealthCheckIDKey, resource, annotations.FailoverKey)
+		}
+		return nil, setIdentifier
+	}
+	role, valid := gwFailoverRoles[strings.ToLower(strings.TrimSpace(value))]
+	if !valid {
+		log.Debugf("Ignoring invalid %s annotation %q of %s, expected primary or secondary", annotations.FailoverKey, value, resource)
+		return nil, setIdentifier
+	}
+	if i := slices.IndexFunc(providerSpecific, func(p endpoint.ProviderSpecificProperty) bool {
+		return slices.Contains(policy.properties(), p.Name)
+	}); i >= 0 {
+		log.Debugf("Ignoring %s annotation of %s because it sets the provider-specific property %s", annotations.FailoverKey, resource, providerSpecific[i].Name)
+		return nil, setIdentifier
+	}
+	for _, key := range []string{annotations.GeoContinentKey, annotations.GeoCountryKey, annotations.GeoSubdivisionKey} {
+		if _, ok := annots[key]; ok {
+			log.Debugf("Ignoring %s annotation of %s because it also has the %s annotation", annotations.FailoverKey, resource, key)
+			return nil, setIdentifier
+		}
+	}
+	props := endpoint.ProviderSpecific{{Name: policy.failover, Value: role}}
+	if healthCheck != "" {
+		if slices.ContainsFunc(providerSpecific, func(p endpoint.ProviderSpecificProperty) bool { return p.Name == policy.healthCheck }) {
+			log.Debugf("Ignoring %s annotation of %s because it sets the provider-specific property %s", annotations.HealthCheckIDKey, resource, policy.healthCheck)
+		} else {
+			props = append(props, endpoint.ProviderSpecificProperty{Name: policy.healthCheck, Value: healthCheck})
+		}
+	}
+	if setIdentifier == "" {
+		setIdentifier = strings.ToLower(role)
+	}
+	return props, setIdentifier
+}
+
+// gwCommentProperties returns the provider-specific property for the comment annotation of a Route,
+// unless it's empty or a provider-specific annotation already sets the property, which is only logged
+// at debug level since the annotations are evaluated at every sync.
+func gwCommentProperties(annots map[string]string, providerSpecific endpoint.ProviderSpecific, resource string) endpoint.ProviderSpecific {
+	comment := strings.TrimSpace(annots[annotations.CommentKey])
+	if comment == "" {
+		return nil
+	}
+	if slices.ContainsFunc(providerSpecific, func(p endpoint.ProviderSpecificProperty) bool { return p.Name == gatewayCommentProperty }) {
+		log.Debugf("Ignoring %s annotation of %s because it sets the provider-specific property %s", annotations.CommentKey, resource, gatewayCommentProperty)
+		return nil
+	}
+	return endpoint.ProviderSpecific{{Name: gatewayCommentProperty, Value: comment}}
+}
+
+// gwGeoCode returns whether s consists of min to max upper-case letters, or also digits if allowed.
+func gwGeoCode(s string, minLen, maxLen int, digits bool) bool {
+	if len(s) < minLen || len(s) > maxLen {
+		return false
+	}
+	for _, c := range s {
+		if ('A' > c || c > 'Z') && (!digits || '0' > c || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
 func uniqueTargets(targets endpoint.Targets) endpoint.Targets {
 	if len(targets) < 2 {
 		return targets
@@ -525,6 +3628,143 @@ func uniqueTargets(targets endpoint.Targets) endpoint.Targets {
 	return targets[:n]
 }
 
+// gwAllowedTargets drops the IP targets of the Gateway that aren't within any of the given CIDRs,
+// unless there are none. Hostname targets are always kept.
+func gwAllowedTargets(gw *v1beta1.Gateway, targets gatewayTargets, cidrs []netip.Prefix) gatewayTargets {
+	if len(cidrs) == 0 {
+		return targets
+	}
+	allowed := make(gatewayTargets, len(targets))
+	for recordType, tgts := range targets {
+		for _, target := range tgts {
+			ip, err := netip.ParseAddr(target)
+			if err == nil && !slices.ContainsFunc(cidrs, func(cidr netip.Prefix) bool { return cidr.Contains(ip.Unmap()) }) {
+				log.WithFields(log.Fields{
+					"gatewayNamespace": gw.Namespace,
+					"gatewayName":      gw.Name,
+					"target":           target,
+				}).Warnf("Dropping target %s of Gateway %s/%s outside of the target CIDR allowlist", target, gw.Namespace, gw.Name)
+				continue
+			}
+			allowed[recordType] = append(allowed[recordType], target)
+		}
+	}
+	return allowed
+}
+
+// gwAddressRecordType returns the record type used to publish the Gateway address.
+// Hostname addresses are always published as CNAMEs, while the record type of
+// IP and implementation-specific addresses is derived from their value.
+func gwAddressRecordType(addr v1.GatewayStatusAddress) string {
+	if addr.Type != nil && *addr.Type == v1.HostnameAddressType {
+		return endpoint.RecordTypeCNAME
+	}
+	return suitableType(addr.Value)
+}
+
+// gwPinnedAddresses returns the normalized values of the Route's gateway-address annotation, a
+// comma-separated list of Gateway addresses.
+func gwPinnedAddresses(annots map[string]string) []string {
+	return gwAddressValues(annots[annotations.GatewayAddressKey])
+}
+
+// gwListenerAddresses returns the normalized addresses of the Gateway's status that a Listener
+// binds to, given the addresses of the Gateway's listener-addresses annotation for the Listener.
+// If there are none, or none of them are in the Gateway's status yet, it returns nil, so that the
+// Listener falls back to all addresses of the Gateway.
+func gwListenerAddresses(gw *v1beta1.Gateway, lisAddrs []string) []string {
+	values := make([]string, 0, len(lisAddrs))
+	for _, value := range lisAddrs {
+		values = append(values, gwAddressValue(value))
+	}
+	var scoped []string
+	for _, addr := range gw.Status.Addresses {
+		if value := gwAddressValue(addr.Value); slices.Contains(values, value) {
+			scoped = append(scoped, value)
+		}
+	}
+	return scoped
+}
+
+// gwAddressValues returns the normalized values of a comma-separated list of Gateway addresses.
+func gwAddressValues(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, gwAddressValue(value))
+		}
+	}
+	return values
+}
+
+// gwAddressValue returns the normalized value of a Gateway address, so that different notations of
+// the same IP address or hostname compare equal.
+func gwAddressValue(value string) string {
+	if ip, err := netip.ParseAddr(value); err == nil {
+		return ip.Unmap().String()
+	}
+	return gwCanonicalHost(value)
+}
+
+// gwAddressHasAccess returns whether the Gateway address matches the value of a Route's access annotation.
+// A value of "private" selects private, loopback, and link-local IPs, whereas "public" selects all other IPs.
+// Addresses that aren't IPs can't be classified and always match, as do all addresses if the value is neither.
+func gwAddressHasAccess(addr v1.GatewayStatusAddress, access string) bool {
+	if access != "public" && access != "private" {
+		return true
+	}
+	ip, err := netip.ParseAddr(addr.Value)
+	if err != nil {
+		return true
+	}
+	private := ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast()
+	return private == (access == "private")
+}
+
+// routeProtocol returns the protocol of the Route's kind. If protocols are matched strictly,
+// the Route's listener-protocol annotation overrides it, e.g. to only attach an HTTPRoute to
+// HTTPS Listeners.
+func (c *gatewayRouteResolver) routeProtocol(rt gatewayRoute) v1.ProtocolType {
+	if !c.src.strictProtocols {
+		return rt.Protocol()
+	}
+	value, ok := rt.Metadata().Annotations[annotations.ListenerProtocolKey]
+	if !ok {
+		return rt.Protocol()
+	}
+	protocol := v1.ProtocolType(strings.ToUpper(strings.TrimSpace(value)))
+	if !slices.Contains(gwListenerProtocols, protocol) {
+		meta := rt.Metadata()
+		log.Warnf("Ignoring invalid %s annotation %q of %s/%s/%s, expected one of HTTP, HTTPS, TLS, TCP, UDP",
+			annotations.ListenerProtocolKey, value, strings.ToLower(c.src.rtKind), meta.Namespace, meta.Name)
+		return rt.Protocol()
+	}
+	return protocol
+}
+
+// gwListenerProtocols lists the protocols of Listeners that the listener-protocol annotation may select.
+var gwListenerProtocols = []v1.ProtocolType{v1.HTTPProtocolType, v1.HTTPSProtocolType, v1.TLSProtocolType, v1.TCPProtocolType, v1.UDPProtocolType}
+
+// gwListenerProtocolMatches returns whether a Route of the given protocol may attach to the Listener.
+// TLS Listeners in Passthrough mode route by the SNI of TLSRoutes, which TCPRoutes lack, so only
+// TLSRoutes may attach to them. Both may attach to TLS Listeners that terminate TLS.
+// If strict, the protocols must be the same instead.
+func gwListenerProtocolMatches(protocol v1.ProtocolType, lis *v1.Listener, strict bool) bool {
+	if strict {
+		return protocol == lis.Protocol
+	}
+	if protocol == v1.TCPProtocolType && lis.Protocol == v1.TLSProtocolType && gwListenerIsPassthrough(lis) {
+		return false
+	}
+	return gwProtocolMatches(protocol, lis.Protocol)
+}
+
+// gwListenerIsPassthrough returns whether the Listener passes TLS connections through to its
+// Routes instead of terminating them. The mode defaults to Terminate.
+func gwListenerIsPassthrough(lis *v1.Listener) bool {
+	return lis.TLS != nil && lis.TLS.Mode != nil && *lis.TLS.Mode == v1.TLSModePassthrough
+}
+
 // gwProtocolMatches returns whether a and b are the same protocol,
 // where HTTP and HTTPS are considered the same.
 // and TLS and TCP are considered the same.
@@ -543,10 +3783,11 @@ func gwProtocolMatches(a, b v1.ProtocolType) bool {
 }
 
 // gwMatchingHost returns the most-specific overlapping host and a bool indicating if one was found.
-// Hostnames that are prefixed with a wildcard label (`*.`) are interpreted as a suffix match.
-// That means that "*.example.com" would match both "test.example.com" and "foo.test.example.com",
-// but not "example.com". An empty string matches anything.
-func gwMatchingHost(a, b string) (string, bool) {
+// Hostnames that are prefixed with a wildcard label (`*.`) match exactly one label, following RFC 6125.
+// That means that "*.example.com" would match "test.example.com", but neither "foo.test.example.com"
+// nor "example.com". If multiLabel is set, wildcards are instead interpreted as a suffix match that
+// also matches "foo.test.example.com". An empty string matches anything.
+func gwMatchingHost(a, b string, multiLabel bool) (string, bool) {
 	var ok bool
 	if a, ok = gwHost(a); !ok {
 		return "", false
@@ -564,10 +3805,13 @@ func gwMatchingHost(a, b string) (string, bool) {
 	if na, nb := len(a), len(b); nb < na || (na == nb && strings.HasPrefix(b, "*.")) {
 		a, b = b, a
 	}
-	if strings.HasPrefix(a, "*.") && strings.HasSuffix(b, a[1:]) {
-		return b, true
+	if !strings.HasPrefix(a, "*.") || !strings.HasSuffix(b, a[1:]) {
+		return "", false
+	}
+	if !multiLabel && strings.Contains(strings.TrimSuffix(b, a[1:]), ".") {
+		return "", false
 	}
-	return "", false
+	return b, true
 }
 
 // gwHost returns the canonical host and a value indicating if it's valid.
@@ -575,10 +3819,56 @@ func gwHost(host string) (string, bool) {
 	if host == "" {
 		return "", true
 	}
+	host = gwCanonicalHost(host)
 	if isIPAddr(host) || !isDNS1123Domain(strings.TrimPrefix(host, "*.")) {
 		return "", false
 	}
-	return toLowerCaseASCII(host), true
+	return host, true
+}
+
+// gwHostError returns why the host isn't valid according to gwHost, or nil if it is.
+func gwHostError(host string) error {
+	if _, ok := gwHost(host); ok {
+		return nil
+	}
+	host = gwCanonicalHost(host)
+	name := strings.TrimPrefix(host, "*.")
+	switch {
+	case isIPAddr(host):
+		return fmt.Errorf("hostname %q is an IP address", host)
+	case len(name) > 255:
+		return fmt.Errorf("hostname %q is longer than 255 characters", host)
+	}
+	for _, lbl := range strings.Split(name, ".") {
+		if !isDNS1123Label(lbl) {
+			return fmt.Errorf("hostname %q has the invalid label %q", host, lbl)
+		}
+	}
+	return fmt.Errorf("hostname %q is invalid", host)
+}
+
+// gwCanonicalHost returns the canonical form of a hostname, regardless of whether it's taken from
+// the spec of a Route, its annotations, or the FQDN template: lower-case and without a trailing dot.
+func gwCanonicalHost(host string) string {
+	if len(host) > 1 {
+		host = strings.TrimSuffix(host, ".")
+	}
+	return toLowerCaseASCII(host)
+}
+
+// gwCanonicalHosts replaces the hostnames with their canonical forms in place and removes the
+// duplicates this reveals, keeping the first occurrence.
+func gwCanonicalHosts(hostnames []string) []string {
+	seen := make(map[string]bool, len(hostnames))
+	canonical := hostnames[:0]
+	for _, host := range hostnames {
+		host = gwCanonicalHost(host)
+		if !seen[host] {
+			seen[host] = true
+			canonical = append(canonical, host)
+		}
+	}
+	return canonical
 }
 
 // isIPAddr returns whether s in an IP address.
@@ -628,6 +3918,49 @@ func isAlphaNum(b byte) bool {
 	}
 }
 
+// certificateDNSNames returns the DNS SANs of the first PEM encoded certificate.
+func certificateDNSNames(data []byte) ([]string, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return cert.DNSNames, nil
+}
+
+// validateGatewayName returns an error if the Gateway name isn't a valid pattern for gatewayNameMatches.
+func validateGatewayName(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid Gateway name %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// gatewayNameMatches returns whether the name of a Gateway matches the pattern, which may contain
+// the wildcards of path.Match, e.g. "edge-*". Names without wildcards must match exactly, and an
+// empty pattern matches any name.
+func gatewayNameMatches(pattern, name string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, err := path.Match(pattern, name)
+	return ok && err == nil
+}
+
+// gatewayClassFilter splits a comma-separated list of GatewayClass names.
+func gatewayClassFilter(filter string) []string {
+	var classes []string
+	for _, class := range strings.Split(filter, ",") {
+		if class = strings.TrimSpace(class); class != "" {
+			classes = append(classes, class)
+		}
+	}
+	return classes
+}
+
 func strVal(ptr *string, def string) string {
 	if ptr == nil || *ptr == "" {
 		return def
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	gatewayscheme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Reasons of the Events emitted on Routes.
+const (
+	gwEventEndpointsGenerated = "EndpointsGenerated"
+	gwEventEndpointsSkipped   = "EndpointsSkipped"
+	gwEventEndpointsFailed    = "EndpointsFailed"
+)
+
+// gatewayRouteEvents emits Events on Routes about the outcome of generating their endpoints.
+// An Event is only emitted when the outcome for a Route changes, and the spam filter of the
+// recorder further rate-limits the Events of each Route. Its methods accept nil receivers, which
+// are used if Events are disabled, and are safe for concurrent use.
+type gatewayRouteEvents struct {
+	recorder record.EventRecorder
+
+	mu sync.Mutex
+	// outcomes holds the reason and message of the last Event of each Route.
+	outcomes map[types.NamespacedName]string
+}
+
+// newGatewayRouteEvents returns a gatewayRouteEvents that records Events through the Kubernetes
+// API until the context is done.
+func newGatewayRouteEvents(ctx context.Context, kubeClient kubernetes.Interface) *gatewayRouteEvents {
+	broadcaster := record.NewBroadcaster(record.WithContext(ctx))
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	go func() {
+		<-ctx.Done()
+		broadcaster.Shutdown()
+	}()
+	return &gatewayRouteEvents{
+		recorder: broadcaster.NewRecorder(gatewayscheme.Scheme, corev1.EventSource{Component: "external-dns"}),
+		outcomes: make(map[types.NamespacedName]string),
+	}
+}
+
+// observe emits an Event on the Route about its endpoints, the reason why it was skipped, or the
+// error that prevented it from generating endpoints, unless the outcome is unchanged.
+func (e *gatewayRouteEvents) observe(rt gatewayRoute, endpoints []*endpoint.Endpoint, skipReason string, err error) {
+	if e == nil {
+		return
+	}
+	eventType, reason, message := corev1.EventTypeNormal, gwEventEndpointsGenerated, ""
+	switch {
+	case err != nil:
+		eventType, reason, message = corev1.EventTypeWarning, gwEventEndpointsFailed, fmt.Sprintf("Failed to generate endpoints: %v", err)
+	case len(endpoints) == 0:
+		eventType, reason, message = corev1.EventTypeWarning, gwEventEndpointsSkipped, fmt.Sprintf("No endpoints were generated: %s", skipReason)
+	default:
+		var hosts []string
+		for _, ep := range endpoints {
+			hosts = append(hosts, ep.DNSName)
+		}
+		slices.Sort(hosts)
+		message = "Generated endpoints for " + strings.Join(slices.Compact(hosts), ", ")
+	}
+
+	meta := rt.Metadata()
+	key := namespacedName(meta.Namespace, meta.Name)
+	outcome := reason + ": " + message
+	e.mu.Lock()
+	unchanged := e.outcomes[key] == outcome
+	e.outcomes[key] = outcome
+	e.mu.Unlock()
+	if !unchanged {
+		e.recorder.Event(rt.Object(), eventType, reason, message)
+	}
+}
+
+// forget drops the outcomes of Routes that no longer exist.
+func (e *gatewayRouteEvents) forget(routes []gatewayRoute) {
+	if e == nil {
+		return
+	}
+	keys := make(map[types.NamespacedName]bool, len(routes))
+	for _, rt := range routes {
+		meta := rt.Metadata()
+		keys[namespacedName(meta.Namespace, meta.Name)] = true
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for key := range e.outcomes {
+		if !keys[key] {
+			delete(e.outcomes, key)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+)
+
+func TestGatewayHTTPRouteSourceEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gwClient := gatewayfake.NewSimpleClientset()
+	kubeClient := kubefake.NewSimpleClientset()
+	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Namespace")
+	_, err = gwClient.GatewayV1beta1().Gateways("default").Create(ctx, &v1beta1.Gateway{
+		ObjectMeta: objectMeta("default", "test"),
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+		},
+		Status: gatewayStatus("1.2.3.4"),
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Gateway")
+	for _, rt := range []*v1beta1.HTTPRoute{
+		{
+			ObjectMeta: objectMeta("default", "generated"),
+			Spec: v1.HTTPRouteSpec{
+				Hostnames: []v1.Hostname{"b.example.internal", "a.example.internal"},
+				CommonRouteSpec: v1.CommonRouteSpec{
+					ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+				},
+			},
+			Status: httpRouteStatus(gwParentRef("default", "test")),
+		},
+		{
+			ObjectMeta: objectMeta("default", "skipped"),
+			Spec: v1.HTTPRouteSpec{
+				Hostnames: []v1.Hostname{"c.example.internal"},
+				CommonRouteSpec: v1.CommonRouteSpec{
+					ParentRefs: []v1.ParentReference{gwParentRef("default", "missing")},
+				},
+			},
+			Status: httpRouteStatus(gwParentRef("default", "missing")),
+		},
+	} {
+		_, err := gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, rt, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create HTTPRoute")
+	}
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubeClient, nil)
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{GatewayRouteEvents: true})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+
+	// Events are recorded through the Kubernetes API.
+	_, err = src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		events, err := kubeClient.CoreV1().Events("default").List(ctx, metav1.ListOptions{})
+		if !assert.NoError(c, err, "failed to list Events") {
+			return
+		}
+		reasons := make(map[string]string)
+		for _, ev := range events.Items {
+			reasons[ev.InvolvedObject.Name] = ev.Reason
+		}
+		assert.Equal(c, map[string]string{
+			"generated": gwEventEndpointsGenerated,
+			"skipped":   gwEventEndpointsSkipped,
+		}, reasons)
+	}, 10*time.Second, 10*time.Millisecond, "expected Events to be recorded on the HTTPRoutes")
+
+	// Events are only emitted when the outcome of a Route changes.
+	recorder := record.NewFakeRecorder(10)
+	events := src.(*gatewayRouteSource).events
+	events.recorder = recorder
+	clear(events.outcomes)
+	_, err = src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	require.ElementsMatch(t, []string{
+		"Normal EndpointsGenerated Generated endpoints for a.example.internal, b.example.internal",
+		"Warning EndpointsSkipped No endpoints were generated: no-matching-parent",
+	}, []string{<-recorder.Events, <-recorder.Events})
+	_, err = src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	require.Empty(t, recorder.Events, "expected no Events while the outcomes are unchanged")
+
+	// The outcomes of deleted Routes are forgotten.
+	events.forget(nil)
+	require.Empty(t, events.outcomes, "expected the outcomes of deleted Routes to be forgotten")
+}
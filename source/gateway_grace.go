@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// gatewayDeletionGrace remembers the endpoints of Routes, so that they're still published for a
+// grace period after their Route disappears. This keeps the records of Routes that are deleted and
+// recreated shortly after, e.g. during rollouts, from flapping. Its methods accept nil receivers,
+// which are used if the grace period is disabled, and are safe for concurrent use.
+type gatewayDeletionGrace struct {
+	period time.Duration
+
+	mu sync.Mutex
+	// routes holds the last endpoints of each Route and when it was last listed.
+	routes map[types.NamespacedName]gatewayGraceRoute
+}
+
+type gatewayGraceRoute struct {
+	endpoints []*endpoint.Endpoint
+	lastSeen  time.Time
+}
+
+// newGatewayDeletionGrace returns a gatewayDeletionGrace of the period, or nil if it isn't positive.
+func newGatewayDeletionGrace(period time.Duration) *gatewayDeletionGrace {
+	if period <= 0 {
+		return nil
+	}
+	return &gatewayDeletionGrace{period: period, routes: make(map[types.NamespacedName]gatewayGraceRoute)}
+}
+
+// endpoints remembers the endpoints of the listed Routes, stored at the index of each Route, and
+// returns the remembered endpoints of the Routes that are no longer listed, until the grace period
+// since they were last listed has passed. Routes that are listed without endpoints, e.g. because
+// they're filtered or failed, are remembered without any, so that only deletions are smoothed.
+func (g *gatewayDeletionGrace) endpoints(now time.Time, kind string, routes []gatewayRoute, routeEndpoints [][]*endpoint.Endpoint) []*endpoint.Endpoint {
+	if g == nil {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	listed := make(map[types.NamespacedName]bool, len(routes))
+	for i, rt := range routes {
+		meta := rt.Metadata()
+		key := namespacedName(meta.Namespace, meta.Name)
+		listed[key] = true
+		// The endpoints are copied, since merging the endpoints of several Routes modifies them.
+		g.routes[key] = gatewayGraceRoute{endpoints: gwCopyEndpoints(routeEndpoints[i]), lastSeen: now}
+	}
+	var endpoints []*endpoint.Endpoint
+	for key, r := range g.routes {
+		if listed[key] {
+			continue
+		}
+		if now.Sub(r.lastSeen) >= g.period {
+			delete(g.routes, key)
+			continue
+		}
+		if len(r.endpoints) > 0 {
+			log.Debugf("Publishing the endpoints of deleted %s %s until %s", kind, key, r.lastSeen.Add(g.period).Format(time.RFC3339))
+		}
+		endpoints = append(endpoints, gwCopyEndpoints(r.endpoints)...)
+	}
+	return endpoints
+}
+
+func gwCopyEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	copied := make([]*endpoint.Endpoint, len(endpoints))
+	for i, ep := range endpoints {
+		copied[i] = ep.DeepCopy()
+	}
+	return copied
+}
+
+// gatewayLastEndpoints remembers the last endpoints that each Route generated, so that a Route that
+// fails to generate endpoints, e.g. because of an invalid annotation, keeps publishing them instead of
+// having its records deleted by the sync policy. Its methods are safe for concurrent use.
+type gatewayLastEndpoints struct {
+	mu     sync.Mutex
+	routes map[types.NamespacedName][]*endpoint.Endpoint
+}
+
+// join returns the endpoints of the Routes, stored at the index of each Route along with its error.
+// The endpoints of Routes without errors are remembered, and Routes with errors contribute their
+// remembered endpoints instead. The errors of Routes without remembered endpoints are returned.
+func (l *gatewayLastEndpoints) join(routes []gatewayRoute, routeEndpoints [][]*endpoint.Endpoint, routeErrs []error) ([]*endpoint.Endpoint, []error, []error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var endpoints []*endpoint.Endpoint
+	var kept, skipped []error
+	for i, rt := range routes {
+		meta := rt.Metadata()
+		key := namespacedName(meta.Namespace, meta.Name)
+		if routeErrs[i] == nil {
+			if len(routeEndpoints[i]) == 0 {
+				delete(l.routes, key)
+			} else {
+				if l.routes == nil {
+					l.routes = make(map[types.NamespacedName][]*endpoint.Endpoint)
+				}
+				// The endpoints are copied, since merging the endpoints of several Routes modifies them.
+				l.routes[key] = gwCopyEndpoints(routeEndpoints[i])
+			}
+			endpoints = append(endpoints, routeEndpoints[i]...)
+			continue
+		}
+		if last, ok := l.routes[key]; ok {
+			kept = append(kept, routeErrs[i])
+			endpoints = append(endpoints, gwCopyEndpoints(last)...)
+			continue
+		}
+		skipped = append(skipped, routeErrs[i])
+	}
+	return endpoints, kept, skipped
+}
+
+// forget drops the endpoints of Routes that are no longer listed.
+func (l *gatewayLastEndpoints) forget(routes []gatewayRoute) {
+	keys := make(map[types.NamespacedName]bool, len(routes))
+	for _, rt := range routes {
+		meta := rt.Metadata()
+		keys[namespacedName(meta.Namespace, meta.Name)] = true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for key := range l.routes {
+		if !keys[key] {
+			delete(l.routes, key)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestGatewayDeletionGrace(t *testing.T) {
+	t.Parallel()
+
+	route := func(name string) gatewayRoute {
+		return &gatewayHTTPRoute{v1.HTTPRoute{ObjectMeta: objectMeta("default", name)}}
+	}
+	app, canary := route("app"), route("canary")
+	appEndpoints := []*endpoint.Endpoint{newTestEndpoint("app.example.internal", "A", "1.2.3.4")}
+	canaryEndpoints := []*endpoint.Endpoint{newTestEndpoint("canary.example.internal", "A", "1.2.3.4")}
+
+	require.Nil(t, newGatewayDeletionGrace(0), "expected no grace period to be disabled")
+	var disabled *gatewayDeletionGrace
+	require.Empty(t, disabled.endpoints(time.Now(), "HTTPRoute", []gatewayRoute{app}, [][]*endpoint.Endpoint{appEndpoints}))
+
+	grace := newGatewayDeletionGrace(time.Minute)
+	start := time.Now()
+	require.Empty(t, grace.endpoints(start, "HTTPRoute", []gatewayRoute{app, canary}, [][]*endpoint.Endpoint{appEndpoints, canaryEndpoints}),
+		"expected no endpoints of listed Routes")
+
+	// The endpoints of the deleted Route are published during the grace period.
+	remembered := grace.endpoints(start.Add(30*time.Second), "HTTPRoute", []gatewayRoute{app}, [][]*endpoint.Endpoint{appEndpoints})
+	require.Equal(t, canaryEndpoints, remembered)
+	remembered[0].Targets = endpoint.Targets{"5.6.7.8"}
+	require.Equal(t, canaryEndpoints, grace.endpoints(start.Add(59*time.Second), "HTTPRoute", []gatewayRoute{app}, [][]*endpoint.Endpoint{appEndpoints}),
+		"expected the remembered endpoints not to be modified")
+
+	// Once the grace period has passed, they're dropped for good.
+	require.Empty(t, grace.endpoints(start.Add(time.Minute), "HTTPRoute", []gatewayRoute{app}, [][]*endpoint.Endpoint{appEndpoints}))
+	require.Empty(t, grace.endpoints(start.Add(time.Minute), "HTTPRoute", []gatewayRoute{app, canary}, [][]*endpoint.Endpoint{appEndpoints, nil}),
+		"expected a recreated Route to publish its own endpoints")
+
+	// Routes that are listed without endpoints drop them right away, even if they're deleted afterwards.
+	require.Empty(t, grace.endpoints(start.Add(90*time.Second), "HTTPRoute", []gatewayRoute{app}, [][]*endpoint.Endpoint{appEndpoints}))
+}
+
+func TestGatewayLastEndpoints(t *testing.T) {
+	t.Parallel()
+
+	route := func(name string) gatewayRoute {
+		return &gatewayHTTPRoute{v1.HTTPRoute{ObjectMeta: objectMeta("default", name)}}
+	}
+	app, canary := route("app"), route("canary")
+	appEndpoints := []*endpoint.Endpoint{newTestEndpoint("app.example.internal", "A", "1.2.3.4")}
+	errInvalid := errors.New("invalid annotation")
+
+	var last gatewayLastEndpoints
+	endpoints, kept, skipped := last.join([]gatewayRoute{app, canary}, [][]*endpoint.Endpoint{appEndpoints, nil}, []error{nil, errInvalid})
+	require.Equal(t, appEndpoints, endpoints)
+	require.Empty(t, kept)
+	require.Equal(t, []error{errInvalid}, skipped, "expected a Route without previous endpoints to be skipped")
+
+	// A Route that fails afterwards keeps its previous endpoints.
+	endpoints, kept, skipped = last.join([]gatewayRoute{app}, [][]*endpoint.Endpoint{nil}, []error{errInvalid})
+	require.Equal(t, appEndpoints, endpoints)
+	require.Equal(t, []error{errInvalid}, kept)
+	require.Empty(t, skipped)
+
+	// Routes that are no longer listed are forgotten.
+	last.forget([]gatewayRoute{canary})
+	endpoints, kept, skipped = last.join([]gatewayRoute{app}, [][]*endpoint.Endpoint{nil}, []error{errInvalid})
+	require.Empty(t, endpoints)
+	require.Empty(t, kept)
+	require.Equal(t, []error{errInvalid}, skipped)
+}
+
+func TestGatewayDeletionGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gwClient := gatewayfake.NewSimpleClientset()
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubefake.NewSimpleClientset(), nil)
+
+	gw := &v1beta1.Gateway{
+		ObjectMeta: objectMeta("default", "test"),
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+		},
+		Status: gatewayStatus("1.2.3.4"),
+	}
+	_, err := gwClient.GatewayV1beta1().Gateways("default").Create(ctx, gw, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Gateway")
+	rt := &v1beta1.HTTPRoute{
+		ObjectMeta: objectMeta("default", "test"),
+		Spec: v1.HTTPRouteSpec{
+			Hostnames: []v1.Hostname{"grace.example.internal"},
+			CommonRouteSpec: v1.CommonRouteSpec{
+				ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+			},
+		},
+		Status: httpRouteStatus(gwParentRef("default", "test")),
+	}
+	_, err = gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, rt, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create HTTPRoute")
+
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{GatewayDeletionGracePeriod: time.Hour})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+	expected := []*endpoint.Endpoint{newTestEndpoint("grace.example.internal", "A", "1.2.3.4")}
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	validateEndpoints(t, endpoints, expected)
+
+	require.NoError(t, gwClient.GatewayV1beta1().HTTPRoutes("default").Delete(ctx, "test", metav1.DeleteOptions{}), "failed to delete HTTPRoute")
+	require.Eventually(t, func() bool {
+		routes, err := src.(*gatewayRouteSource).rtInformer.List("", labels.Everything())
+		return err == nil && len(routes) == 0
+	}, 10*time.Second, 10*time.Millisecond, "expected the HTTPRoute to be deleted")
+	endpoints, err = src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	validateEndpoints(t, endpoints, expected)
+}
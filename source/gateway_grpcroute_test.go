@@ -87,7 +87,7 @@ func TestGatewayGRPCRouteSourceEndpoints(t *testing.T) {
 	_, err = gwClient.GatewayV1().GRPCRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
 	require.NoError(t, err, "failed to create GRPCRoute")
 
-	src, err := NewGatewayGRPCRouteSource(clients, &Config{
+	src, err := NewGatewayGRPCRouteSource(ctx, clients, &Config{
 		FQDNTemplate:             "{{.Name}}-template.foobar.internal",
 		CombineFQDNAndAnnotation: true,
 	})
@@ -101,3 +101,88 @@ func TestGatewayGRPCRouteSourceEndpoints(t *testing.T) {
 		newTestEndpoint("api-template.foobar.internal", "A", ips...),
 	})
 }
+
+func TestGatewayGRPCRouteSourceWildcardListener(t *testing.T) {
+	t.Parallel()
+
+	gwClient := gatewayfake.NewSimpleClientset()
+	kubeClient := kubefake.NewSimpleClientset()
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubeClient, nil)
+
+	ctx := context.Background()
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+		},
+	}
+	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Namespace")
+
+	gw := &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "internal",
+			Namespace: "default",
+		},
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{{
+				Hostname: hostnamePtr("*.grpc.internal"),
+				Protocol: v1.HTTPSProtocolType,
+			}},
+		},
+		Status: gatewayStatus("10.64.0.1"),
+	}
+	_, err = gwClient.GatewayV1beta1().Gateways(gw.Namespace).Create(ctx, gw, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Gateway")
+
+	routes := []*v1.GRPCRoute{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "api",
+				Namespace: "default",
+			},
+			Spec: v1.GRPCRouteSpec{
+				Hostnames: []v1.Hostname{"api.grpc.internal", "api.other.internal"},
+				CommonRouteSpec: v1.CommonRouteSpec{
+					ParentRefs: []v1.ParentReference{
+						gwParentRef("default", "internal"),
+					},
+				},
+			},
+			Status: v1.GRPCRouteStatus{
+				RouteStatus: gwRouteStatus(gwParentRef("default", "internal")),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "any",
+				Namespace: "default",
+			},
+			Spec: v1.GRPCRouteSpec{
+				CommonRouteSpec: v1.CommonRouteSpec{
+					ParentRefs: []v1.ParentReference{
+						gwParentRef("default", "internal"),
+					},
+				},
+			},
+			Status: v1.GRPCRouteStatus{
+				RouteStatus: gwRouteStatus(gwParentRef("default", "internal")),
+			},
+		},
+	}
+	for _, rt := range routes {
+		_, err = gwClient.GatewayV1().GRPCRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create GRPCRoute")
+	}
+
+	src, err := NewGatewayGRPCRouteSource(ctx, clients, &Config{})
+	require.NoError(t, err, "failed to create Gateway GRPCRoute Source")
+
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("api.grpc.internal", "A", "10.64.0.1"),
+		newTestEndpoint("*.grpc.internal", "A", "10.64.0.1"),
+	})
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package source
 
 import (
+	"context"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -26,8 +28,8 @@ import (
 )
 
 // NewGatewayHTTPRouteSource creates a new Gateway HTTPRoute source with the given config.
-func NewGatewayHTTPRouteSource(clients ClientGenerator, config *Config) (Source, error) {
-	return newGatewayRouteSource(clients, config, "HTTPRoute", func(factory informers.SharedInformerFactory) gatewayRouteInformer {
+func NewGatewayHTTPRouteSource(ctx context.Context, clients ClientGenerator, config *Config) (Source, error) {
+	return newGatewayRouteSource(ctx, clients, config, "HTTPRoute", func(factory informers.SharedInformerFactory) gatewayRouteInformer {
 		return &gatewayHTTPRouteInformer{factory.Gateway().V1beta1().HTTPRoutes()}
 	})
 }
@@ -41,6 +43,37 @@ func (rt *gatewayHTTPRoute) ParentRefs() []v1.ParentReference { return rt.route.
 func (rt *gatewayHTTPRoute) Protocol() v1.ProtocolType        { return v1.HTTPProtocolType }
 func (rt *gatewayHTTPRoute) RouteStatus() v1.RouteStatus      { return rt.route.Status.RouteStatus }
 
+func (rt *gatewayHTTPRoute) BackendRefs() []v1.BackendRef {
+	var refs []v1.BackendRef
+	for _, rule := range rt.route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			refs = append(refs, ref.BackendRef)
+		}
+	}
+	return refs
+}
+
+func (rt *gatewayHTTPRoute) FilterHostnames() []v1.PreciseHostname {
+	var hostnames []v1.PreciseHostname
+	add := func(filters []v1.HTTPRouteFilter) {
+		for _, filter := range filters {
+			switch {
+			case filter.RequestRedirect != nil && filter.RequestRedirect.Hostname != nil:
+				hostnames = append(hostnames, *filter.RequestRedirect.Hostname)
+			case filter.URLRewrite != nil && filter.URLRewrite.Hostname != nil:
+				hostnames = append(hostnames, *filter.URLRewrite.Hostname)
+			}
+		}
+	}
+	for _, rule := range rt.route.Spec.Rules {
+		add(rule.Filters)
+		for _, ref := range rule.BackendRefs {
+			add(ref.Filters)
+		}
+	}
+	return hostnames
+}
+
 type gatewayHTTPRouteInformer struct {
 	informers_v1beta1.HTTPRouteInformer
 }
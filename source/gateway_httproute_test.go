@@ -17,15 +17,33 @@ limitations under the License.
 package source
 
 import (
+	"cmp"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	dto "github.com/prometheus/client_model/go"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 	v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
@@ -52,6 +70,39 @@ func gatewayStatus(ips ...string) v1.GatewayStatus {
 	return v1.GatewayStatus{Addresses: addrs}
 }
 
+func gatewayStatusWithConditions(typ v1.GatewayConditionType, status metav1.ConditionStatus, ips ...string) v1.GatewayStatus {
+	v := gatewayStatus(ips...)
+	v.Conditions = []metav1.Condition{{Type: string(typ), Status: status}}
+	return v
+}
+
+func gwAddress(typ v1.AddressType, value string) v1.GatewayStatusAddress {
+	return v1.GatewayStatusAddress{Type: &typ, Value: value}
+}
+
+func backendRef(name string, weight ...int32) v1.BackendRef {
+	ref := v1.BackendRef{BackendObjectReference: v1.BackendObjectReference{Name: v1.ObjectName(name)}}
+	if len(weight) > 0 {
+		ref.Weight = &weight[0]
+	}
+	return ref
+}
+
+func namespacedBackendRef(namespace, name string) v1.BackendRef {
+	ref := backendRef(name)
+	ref.Namespace = namespacePtr(v1.Namespace(namespace))
+	return ref
+}
+
+// zoneEndpoint returns an EndpointSlice endpoint with the address whose topology hints are for the zone.
+func zoneEndpoint(addr, zone string, ready bool) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses:  []string{addr},
+		Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+		Hints:      &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: zone}}},
+	}
+}
+
 func httpRouteStatus(refs ...v1.ParentReference) v1.HTTPRouteStatus {
 	return v1.HTTPRouteStatus{RouteStatus: gwRouteStatus(refs...)}
 }
@@ -127,6 +178,10 @@ func withPortNumber(port v1.PortNumber) gwParentRefOption {
 	return func(ref *v1.ParentReference) { ref.Port = &port }
 }
 
+func withGroupKind(group v1.Group, kind v1.Kind) gwParentRefOption {
+	return func(ref *v1.ParentReference) { ref.Group, ref.Kind = &group, &kind }
+}
+
 func newTestEndpoint(dnsName, recordType string, targets ...string) *endpoint.Endpoint {
 	return newTestEndpointWithTTL(dnsName, recordType, 0, targets...)
 }
@@ -149,12 +204,6 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 			From: &fromAll,
 		},
 	}
-	objectMeta := func(namespace, name string) metav1.ObjectMeta {
-		return metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		}
-	}
 	namespaces := func(names ...string) []*corev1.Namespace {
 		v := make([]*corev1.Namespace, len(names))
 		for i, name := range names {
@@ -169,6 +218,13 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 		config          Config
 		namespaces      []*corev1.Namespace
 		gateways        []*v1beta1.Gateway
+		referenceGrants []*v1beta1.ReferenceGrant
+		secrets         []*corev1.Secret
+		services        []*corev1.Service
+		endpointSlices  []*discoveryv1.EndpointSlice
+		nodes           []*corev1.Node
+		gatewayClasses  []*v1beta1.GatewayClass
+		configMaps      []*corev1.ConfigMap
 		routes          []*v1beta1.HTTPRoute
 		endpoints       []*endpoint.Endpoint
 		logExpectations []string
@@ -224,6 +280,117 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 				"Gateway gateway-namespace/not-gateway-name does not match gateway-name route-namespace/test",
 			},
 		},
+		{
+			title: "GatewayNamePattern",
+			config: Config{
+				GatewayName: "edge-*",
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "edge-a"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "edge-b"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "core"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("3.4.5.6"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "edge-a"),
+							gwParentRef("default", "edge-b"),
+							gwParentRef("default", "core"),
+						},
+					},
+				},
+				Status: httpRouteStatus(
+					gwParentRef("default", "edge-a"),
+					gwParentRef("default", "edge-b"),
+					gwParentRef("default", "core"),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4", "2.3.4.5"),
+			},
+			logExpectations: []string{
+				"Gateway default/core does not match edge-* default/test",
+			},
+		},
+		{
+			title: "GatewayClassFilter",
+			config: Config{
+				GatewayClassFilter: "internal, public",
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "internal"),
+					Spec: v1.GatewaySpec{
+						GatewayClassName: "internal",
+						Listeners:        []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "public"),
+					Spec: v1.GatewaySpec{
+						GatewayClassName: "public",
+						Listeners:        []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "other"),
+					Spec: v1.GatewaySpec{
+						GatewayClassName: "other",
+						Listeners:        []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("3.4.5.6"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "internal"),
+							gwParentRef("default", "public"),
+							gwParentRef("default", "other"),
+						},
+					},
+				},
+				Status: httpRouteStatus(
+					gwParentRef("default", "internal"),
+					gwParentRef("default", "public"),
+					gwParentRef("default", "other"),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4", "2.3.4.5"),
+			},
+			logExpectations: []string{
+				"Gateway default/other of GatewayClass other does not match HTTPRoute default/test",
+			},
+		},
 		{
 			title: "GatewayNameNoneAccepted",
 			config: Config{
@@ -396,152 +563,97 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 			},
 		},
 		{
-			title: "RouteLabelFilter",
+			title: "GatewayLabelFallback",
 			config: Config{
-				LabelFilter: mustGetLabelSelector("foo=bar"),
+				GatewayLabelFilter:   "foo=bar",
+				GatewayLabelFallback: true,
 			},
-			namespaces: namespaces("default"),
-			gateways: []*v1beta1.Gateway{{
-				ObjectMeta: objectMeta("default", "test"),
-				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
-				},
-				Status: gatewayStatus("1.2.3.4"),
-			}},
-			routes: []*v1beta1.HTTPRoute{
+			namespaces: namespaces("default", "other"),
+			gateways: []*v1beta1.Gateway{
 				{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "labels-match",
 						Namespace: "default",
 						Labels:    map[string]string{"foo": "bar"},
 					},
-					Spec: v1.HTTPRouteSpec{
-						Hostnames: hostnames("labels-match.example.internal"),
-						CommonRouteSpec: v1.CommonRouteSpec{
-							ParentRefs: []v1.ParentReference{
-								gwParentRef("default", "test"),
-							},
-						},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
 					},
-					Status: httpRouteStatus(gwParentRef("default", "test")),
+					Status: gatewayStatus("1.2.3.4"),
 				},
 				{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "labels-dont-match",
-						Namespace: "default",
+						Namespace: "other",
 						Labels:    map[string]string{"foo": "qux"},
 					},
-					Spec: v1.HTTPRouteSpec{
-						Hostnames: hostnames("labels-dont-match.example.internal"),
-						CommonRouteSpec: v1.CommonRouteSpec{
-							ParentRefs: []v1.ParentReference{
-								gwParentRef("default", "test"),
-							},
-						},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{
+							Protocol:      v1.HTTPProtocolType,
+							AllowedRoutes: allowAllNamespaces,
+						}},
 					},
-					Status: httpRouteStatus(gwParentRef("default", "test")),
+					Status: gatewayStatus("2.3.4.5"),
 				},
 			},
-			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("labels-match.example.internal", "A", "1.2.3.4"),
-			},
-		},
-		{
-			title: "RouteAnnotationFilter",
-			config: Config{
-				AnnotationFilter: "foo=bar",
-			},
-			namespaces: namespaces("default"),
-			gateways: []*v1beta1.Gateway{{
-				ObjectMeta: objectMeta("default", "test"),
-				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
-				},
-				Status: gatewayStatus("1.2.3.4"),
-			}},
 			routes: []*v1beta1.HTTPRoute{
 				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:        "annotations-match",
-						Namespace:   "default",
-						Annotations: map[string]string{"foo": "bar"},
-					},
+					ObjectMeta: objectMeta("default", "missing"),
 					Spec: v1.HTTPRouteSpec{
-						Hostnames: hostnames("annotations-match.example.internal"),
+						Hostnames: hostnames("missing.example.internal"),
 						CommonRouteSpec: v1.CommonRouteSpec{
 							ParentRefs: []v1.ParentReference{
-								gwParentRef("default", "test"),
+								gwParentRef("default", "missing"),
 							},
 						},
 					},
-					Status: httpRouteStatus(gwParentRef("default", "test")),
+					Status: httpRouteStatus(gwParentRef("default", "missing")),
 				},
 				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:        "annotations-dont-match",
-						Namespace:   "default",
-						Annotations: map[string]string{"foo": "qux"},
-					},
+					ObjectMeta: objectMeta("default", "other-namespace"),
 					Spec: v1.HTTPRouteSpec{
-						Hostnames: hostnames("annotations-dont-match.example.internal"),
+						Hostnames: hostnames("other-namespace.example.internal"),
 						CommonRouteSpec: v1.CommonRouteSpec{
 							ParentRefs: []v1.ParentReference{
-								gwParentRef("default", "test"),
+								gwParentRef("other", "labels-dont-match"),
 							},
 						},
 					},
-					Status: httpRouteStatus(gwParentRef("default", "test")),
+					Status: httpRouteStatus(gwParentRef("other", "labels-dont-match")),
 				},
 			},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("annotations-match.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("missing.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"Gateway default/missing not found for HTTPRoute default/missing, using Gateway default/labels-match selected by label",
 			},
 		},
 		{
-			title:      "SkipControllerAnnotation",
-			config:     Config{},
-			namespaces: namespaces("default"),
-			gateways: []*v1beta1.Gateway{{
-				ObjectMeta: objectMeta("default", "test"),
-				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
-				},
-				Status: gatewayStatus("1.2.3.4"),
-			}},
-			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "api",
-					Namespace: "default",
-					Annotations: map[string]string{
-						controllerAnnotationKey: "something-else",
-					},
-				},
-				Spec: v1.HTTPRouteSpec{
-					CommonRouteSpec: v1.CommonRouteSpec{
-						ParentRefs: []v1.ParentReference{
-							gwParentRef("default", "test"),
-						},
-					},
-					Hostnames: hostnames("api.example.internal"),
-				},
-				Status: httpRouteStatus(gwParentRef("default", "test")),
-			}},
-			endpoints: nil,
-		},
-		{
-			title:      "MultipleGateways",
-			config:     Config{},
+			title: "GatewayLabelFallbackAmbiguous",
+			config: Config{
+				GatewayLabelFilter:   "foo=bar",
+				GatewayLabelFallback: true,
+			},
 			namespaces: namespaces("default"),
 			gateways: []*v1beta1.Gateway{
 				{
-					ObjectMeta: objectMeta("default", "one"),
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "a",
+						Namespace: "default",
+						Labels:    map[string]string{"foo": "bar"},
+					},
 					Spec: v1.GatewaySpec{
 						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
 					},
 					Status: gatewayStatus("1.2.3.4"),
 				},
 				{
-					ObjectMeta: objectMeta("default", "two"),
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "b",
+						Namespace: "default",
+						Labels:    map[string]string{"foo": "bar"},
+					},
 					Spec: v1.GatewaySpec{
 						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
 					},
@@ -549,402 +661,543 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 				},
 			},
 			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("default", "test"),
+				ObjectMeta: objectMeta("default", "missing"),
 				Spec: v1.HTTPRouteSpec{
-					Hostnames: hostnames("test.example.internal"),
+					Hostnames: hostnames("missing.example.internal"),
 					CommonRouteSpec: v1.CommonRouteSpec{
 						ParentRefs: []v1.ParentReference{
-							gwParentRef("default", "one"),
-							gwParentRef("default", "two"),
+							gwParentRef("default", "missing"),
 						},
 					},
 				},
-				Status: httpRouteStatus(
-					gwParentRef("default", "one"),
-					gwParentRef("default", "two"),
-				),
+				Status: httpRouteStatus(gwParentRef("default", "missing")),
 			}},
-			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("test.example.internal", "A", "1.2.3.4", "2.3.4.5"),
+			endpoints: []*endpoint.Endpoint{},
+			logExpectations: []string{
+				"Gateway default/missing not found for HTTPRoute default/missing, skipping it since 2 Gateways are selected by label: a, b",
 			},
 		},
 		{
-			title:      "MultipleListeners",
-			config:     Config{},
+			title:      "RequireAttachedRoutes",
+			config:     Config{GatewayRequireAttachedRoutes: true},
 			namespaces: namespaces("default"),
 			gateways: []*v1beta1.Gateway{{
-				ObjectMeta: objectMeta("default", "one"),
+				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.GatewaySpec{
 					Listeners: []v1.Listener{
 						{
-							Name:     "foo",
+							Name:     "attached",
+							Hostname: hostnamePtr("attached.example.internal"),
 							Protocol: v1.HTTPProtocolType,
-							Hostname: hostnamePtr("foo.example.internal"),
 						},
 						{
-							Name:     "bar",
+							Name:     "not-attached",
+							Hostname: hostnamePtr("not-attached.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						},
+						{
+							Name:     "other-kind",
+							Hostname: hostnamePtr("other-kind.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						},
+						{
+							Name:     "no-status",
+							Hostname: hostnamePtr("no-status.example.internal"),
 							Protocol: v1.HTTPProtocolType,
-							Hostname: hostnamePtr("bar.example.internal"),
 						},
 					},
 				},
-				Status: gatewayStatus("1.2.3.4"),
+				Status: func() v1.GatewayStatus {
+					status := gatewayStatus("1.2.3.4")
+					status.Listeners = []v1.ListenerStatus{
+						{
+							Name:           "attached",
+							AttachedRoutes: 1,
+							SupportedKinds: []v1.RouteGroupKind{{Kind: "HTTPRoute"}},
+						},
+						{
+							Name:           "not-attached",
+							SupportedKinds: []v1.RouteGroupKind{{Kind: "HTTPRoute"}},
+						},
+						{
+							Name:           "other-kind",
+							AttachedRoutes: 1,
+							SupportedKinds: []v1.RouteGroupKind{{Kind: "GRPCRoute"}},
+						},
+					}
+					return status
+				}(),
 			}},
 			routes: []*v1beta1.HTTPRoute{{
 				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.HTTPRouteSpec{
-					Hostnames: hostnames("*.example.internal"),
+					Hostnames: hostnames(
+						"attached.example.internal",
+						"not-attached.example.internal",
+						"other-kind.example.internal",
+						"no-status.example.internal",
+					),
 					CommonRouteSpec: v1.CommonRouteSpec{
 						ParentRefs: []v1.ParentReference{
-							gwParentRef("default", "one"),
+							gwParentRef("default", "test"),
 						},
 					},
 				},
-				Status: httpRouteStatus(
-					gwParentRef("default", "one"),
-				),
+				Status: httpRouteStatus(gwParentRef("default", "test")),
 			}},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
-				newTestEndpoint("bar.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("attached.example.internal", "A", "1.2.3.4"),
 			},
 		},
 		{
-			title:      "SectionNameMatch",
-			config:     Config{},
-			namespaces: namespaces("default"),
+			title:  "NamespaceDeleting",
+			config: Config{},
+			namespaces: append(namespaces("default"), &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "deleting",
+					DeletionTimestamp: &metav1.Time{Time: time.Now()},
+					Finalizers:        []string{"kubernetes"},
+				},
+				Status: corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating},
+			}),
 			gateways: []*v1beta1.Gateway{{
 				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{
-						{
-							Name:     "foo",
-							Protocol: v1.HTTPProtocolType,
-							Hostname: hostnamePtr("foo.example.internal"),
-						},
-						{
-							Name:     "bar",
-							Protocol: v1.HTTPProtocolType,
-							Hostname: hostnamePtr("bar.example.internal"),
-						},
-					},
+					Listeners: []v1.Listener{{
+						Protocol:      v1.HTTPProtocolType,
+						AllowedRoutes: allowAllNamespaces,
+					}},
 				},
 				Status: gatewayStatus("1.2.3.4"),
 			}},
-			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("default", "test"),
-				Spec: v1.HTTPRouteSpec{
-					Hostnames: hostnames("*.example.internal"),
-					CommonRouteSpec: v1.CommonRouteSpec{
-						ParentRefs: []v1.ParentReference{
-							gwParentRef("default", "test", withSectionName("foo")),
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "active"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("active.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
 						},
 					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
 				},
-				Status: httpRouteStatus(
-					gwParentRef("default", "test", withSectionName("foo")),
-				),
-			}},
+				{
+					ObjectMeta: objectMeta("deleting", "deleting"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("deleting.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("active.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"Skipping HTTPRoute deleting/deleting because its namespace is Terminating",
 			},
 		},
 		{
-			// EXPERIMENTAL: https://gateway-api.sigs.k8s.io/geps/gep-957/
-			title:      "PortNumberMatch",
-			config:     Config{},
+			title:      "GatewayClassParameters",
+			config:     Config{GatewayClassParameters: true},
 			namespaces: namespaces("default"),
-			gateways: []*v1beta1.Gateway{{
-				ObjectMeta: objectMeta("default", "test"),
-				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{
-						{
-							Name:     "foo",
-							Protocol: v1.HTTPProtocolType,
-							Hostname: hostnamePtr("foo.example.internal"),
-							Port:     80,
+			gatewayClasses: func() []*v1beta1.GatewayClass {
+				parameters := func(group, kind, name string) *v1.ParametersReference {
+					namespace := v1.Namespace("platform")
+					return &v1.ParametersReference{
+						Group:     v1.Group(group),
+						Kind:      v1.Kind(kind),
+						Name:      name,
+						Namespace: &namespace,
+					}
+				}
+				return []*v1beta1.GatewayClass{
+					{
+						ObjectMeta: objectMeta("", "configured"),
+						Spec: v1.GatewayClassSpec{
+							ControllerName: "example.internal/controller",
+							ParametersRef:  parameters("", "ConfigMap", "dns-defaults"),
 						},
-						{
-							Name:     "bar",
-							Protocol: v1.HTTPProtocolType,
-							Hostname: hostnamePtr("bar.example.internal"),
-							Port:     80,
-						},
-						{
-							Name:     "qux",
-							Protocol: v1.HTTPProtocolType,
-							Hostname: hostnamePtr("qux.example.internal"),
-							Port:     8080,
+					},
+					{
+						ObjectMeta: objectMeta("", "missing-parameters"),
+						Spec: v1.GatewayClassSpec{
+							ControllerName: "example.internal/controller",
+							ParametersRef:  parameters("", "ConfigMap", "missing"),
 						},
 					},
-				},
-				Status: gatewayStatus("1.2.3.4"),
-			}},
-			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("default", "test"),
-				Spec: v1.HTTPRouteSpec{
-					Hostnames: hostnames("*.example.internal"),
-					CommonRouteSpec: v1.CommonRouteSpec{
-						ParentRefs: []v1.ParentReference{
-							gwParentRef("default", "test", withPortNumber(80)),
+					{
+						ObjectMeta: objectMeta("", "unsupported-parameters"),
+						Spec: v1.GatewayClassSpec{
+							ControllerName: "example.internal/controller",
+							ParametersRef:  parameters("example.internal", "Parameters", "dns-defaults"),
 						},
 					},
+				}
+			}(),
+			configMaps: []*corev1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "dns-defaults",
+					Namespace:   "platform",
+					Annotations: map[string]string{annotations.TargetKey: "lb.example.internal"},
 				},
-				Status: httpRouteStatus(
-					gwParentRef("default", "test", withPortNumber(80)),
-				),
 			}},
+			gateways: func() []*v1beta1.Gateway {
+				gateway := func(name, class string, ips ...string) *v1beta1.Gateway {
+					return &v1beta1.Gateway{
+						ObjectMeta: objectMeta("default", name),
+						Spec: v1.GatewaySpec{
+							GatewayClassName: v1.ObjectName(class),
+							Listeners:        []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+						},
+						Status: gatewayStatus(ips...),
+					}
+				}
+				return []*v1beta1.Gateway{
+					gateway("configured", "configured"),
+					gateway("addresses", "configured", "1.2.3.4"),
+					gateway("missing-parameters", "missing-parameters"),
+					gateway("unsupported-parameters", "unsupported-parameters"),
+					gateway("missing-class", "missing-class"),
+				}
+			}(),
+			routes: func() []*v1beta1.HTTPRoute {
+				var routes []*v1beta1.HTTPRoute
+				for _, name := range []string{"configured", "addresses", "missing-parameters", "unsupported-parameters", "missing-class"} {
+					routes = append(routes, &v1beta1.HTTPRoute{
+						ObjectMeta: objectMeta("default", name),
+						Spec: v1.HTTPRouteSpec{
+							Hostnames: hostnames(v1.Hostname(name + ".example.internal")),
+							CommonRouteSpec: v1.CommonRouteSpec{
+								ParentRefs: []v1.ParentReference{
+									gwParentRef("default", name),
+								},
+							},
+						},
+						Status: httpRouteStatus(gwParentRef("default", name)),
+					})
+				}
+				return routes
+			}(),
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
-				newTestEndpoint("bar.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("configured.example.internal", "CNAME", "lb.example.internal"),
+				newTestEndpoint("addresses.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"Ignoring unsupported parameters example.internal/Parameters of GatewayClass unsupported-parameters",
 			},
 		},
 		{
-			title:      "WildcardInGateway",
-			config:     Config{},
+			title: "RouteLabelFilter",
+			config: Config{
+				LabelFilter: mustGetLabelSelector("foo=bar"),
+			},
 			namespaces: namespaces("default"),
 			gateways: []*v1beta1.Gateway{{
 				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{
-						Protocol: v1.HTTPProtocolType,
-						Hostname: hostnamePtr("*.example.internal"),
-					}},
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
 				},
 				Status: gatewayStatus("1.2.3.4"),
 			}},
-			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("default", "no-hostname"),
-				Spec: v1.HTTPRouteSpec{
-					CommonRouteSpec: v1.CommonRouteSpec{
-						ParentRefs: []v1.ParentReference{
-							gwParentRef("default", "test"),
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels-match",
+						Namespace: "default",
+						Labels:    map[string]string{"foo": "bar"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("labels-match.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
 						},
 					},
-					Hostnames: []v1.Hostname{
-						"foo.example.internal",
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels-dont-match",
+						Namespace: "default",
+						Labels:    map[string]string{"foo": "qux"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("labels-dont-match.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
 					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
 				},
-				Status: httpRouteStatus(gwParentRef("default", "test")),
-			}},
+			},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("labels-match.example.internal", "A", "1.2.3.4"),
 			},
 		},
 		{
-			title:      "WildcardInRoute",
-			config:     Config{},
+			title: "RouteAnnotationFilter",
+			config: Config{
+				AnnotationFilter: "foo=bar",
+			},
 			namespaces: namespaces("default"),
 			gateways: []*v1beta1.Gateway{{
 				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{
-						Protocol: v1.HTTPProtocolType,
-						Hostname: hostnamePtr("foo.example.internal"),
-					}},
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
 				},
 				Status: gatewayStatus("1.2.3.4"),
 			}},
-			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("default", "no-hostname"),
-				Spec: v1.HTTPRouteSpec{
-					CommonRouteSpec: v1.CommonRouteSpec{
-						ParentRefs: []v1.ParentReference{
-							gwParentRef("default", "test"),
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "annotations-match",
+						Namespace:   "default",
+						Annotations: map[string]string{"foo": "bar"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("annotations-match.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
 						},
 					},
-					Hostnames: []v1.Hostname{
-						"*.example.internal",
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "annotations-dont-match",
+						Namespace:   "default",
+						Annotations: map[string]string{"foo": "qux"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("annotations-dont-match.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
 					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
 				},
-				Status: httpRouteStatus(gwParentRef("default", "test")),
-			}},
+			},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("annotations-match.example.internal", "A", "1.2.3.4"),
 			},
 		},
 		{
-			title:      "WildcardInRouteAndGateway",
+			title:      "SkipControllerAnnotation",
 			config:     Config{},
 			namespaces: namespaces("default"),
 			gateways: []*v1beta1.Gateway{{
 				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{
-						Protocol: v1.HTTPProtocolType,
-						Hostname: hostnamePtr("*.example.internal"),
-					}},
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
 				},
 				Status: gatewayStatus("1.2.3.4"),
 			}},
 			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("default", "no-hostname"),
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "api",
+					Namespace: "default",
+					Annotations: map[string]string{
+						controllerAnnotationKey: "something-else",
+					},
+				},
 				Spec: v1.HTTPRouteSpec{
 					CommonRouteSpec: v1.CommonRouteSpec{
 						ParentRefs: []v1.ParentReference{
 							gwParentRef("default", "test"),
 						},
 					},
-					Hostnames: []v1.Hostname{
-						"*.example.internal",
-					},
+					Hostnames: hostnames("api.example.internal"),
 				},
 				Status: httpRouteStatus(gwParentRef("default", "test")),
 			}},
-			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("*.example.internal", "A", "1.2.3.4"),
-			},
+			endpoints: nil,
 		},
 		{
-			title:      "NoRouteHostname",
-			config:     Config{},
+			title:      "ParentlessRoutes",
+			config:     Config{GatewayParentlessRoutes: true},
 			namespaces: namespaces("default"),
-			gateways: []*v1beta1.Gateway{{
-				ObjectMeta: objectMeta("default", "test"),
-				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{
-						Protocol: v1.HTTPProtocolType,
-						Hostname: hostnamePtr("foo.example.internal"),
-					}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "targeted",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.TargetKey:   "203.0.113.1,203.0.113.2",
+							annotations.HostnameKey: "annotated.parentless.internal",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("spec.parentless.internal"),
+					},
 				},
-				Status: gatewayStatus("1.2.3.4"),
-			}},
-			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("default", "no-hostname"),
-				Spec: v1.HTTPRouteSpec{
-					CommonRouteSpec: v1.CommonRouteSpec{
-						ParentRefs: []v1.ParentReference{
-							gwParentRef("default", "test"),
-						},
+				{
+					ObjectMeta: objectMeta("default", "untargeted"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("untargeted.parentless.internal"),
 					},
-					Hostnames: nil,
 				},
-				Status: httpRouteStatus(gwParentRef("default", "test")),
-			}},
+			},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("spec.parentless.internal", "A", "203.0.113.1", "203.0.113.2"),
+				newTestEndpoint("annotated.parentless.internal", "A", "203.0.113.1", "203.0.113.2"),
+			},
+			logExpectations: []string{
+				"No parent references found for HTTPRoute default/targeted, publishing its hosts at the targets of its annotation",
+				"No parent references found for HTTPRoute default/untargeted",
 			},
 		},
 		{
-			title:      "NoGateways",
-			config:     Config{},
-			namespaces: namespaces("default"),
-			gateways:   nil,
-			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("default", "test"),
-				Spec: v1.HTTPRouteSpec{
-					Hostnames: hostnames("example.internal"),
-					CommonRouteSpec: v1.CommonRouteSpec{
-						ParentRefs: []v1.ParentReference{},
+			title: "ParentlessRoutesCommonChecks",
+			config: Config{
+				GatewayParentlessRoutes: true,
+				GatewayWildcardApex:     true,
+				GatewayRequireBackends:  true,
+			},
+			namespaces: append(namespaces("default"), &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "deleting",
+					DeletionTimestamp: &metav1.Time{Time: time.Now()},
+					Finalizers:        []string{"kubernetes"},
+				},
+				Status: corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating},
+			}),
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "wildcard",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.TargetKey: "203.0.113.1,203.0.113.1"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("*.wildcard.parentless.internal"),
+						Rules:     []v1.HTTPRouteRule{{BackendRefs: []v1.HTTPBackendRef{{BackendRef: backendRef("app")}}}},
 					},
 				},
-				Status: httpRouteStatus(),
-			}},
-			endpoints: nil,
-		},
-		{
-			title:      "NoHostnames",
-			config:     Config{},
-			namespaces: namespaces("default"),
-			gateways: []*v1beta1.Gateway{{
-				ObjectMeta: objectMeta("default", "test"),
-				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "backendless",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.TargetKey: "203.0.113.1,203.0.113.1"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("backendless.parentless.internal"),
+					},
 				},
-				Status: gatewayStatus("1.2.3.4"),
-			}},
-			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("default", "no-hostname"),
-				Spec: v1.HTTPRouteSpec{
-					CommonRouteSpec: v1.CommonRouteSpec{
-						ParentRefs: []v1.ParentReference{
-							gwParentRef("default", "test"),
-						},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "deleting",
+						Namespace:   "deleting",
+						Annotations: map[string]string{annotations.TargetKey: "203.0.113.1,203.0.113.1"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("deleting.parentless.internal"),
+						Rules:     []v1.HTTPRouteRule{{BackendRefs: []v1.HTTPBackendRef{{BackendRef: backendRef("app")}}}},
 					},
-					Hostnames: nil,
 				},
-				Status: httpRouteStatus(gwParentRef("default", "test")),
-			}},
-			endpoints: nil,
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("*.wildcard.parentless.internal", "A", "203.0.113.1"),
+				newTestEndpoint("wildcard.parentless.internal", "A", "203.0.113.1"),
+			},
+			logExpectations: []string{
+				"Skipping HTTPRoute default/backendless because it has no backendRefs",
+				"Skipping HTTPRoute deleting/deleting because its namespace is Terminating",
+			},
 		},
 		{
-			title:      "HostnameAnnotation",
+			title:      "GatewayExcludeHostnames",
 			config:     Config{},
 			namespaces: namespaces("default"),
 			gateways: []*v1beta1.Gateway{{
-				ObjectMeta: objectMeta("default", "test"),
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+					Annotations: map[string]string{
+						annotations.ExcludeHostnamesKey: "secret.exclude.internal,*.admin.exclude.internal",
+					},
+				},
 				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					Listeners: []v1.Listener{{
+						Protocol: v1.HTTPProtocolType,
+						Hostname: hostnamePtr("*.exclude.internal"),
+					}},
 				},
 				Status: gatewayStatus("1.2.3.4"),
 			}},
 			routes: []*v1beta1.HTTPRoute{
 				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "without-hostame",
-						Namespace: "default",
-						Annotations: map[string]string{
-							hostnameAnnotationKey: "annotation.without-hostname.internal",
-						},
-					},
+					ObjectMeta: objectMeta("default", "hostnames"),
 					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("app.exclude.internal", "secret.exclude.internal", "*.admin.exclude.internal", "ops.admin.exclude.internal"),
 						CommonRouteSpec: v1.CommonRouteSpec{
 							ParentRefs: []v1.ParentReference{
 								gwParentRef("default", "test"),
 							},
 						},
-						Hostnames: nil,
 					},
 					Status: httpRouteStatus(gwParentRef("default", "test")),
 				},
 				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "with-hostame",
-						Namespace: "default",
-						Annotations: map[string]string{
-							hostnameAnnotationKey: "annotation.with-hostname.internal",
-						},
-					},
+					// The wildcard of the Listener isn't covered by any of the excluded hostnames.
+					ObjectMeta: objectMeta("default", "no-hostnames"),
 					Spec: v1.HTTPRouteSpec{
 						CommonRouteSpec: v1.CommonRouteSpec{
 							ParentRefs: []v1.ParentReference{
 								gwParentRef("default", "test"),
 							},
 						},
-						Hostnames: hostnames("with-hostname.internal"),
 					},
 					Status: httpRouteStatus(gwParentRef("default", "test")),
 				},
 			},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("annotation.without-hostname.internal", "A", "1.2.3.4"),
-				newTestEndpoint("annotation.with-hostname.internal", "A", "1.2.3.4"),
-				newTestEndpoint("with-hostname.internal", "A", "1.2.3.4"),
+				newTestEndpoint("app.exclude.internal", "A", "1.2.3.4"),
+				newTestEndpoint("*.exclude.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"Skipping host secret.exclude.internal of HTTPRoute default/hostnames excluded by Gateway default/test",
 			},
 		},
 		{
-			title: "IgnoreHostnameAnnotation",
-			config: Config{
-				IgnoreHostnameAnnotation: true,
-			},
+			title:      "ListenerAddresses",
+			config:     Config{},
 			namespaces: namespaces("default"),
 			gateways: []*v1beta1.Gateway{{
-				ObjectMeta: objectMeta("default", "test"),
-				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
-				},
-				Status: gatewayStatus("1.2.3.4"),
-			}},
-			routes: []*v1beta1.HTTPRoute{{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "with-hostame",
+					Name:      "test",
 					Namespace: "default",
 					Annotations: map[string]string{
-						hostnameAnnotationKey: "annotation.with-hostname.internal",
+						// None of the addresses of c are in the status, so the Gateway's addresses are used.
+						annotations.ListenerAddressesKey: "a=10.0.0.1, b=10.0.0.2, b = 2001:db8:0::1, c=10.9.9.9",
+					},
+				},
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{Name: "a", Protocol: v1.HTTPProtocolType, Hostname: hostnamePtr("*.a.scoped.internal")},
+						{Name: "b", Protocol: v1.HTTPProtocolType, Hostname: hostnamePtr("*.b.scoped.internal")},
+						{Name: "c", Protocol: v1.HTTPProtocolType, Hostname: hostnamePtr("*.c.scoped.internal")},
 					},
 				},
+				Status: gatewayStatus("10.0.0.1", "10.0.0.2", "2001:db8::1"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.HTTPRouteSpec{
-					Hostnames: hostnames("with-hostname.internal"),
+					Hostnames: hostnames("app.a.scoped.internal", "app.b.scoped.internal", "app.c.scoped.internal"),
 					CommonRouteSpec: v1.CommonRouteSpec{
 						ParentRefs: []v1.ParentReference{
 							gwParentRef("default", "test"),
@@ -954,311 +1207,343 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 				Status: httpRouteStatus(gwParentRef("default", "test")),
 			}},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("with-hostname.internal", "A", "1.2.3.4"),
+				newTestEndpoint("app.a.scoped.internal", "A", "10.0.0.1"),
+				newTestEndpoint("app.b.scoped.internal", "A", "10.0.0.2"),
+				newTestEndpoint("app.b.scoped.internal", "AAAA", "2001:db8::1"),
+				newTestEndpoint("app.c.scoped.internal", "A", "10.0.0.1", "10.0.0.2"),
+				newTestEndpoint("app.c.scoped.internal", "AAAA", "2001:db8::1"),
 			},
 		},
 		{
-			title: "FQDNTemplate",
-			config: Config{
-				FQDNTemplate: "{{.Name}}.zero.internal, {{.Name}}.one.internal. ,  {{.Name}}.two.internal  ",
-			},
+			title:      "FilterHostnames",
+			config:     Config{GatewayFilterHostnames: true},
 			namespaces: namespaces("default"),
 			gateways: []*v1beta1.Gateway{{
 				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					Listeners: []v1.Listener{{
+						Protocol: v1.HTTPProtocolType,
+						Hostname: hostnamePtr("*.filter.internal"),
+					}},
 				},
 				Status: gatewayStatus("1.2.3.4"),
 			}},
-			routes: []*v1beta1.HTTPRoute{
-				{
-					ObjectMeta: objectMeta("default", "fqdn-with-hostnames"),
-					Spec: v1.HTTPRouteSpec{
-						Hostnames: hostnames("fqdn-with-hostnames.internal"),
-						CommonRouteSpec: v1.CommonRouteSpec{
-							ParentRefs: []v1.ParentReference{
-								gwParentRef("default", "test"),
-							},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("app.filter.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
 						},
 					},
-					Status: httpRouteStatus(gwParentRef("default", "test")),
-				},
-				{
-					ObjectMeta: objectMeta("default", "fqdn-without-hostnames"),
-					Spec: v1.HTTPRouteSpec{
-						CommonRouteSpec: v1.CommonRouteSpec{
-							ParentRefs: []v1.ParentReference{
-								gwParentRef("default", "test"),
+					Rules: []v1.HTTPRouteRule{
+						{
+							Filters: []v1.HTTPRouteFilter{
+								{
+									Type:            v1.HTTPRouteFilterRequestRedirect,
+									RequestRedirect: &v1.HTTPRequestRedirectFilter{Hostname: preciseHostnamePtr("www.filter.internal")},
+								},
+								{
+									// Single labels are resolved against the search domains of clients.
+									Type:            v1.HTTPRouteFilterRequestRedirect,
+									RequestRedirect: &v1.HTTPRequestRedirectFilter{Hostname: preciseHostnamePtr("local")},
+								},
+								{
+									Type:            v1.HTTPRouteFilterRequestRedirect,
+									RequestRedirect: &v1.HTTPRequestRedirectFilter{},
+								},
 							},
 						},
-						Hostnames: nil,
+						{
+							BackendRefs: []v1.HTTPBackendRef{{
+								Filters: []v1.HTTPRouteFilter{{
+									Type:       v1.HTTPRouteFilterURLRewrite,
+									URLRewrite: &v1.HTTPURLRewriteFilter{Hostname: preciseHostnamePtr("api.filter.internal")},
+								}},
+							}},
+						},
 					},
-					Status: httpRouteStatus(gwParentRef("default", "test")),
 				},
-			},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("fqdn-without-hostnames.zero.internal", "A", "1.2.3.4"),
-				newTestEndpoint("fqdn-without-hostnames.one.internal", "A", "1.2.3.4"),
-				newTestEndpoint("fqdn-without-hostnames.two.internal", "A", "1.2.3.4"),
-				newTestEndpoint("fqdn-with-hostnames.internal", "A", "1.2.3.4"),
+				newTestEndpoint("app.filter.internal", "A", "1.2.3.4"),
+				newTestEndpoint("www.filter.internal", "A", "1.2.3.4"),
+				newTestEndpoint("api.filter.internal", "A", "1.2.3.4"),
 			},
 		},
 		{
-			title: "CombineFQDN",
-			config: Config{
-				FQDNTemplate:             "combine-{{.Name}}.internal",
-				CombineFQDNAndAnnotation: true,
-			},
+			title:      "FilterHostnamesDisabled",
+			config:     Config{},
 			namespaces: namespaces("default"),
 			gateways: []*v1beta1.Gateway{{
 				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					Listeners: []v1.Listener{{
+						Protocol: v1.HTTPProtocolType,
+						Hostname: hostnamePtr("*.filter.internal"),
+					}},
 				},
 				Status: gatewayStatus("1.2.3.4"),
 			}},
 			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("default", "fqdn-with-hostnames"),
+				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("app.filter.internal"),
 					CommonRouteSpec: v1.CommonRouteSpec{
 						ParentRefs: []v1.ParentReference{
 							gwParentRef("default", "test"),
 						},
 					},
-					Hostnames: hostnames("fqdn-with-hostnames.internal"),
+					Rules: []v1.HTTPRouteRule{
+						{
+							Filters: []v1.HTTPRouteFilter{
+								{
+									Type:            v1.HTTPRouteFilterRequestRedirect,
+									RequestRedirect: &v1.HTTPRequestRedirectFilter{Hostname: preciseHostnamePtr("www.filter.internal")},
+								},
+								{
+									// Single labels are resolved against the search domains of clients.
+									Type:            v1.HTTPRouteFilterRequestRedirect,
+									RequestRedirect: &v1.HTTPRequestRedirectFilter{Hostname: preciseHostnamePtr("local")},
+								},
+								{
+									Type:            v1.HTTPRouteFilterRequestRedirect,
+									RequestRedirect: &v1.HTTPRequestRedirectFilter{},
+								},
+							},
+						},
+						{
+							BackendRefs: []v1.HTTPBackendRef{{
+								Filters: []v1.HTTPRouteFilter{{
+									Type:       v1.HTTPRouteFilterURLRewrite,
+									URLRewrite: &v1.HTTPURLRewriteFilter{Hostname: preciseHostnamePtr("api.filter.internal")},
+								}},
+							}},
+						},
+					},
 				},
 				Status: httpRouteStatus(gwParentRef("default", "test")),
 			}},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("fqdn-with-hostnames.internal", "A", "1.2.3.4"),
-				newTestEndpoint("combine-fqdn-with-hostnames.internal", "A", "1.2.3.4"),
+				newTestEndpoint("app.filter.internal", "A", "1.2.3.4"),
 			},
 		},
 		{
-			title:      "TTL",
+			title:      "MissingSection",
 			config:     Config{},
 			namespaces: namespaces("default"),
 			gateways: []*v1beta1.Gateway{{
 				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					Listeners: []v1.Listener{{
+						Name:     "http",
+						Protocol: v1.HTTPProtocolType,
+						Hostname: hostnamePtr("*.section.internal"),
+					}},
 				},
 				Status: gatewayStatus("1.2.3.4"),
 			}},
 			routes: []*v1beta1.HTTPRoute{
 				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:        "valid-ttl",
-						Namespace:   "default",
-						Annotations: map[string]string{ttlAnnotationKey: "15s"},
-					},
+					ObjectMeta: objectMeta("default", "typo"),
 					Spec: v1.HTTPRouteSpec{
-						Hostnames: hostnames("valid-ttl.internal"),
+						Hostnames: hostnames("typo.section.internal"),
 						CommonRouteSpec: v1.CommonRouteSpec{
 							ParentRefs: []v1.ParentReference{
-								gwParentRef("default", "test"),
+								gwParentRef("default", "test", withSectionName("htpp")),
 							},
 						},
 					},
-					Status: httpRouteStatus(gwParentRef("default", "test")),
+					// The Gateway rejects the Route, since it has no such Listener.
+					Status: v1.HTTPRouteStatus{
+						RouteStatus: v1.RouteStatus{
+							Parents: []v1.RouteParentStatus{{
+								ParentRef: gwParentRef("default", "test", withSectionName("htpp")),
+								Conditions: []metav1.Condition{{
+									Type:   string(v1.RouteConditionAccepted),
+									Status: metav1.ConditionFalse,
+									Reason: string(v1.RouteReasonNoMatchingParent),
+								}},
+							}},
+						},
+					},
 				},
 				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:        "invalid-ttl",
-						Namespace:   "default",
-						Annotations: map[string]string{ttlAnnotationKey: "abc"},
+					// The Listener exists, but doesn't match the hostname.
+					ObjectMeta: objectMeta("default", "no-match"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("app.other.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test", withSectionName("http")),
+							},
+						},
 					},
+					Status: httpRouteStatus(gwParentRef("default", "test", withSectionName("http"))),
+				},
+				{
+					ObjectMeta: objectMeta("default", "match"),
 					Spec: v1.HTTPRouteSpec{
-						Hostnames: hostnames("invalid-ttl.internal"),
+						Hostnames: hostnames("app.section.internal"),
 						CommonRouteSpec: v1.CommonRouteSpec{
 							ParentRefs: []v1.ParentReference{
-								gwParentRef("default", "test"),
+								gwParentRef("default", "test", withSectionName("http")),
 							},
 						},
 					},
-					Status: httpRouteStatus(gwParentRef("default", "test")),
+					Status: httpRouteStatus(gwParentRef("default", "test", withSectionName("http"))),
 				},
 			},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("invalid-ttl.internal", "A", "1.2.3.4"),
-				newTestEndpointWithTTL("valid-ttl.internal", "A", 15, "1.2.3.4"),
+				newTestEndpoint("app.section.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				`Gateway default/test has no listener named "htpp" referenced by HTTPRoute default/typo`,
+				`Gateway default/test section "http" does not match HTTPRoute default/no-match hostnames ["app.other.internal"]`,
 			},
 		},
 		{
-			title:      "ProviderAnnotations",
+			title:      "IPFamilies",
 			config:     Config{},
 			namespaces: namespaces("default"),
-			gateways: []*v1beta1.Gateway{{
-				ObjectMeta: objectMeta("default", "test"),
-				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "test"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4", "2001:db8::1"),
 				},
-				Status: gatewayStatus("1.2.3.4"),
-			}},
-			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "provider-annotations",
-					Namespace: "default",
-					Annotations: map[string]string{
-						annotations.SetIdentifierKey: "test-set-identifier",
-						aliasAnnotationKey:           "true",
+				{
+					ObjectMeta: objectMeta("default", "ipv6-only"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
 					},
+					Status: gatewayStatus("2001:db8::2"),
 				},
-				Spec: v1.HTTPRouteSpec{
-					CommonRouteSpec: v1.CommonRouteSpec{
-						ParentRefs: []v1.ParentReference{
-							gwParentRef("default", "test"),
-						},
-					},
-					Hostnames: hostnames("provider-annotations.com"),
-				},
-				Status: httpRouteStatus(gwParentRef("default", "test")),
-			}},
-			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("provider-annotations.com", "A", "1.2.3.4").
-					WithProviderSpecific("alias", "true").
-					WithSetIdentifier("test-set-identifier"),
 			},
-		},
-		{
-			title:      "DifferentHostnameDifferentGateway",
-			config:     Config{},
-			namespaces: namespaces("default"),
-			gateways: []*v1beta1.Gateway{
+			routes: []*v1beta1.HTTPRoute{
 				{
-					ObjectMeta: objectMeta("default", "one"),
-					Spec: v1.GatewaySpec{
-						Listeners: []v1.Listener{{
-							Hostname: hostnamePtr("*.one.internal"),
-							Protocol: v1.HTTPProtocolType,
-						}},
+					ObjectMeta: objectMeta("default", "default-families"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("default.families.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
 					},
-					Status: gatewayStatus("1.2.3.4"),
+					Status: httpRouteStatus(gwParentRef("default", "test")),
 				},
 				{
-					ObjectMeta: objectMeta("default", "two"),
-					Spec: v1.GatewaySpec{
-						Listeners: []v1.Listener{{
-							Hostname: hostnamePtr("*.two.internal"),
-							Protocol: v1.HTTPProtocolType,
-						}},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "ipv4",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.IPFamiliesKey: "ipv4"},
 					},
-					Status: gatewayStatus("2.3.4.5"),
-				},
-			},
-			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("default", "test"),
-				Spec: v1.HTTPRouteSpec{
-					CommonRouteSpec: v1.CommonRouteSpec{
-						ParentRefs: []v1.ParentReference{
-							gwParentRef("default", "one"),
-							gwParentRef("default", "two"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("ipv4.families.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
 						},
 					},
-					Hostnames: hostnames("test.one.internal", "test.two.internal"),
+					Status: httpRouteStatus(gwParentRef("default", "test")),
 				},
-				Status: httpRouteStatus(
-					gwParentRef("default", "one"),
-					gwParentRef("default", "two"),
-				),
-			}},
-			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("test.one.internal", "A", "1.2.3.4"),
-				newTestEndpoint("test.two.internal", "A", "2.3.4.5"),
-			},
-		},
-		{
-			title:      "AllowedRoutesSameNamespace",
-			config:     Config{},
-			namespaces: namespaces("same-namespace", "other-namespace"),
-			gateways: []*v1beta1.Gateway{{
-				ObjectMeta: objectMeta("same-namespace", "test"),
-				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{
-						Protocol: v1.HTTPProtocolType,
-						AllowedRoutes: &v1.AllowedRoutes{
-							Namespaces: &v1.RouteNamespaces{
-								From: &fromSame,
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "ipv6",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.IPFamiliesKey: "ipv6"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("ipv6.families.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
 							},
 						},
-					}},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
 				},
-				Status: gatewayStatus("1.2.3.4"),
-			}},
-			routes: []*v1beta1.HTTPRoute{
 				{
-					ObjectMeta: objectMeta("same-namespace", "test"),
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "dualstack",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.IPFamiliesKey: "ipv4,ipv6"},
+					},
 					Spec: v1.HTTPRouteSpec{
-						Hostnames: hostnames("same-namespace.example.internal"),
+						Hostnames: hostnames("dualstack.families.internal"),
 						CommonRouteSpec: v1.CommonRouteSpec{
 							ParentRefs: []v1.ParentReference{
-								gwParentRef("same-namespace", "test"),
+								gwParentRef("default", "test"),
 							},
 						},
 					},
-					Status: httpRouteStatus(gwParentRef("same-namespace", "test")),
+					Status: httpRouteStatus(gwParentRef("default", "test")),
 				},
 				{
-					ObjectMeta: objectMeta("other-namespace", "test"),
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "no-targets",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.IPFamiliesKey: "ipv4"},
+					},
 					Spec: v1.HTTPRouteSpec{
-						Hostnames: hostnames("other-namespace.example.internal"),
+						Hostnames: hostnames("none.families.internal"),
 						CommonRouteSpec: v1.CommonRouteSpec{
 							ParentRefs: []v1.ParentReference{
-								gwParentRef("same-namespace", "test"),
+								gwParentRef("default", "ipv6-only"),
 							},
 						},
 					},
-					Status: httpRouteStatus(gwParentRef("same-namespace", "test")),
+					Status: httpRouteStatus(gwParentRef("default", "ipv6-only")),
 				},
 			},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("same-namespace.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("default.families.internal", "A", "1.2.3.4"),
+				newTestEndpoint("default.families.internal", "AAAA", "2001:db8::1"),
+				newTestEndpoint("ipv4.families.internal", "A", "1.2.3.4"),
+				newTestEndpoint("ipv6.families.internal", "AAAA", "2001:db8::1"),
+				newTestEndpoint("dualstack.families.internal", "A", "1.2.3.4"),
+				newTestEndpoint("dualstack.families.internal", "AAAA", "2001:db8::1"),
+			},
+			logExpectations: []string{
+				`Skipping host none.families.internal of HTTPRoute default/no-targets without targets of IP families ["ipv4"]`,
 			},
 		},
 		{
-			title:  "AllowedRoutesNamespaceSelector",
-			config: Config{},
-			namespaces: []*corev1.Namespace{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "default",
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:   "foo",
-						Labels: map[string]string{"team": "foo"},
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:   "bar",
-						Labels: map[string]string{"team": "bar"},
-					},
-				},
-			},
+			title:      "HostnameOverrides",
+			config:     Config{},
+			namespaces: namespaces("default"),
 			gateways: []*v1beta1.Gateway{{
 				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.GatewaySpec{
 					Listeners: []v1.Listener{{
 						Protocol: v1.HTTPProtocolType,
-						AllowedRoutes: &v1.AllowedRoutes{
-							Namespaces: &v1.RouteNamespaces{
-								From: &fromSelector,
-								Selector: &metav1.LabelSelector{
-									MatchLabels: map[string]string{"team": "foo"},
-								},
-							},
-						},
+						Hostname: hostnamePtr("*.override.internal"),
 					}},
 				},
 				Status: gatewayStatus("1.2.3.4"),
 			}},
 			routes: []*v1beta1.HTTPRoute{
 				{
-					ObjectMeta: objectMeta("foo", "test"),
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "overrides",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.TtlKey: "300",
+							annotations.HostnameOverridesKey: `{
+								"canary.override.internal": {"targets": ["canary-lb.override.internal"], "ttl": "30s"},
+								"api.override.internal": {"ttl": "60"},
+								"other.internal": {"targets": ["5.6.7.8"]}
+							}`,
+						},
+					},
 					Spec: v1.HTTPRouteSpec{
-						Hostnames: hostnames("foo.example.internal"),
+						Hostnames: hostnames("app.override.internal", "api.override.internal", "canary.override.internal"),
 						CommonRouteSpec: v1.CommonRouteSpec{
 							ParentRefs: []v1.ParentReference{
 								gwParentRef("default", "test"),
@@ -1268,9 +1553,16 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 					Status: httpRouteStatus(gwParentRef("default", "test")),
 				},
 				{
-					ObjectMeta: objectMeta("bar", "test"),
+					// Malformed overrides skip the Route.
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "malformed",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.HostnameOverridesKey: "malformed.override.internal=5.6.7.8",
+						},
+					},
 					Spec: v1.HTTPRouteSpec{
-						Hostnames: hostnames("bar.example.internal"),
+						Hostnames: hostnames("malformed.override.internal"),
 						CommonRouteSpec: v1.CommonRouteSpec{
 							ParentRefs: []v1.ParentReference{
 								gwParentRef("default", "test"),
@@ -1281,266 +1573,4948 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 				},
 			},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+				newTestEndpointWithTTL("app.override.internal", "A", 300, "1.2.3.4"),
+				newTestEndpointWithTTL("api.override.internal", "A", 60, "1.2.3.4"),
+				newTestEndpointWithTTL("canary.override.internal", "CNAME", 30, "canary-lb.override.internal"),
+			},
+			logExpectations: []string{
+				"Ignoring override of host other.internal of HTTPRoute default/overrides, which it doesn't publish",
+				"Skipped 1 HTTPRoutes that failed to generate endpoints: invalid external-dns.alpha.kubernetes.io/hostname-overrides annotation",
 			},
 		},
 		{
-			title:      "MissingNamespace",
+			title:      "IgnoreAnnotation",
 			config:     Config{},
-			namespaces: nil,
+			namespaces: namespaces("default"),
 			gateways: []*v1beta1.Gateway{{
 				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.GatewaySpec{
-					Listeners: []v1.Listener{{
-						Protocol: v1.HTTPProtocolType,
-						AllowedRoutes: &v1.AllowedRoutes{
-							Namespaces: &v1.RouteNamespaces{
-								// Namespace selector triggers namespace lookup.
-								From: &fromSelector,
-								Selector: &metav1.LabelSelector{
-									MatchLabels: map[string]string{"foo": "bar"},
-								},
-							},
-						},
-					}},
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
 				},
 				Status: gatewayStatus("1.2.3.4"),
 			}},
-			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("default", "test"),
-				Spec: v1.HTTPRouteSpec{
-					CommonRouteSpec: v1.CommonRouteSpec{
-						ParentRefs: []v1.ParentReference{
-							gwParentRef("default", "test"),
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "paused",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.IgnoreKey:   "true",
+							annotations.HostnameKey: "paused.example.internal",
 						},
 					},
-					Hostnames: hostnames("example.internal"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: hostnames("api.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
 				},
-				Status: httpRouteStatus(gwParentRef("default", "test")),
-			}},
-			endpoints: nil,
-		},
-		{
-			title: "AnnotationOverride",
-			config: Config{
-				GatewayNamespace: "gateway-namespace",
-			},
-			namespaces: namespaces("gateway-namespace", "route-namespace"),
-			gateways: []*v1beta1.Gateway{
 				{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:      "overriden-gateway",
-						Namespace: "gateway-namespace",
+						Name:      "resumed",
+						Namespace: "default",
 						Annotations: map[string]string{
-							targetAnnotationKey: "4.3.2.1",
+							annotations.IgnoreKey: "false",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
 						},
+						Hostnames: hostnames("web.example.internal"),
 					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("web.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"Skipping HTTPRoute default/paused because it is ignored",
+			},
+		},
+		{
+			title:      "MultipleGateways",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "one"),
 					Spec: v1.GatewaySpec{
-						Listeners: []v1.Listener{{
-							Protocol:      v1.HTTPProtocolType,
-							AllowedRoutes: allowAllNamespaces,
-						}},
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
 					},
 					Status: gatewayStatus("1.2.3.4"),
 				},
+				{
+					ObjectMeta: objectMeta("default", "two"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
 			},
 			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("route-namespace", "test"),
+				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.HTTPRouteSpec{
 					Hostnames: hostnames("test.example.internal"),
 					CommonRouteSpec: v1.CommonRouteSpec{
 						ParentRefs: []v1.ParentReference{
-							gwParentRef("gateway-namespace", "overriden-gateway"),
+							gwParentRef("default", "one"),
+							gwParentRef("default", "two"),
 						},
 					},
 				},
-				Status: httpRouteStatus( // The route is attached to both gateways.
-					gwParentRef("gateway-namespace", "overriden-gateway"),
-				),
-			}},
+				Status: httpRouteStatus(
+					gwParentRef("default", "one"),
+					gwParentRef("default", "two"),
+				),
+			}},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("test.example.internal", "A", "4.3.2.1"),
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4", "2.3.4.5"),
 			},
 		},
 		{
-			title: "MutlipleGatewaysOneAnnotationOverride",
-			config: Config{
-				GatewayNamespace: "gateway-namespace",
-			},
-			namespaces: namespaces("gateway-namespace", "route-namespace"),
-			gateways: []*v1beta1.Gateway{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "overriden-gateway",
-						Namespace: "gateway-namespace",
-						Annotations: map[string]string{
-							targetAnnotationKey: "4.3.2.1",
+			title:      "MultipleListeners",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "one"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{
+							Name:     "foo",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("foo.example.internal"),
+						},
+						{
+							Name:     "bar",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("bar.example.internal"),
 						},
 					},
-					Spec: v1.GatewaySpec{
-						Listeners: []v1.Listener{{
-							Protocol:      v1.HTTPProtocolType,
-							AllowedRoutes: allowAllNamespaces,
-						}},
-					},
-					Status: gatewayStatus("1.2.3.4"),
-				},
-				{
-					ObjectMeta: objectMeta("gateway-namespace", "test"),
-					Spec: v1.GatewaySpec{
-						Listeners: []v1.Listener{{
-							Protocol:      v1.HTTPProtocolType,
-							AllowedRoutes: allowAllNamespaces,
-						}},
-					},
-					Status: gatewayStatus("2.3.4.5"),
 				},
-			},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
 			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("route-namespace", "test"),
+				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.HTTPRouteSpec{
-					Hostnames: hostnames("test.example.internal"),
+					Hostnames: hostnames("*.example.internal"),
 					CommonRouteSpec: v1.CommonRouteSpec{
 						ParentRefs: []v1.ParentReference{
-							gwParentRef("gateway-namespace", "overriden-gateway"),
-							gwParentRef("gateway-namespace", "test"),
+							gwParentRef("default", "one"),
 						},
 					},
 				},
 				Status: httpRouteStatus(
-					gwParentRef("gateway-namespace", "overriden-gateway"),
-					gwParentRef("gateway-namespace", "test"),
+					gwParentRef("default", "one"),
 				),
 			}},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("test.example.internal", "A", "4.3.2.1", "2.3.4.5"),
+				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("bar.example.internal", "A", "1.2.3.4"),
 			},
 		},
 		{
-			title:      "MultipleGatewaysMultipleRoutes",
+			title:      "SectionNameMatch",
 			config:     Config{},
 			namespaces: namespaces("default"),
-			gateways: []*v1beta1.Gateway{
-				{
-					ObjectMeta: objectMeta("default", "one"),
-					Spec: v1.GatewaySpec{
-						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
-					},
-					Status: gatewayStatus("1.2.3.4"),
-				},
-				{
-					ObjectMeta: objectMeta("default", "two"),
-					Spec: v1.GatewaySpec{
-						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
-					},
-					Status: gatewayStatus("2.3.4.5"),
-				},
-			},
-			routes: []*v1beta1.HTTPRoute{
-				{
-					ObjectMeta: objectMeta("default", "one"),
-					Spec: v1.HTTPRouteSpec{
-						Hostnames: hostnames("test.one.internal"),
-						CommonRouteSpec: v1.CommonRouteSpec{
-							ParentRefs: []v1.ParentReference{
-								gwParentRef("default", "one"),
-							},
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{
+							Name:     "foo",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("foo.example.internal"),
+						},
+						{
+							Name:     "bar",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("bar.example.internal"),
 						},
 					},
-					Status: httpRouteStatus(
-						gwParentRef("default", "one"),
-					),
 				},
-				{
-					ObjectMeta: objectMeta("default", "two"),
-					Spec: v1.HTTPRouteSpec{
-						Hostnames: hostnames("test.two.internal"),
-						CommonRouteSpec: v1.CommonRouteSpec{
-							ParentRefs: []v1.ParentReference{
-								gwParentRef("default", "two"),
-							},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("*.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test", withSectionName("foo")),
 						},
 					},
-					Status: httpRouteStatus(
-						gwParentRef("default", "two"),
-					),
 				},
-			},
+				Status: httpRouteStatus(
+					gwParentRef("default", "test", withSectionName("foo")),
+				),
+			}},
 			endpoints: []*endpoint.Endpoint{
-				newTestEndpoint("test.one.internal", "A", "1.2.3.4"),
-				newTestEndpoint("test.two.internal", "A", "2.3.4.5"),
-			},
-			logExpectations: []string{
-				"Endpoints generated from HTTPRoute default/one: [test.one.internal 0 IN A  1.2.3.4 []]",
-				"Endpoints generated from HTTPRoute default/two: [test.two.internal 0 IN A  2.3.4.5 []]",
+				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
 			},
 		},
 		{
-			title: "NoParentRefs",
-			config: Config{
-				GatewayNamespace: "gateway-namespace",
-			},
-			namespaces: namespaces("gateway-namespace", "route-namespace"),
-			gateways: []*v1beta1.Gateway{
-				{
-					ObjectMeta: objectMeta("gateway-namespace", "test"),
-					Spec: v1.GatewaySpec{
-						Listeners: []v1.Listener{{
-							Protocol:      v1.HTTPProtocolType,
-							AllowedRoutes: allowAllNamespaces,
-						}},
+			// EXPERIMENTAL: https://gateway-api.sigs.k8s.io/geps/gep-957/
+			title:      "PortNumberMatch",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{
+							Name:     "foo",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("foo.example.internal"),
+							Port:     80,
+						},
+						{
+							Name:     "bar",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("bar.example.internal"),
+							Port:     80,
+						},
+						{
+							Name:     "qux",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("qux.example.internal"),
+							Port:     8080,
+						},
 					},
-					Status: gatewayStatus("1.2.3.4"),
 				},
-			},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
 			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("route-namespace", "test"),
+				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.HTTPRouteSpec{
-					Hostnames: hostnames("test.example.internal"),
+					Hostnames: hostnames("*.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test", withPortNumber(80)),
+						},
+					},
 				},
-				Status: httpRouteStatus(gwParentRef("gateway-namespace", "test")),
+				Status: httpRouteStatus(
+					gwParentRef("default", "test", withPortNumber(80)),
+				),
 			}},
-			endpoints: []*endpoint.Endpoint{},
-			logExpectations: []string{
-				"No parent references found for HTTPRoute route-namespace/test",
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("bar.example.internal", "A", "1.2.3.4"),
 			},
 		},
 		{
-			title: "ParentRefsMismatch",
+			// EXPERIMENTAL: https://gateway-api.sigs.k8s.io/geps/gep-957/
+			title: "PortOnlyAttachment",
 			config: Config{
-				GatewayNamespace: "gateway-namespace",
+				GatewayStrictListenerPorts: true,
 			},
-			namespaces: namespaces("gateway-namespace", "route-namespace"),
-			gateways: []*v1beta1.Gateway{
-				{
-					ObjectMeta: objectMeta("gateway-namespace", "test"),
-					Spec: v1.GatewaySpec{
-						Listeners: []v1.Listener{{
-							Protocol:      v1.HTTPProtocolType,
-							AllowedRoutes: allowAllNamespaces,
-						}},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{
+							Name:     "foo",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("foo.port.internal"),
+							Port:     8080,
+						},
+						{
+							Name:     "bar",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("bar.port.internal"),
+							Port:     8080,
+						},
+						{
+							Name:     "qux",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("qux.port.internal"),
+							Port:     80,
+						},
+						{
+							Name:     "tls",
+							Protocol: v1.TLSProtocolType,
+							Hostname: hostnamePtr("tls.port.internal"),
+							Port:     8080,
+						},
 					},
-					Status: gatewayStatus("1.2.3.4"),
 				},
-			},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
 			routes: []*v1beta1.HTTPRoute{{
-				ObjectMeta: objectMeta("route-namespace", "test"),
+				// Without hostnames of its own, the Route adopts those of the Listeners on the port,
+				// even though they aren't on the default port of their protocol.
+				ObjectMeta: objectMeta("default", "test"),
 				Spec: v1.HTTPRouteSpec{
-					Hostnames: hostnames("test.example.internal"),
 					CommonRouteSpec: v1.CommonRouteSpec{
 						ParentRefs: []v1.ParentReference{
-							gwParentRef("gateway-namespace", "default-gateway"),
+							gwParentRef("default", "test", withPortNumber(8080)),
 						},
 					},
 				},
-				Status: httpRouteStatus(gwParentRef("gateway-namespace", "other-gateway")),
+				Status: httpRouteStatus(
+					gwParentRef("default", "test", withPortNumber(8080)),
+				),
 			}},
-			endpoints: []*endpoint.Endpoint{},
-			logExpectations: []string{
-				"Parent reference gateway-namespace/other-gateway not found in routeParentRefs for HTTPRoute route-namespace/test",
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("foo.port.internal", "A", "1.2.3.4"),
+				newTestEndpoint("bar.port.internal", "A", "1.2.3.4"),
 			},
 		},
-	}
+		{
+			// EXPERIMENTAL: https://gateway-api.sigs.k8s.io/geps/gep-957/
+			title:      "PortOnlyAttachmentUnreported",
+			config:     Config{GatewayIncludeUnaccepted: true},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{
+							Name:     "foo",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("foo.unreported.internal"),
+							Port:     80,
+						},
+						{
+							Name:     "bar",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("bar.unreported.internal"),
+							Port:     8080,
+						},
+					},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				// The status only reports the first of the Route's parentRefs of the same Gateway.
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test", withPortNumber(80)),
+							gwParentRef("default", "test", withPortNumber(8080)),
+						},
+					},
+				},
+				Status: httpRouteStatus(
+					gwParentRef("default", "test", withPortNumber(80)),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("foo.unreported.internal", "A", "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "httproute/default/test"),
+				newTestEndpoint("bar.unreported.internal", "A", "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "httproute/default/test").
+					WithLabel(endpoint.ProvisionalLabelKey, "true"),
+			},
+		},
+		{
+			title: "StrictListenerPorts",
+			config: Config{
+				GatewayStrictListenerPorts: true,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{
+							Name:     "http",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("http.example.internal"),
+							Port:     80,
+						},
+						{
+							Name:     "https",
+							Protocol: v1.HTTPSProtocolType,
+							Hostname: hostnamePtr("https.example.internal"),
+							Port:     443,
+						},
+						{
+							Name:     "alt",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("alt.example.internal"),
+							Port:     8080,
+						},
+					},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "default-ports"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("*.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "section-name"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("*.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test", withSectionName("alt")),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test", withSectionName("alt"))),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("http.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("https.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("alt.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:      "WildcardInGateway",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Protocol: v1.HTTPProtocolType,
+						Hostname: hostnamePtr("*.example.internal"),
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "no-hostname"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: []v1.Hostname{
+						"foo.example.internal",
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:      "WildcardInRoute",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Protocol: v1.HTTPProtocolType,
+						Hostname: hostnamePtr("foo.example.internal"),
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "no-hostname"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: []v1.Hostname{
+						"*.example.internal",
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:      "WildcardInRouteAndGateway",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Protocol: v1.HTTPProtocolType,
+						Hostname: hostnamePtr("*.example.internal"),
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "no-hostname"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: []v1.Hostname{
+						"*.example.internal",
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("*.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:      "WildcardHostnamesAnnotation",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{
+							Name:     "wildcard",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("*.example.internal"),
+						},
+						{
+							Name:     "exact",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("exact.example.org"),
+						},
+					},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+					Annotations: map[string]string{
+						annotations.WildcardHostnamesKey: "foo.example.internal,bar.example.internal,baz.example.org",
+					},
+				},
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("bar.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("exact.example.org", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				`Ignoring wildcard hostname "baz.example.org" of HTTPRoute default/test that doesn't fall under any of its wildcard hosts`,
+			},
+		},
+		{
+			title:      "WildcardMatchesSingleLabel",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Protocol: v1.HTTPProtocolType,
+						Hostname: hostnamePtr("*.example.internal"),
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: hostnames("foo.example.internal", "foo.bar.example.internal"),
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title: "WildcardMatchesMultipleLabels",
+			config: Config{
+				GatewayMultiLabelWildcards: true,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Protocol: v1.HTTPProtocolType,
+						Hostname: hostnamePtr("*.example.internal"),
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: hostnames("foo.example.internal", "foo.bar.example.internal"),
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("foo.bar.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:      "NoRouteHostname",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Protocol: v1.HTTPProtocolType,
+						Hostname: hostnamePtr("foo.example.internal"),
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "no-hostname"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: nil,
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:      "NoGateways",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways:   nil,
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{},
+					},
+				},
+				Status: httpRouteStatus(),
+			}},
+			endpoints: nil,
+		},
+		{
+			title:      "NoHostnames",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "no-hostname"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: nil,
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: nil,
+		},
+		{
+			title:      "HostnameAnnotation",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "without-hostame",
+						Namespace: "default",
+						Annotations: map[string]string{
+							hostnameAnnotationKey: "annotation.without-hostname.internal",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: nil,
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "with-hostame",
+						Namespace: "default",
+						Annotations: map[string]string{
+							hostnameAnnotationKey: "annotation.with-hostname.internal",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: hostnames("with-hostname.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("annotation.without-hostname.internal", "A", "1.2.3.4"),
+				newTestEndpoint("annotation.with-hostname.internal", "A", "1.2.3.4"),
+				newTestEndpoint("with-hostname.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title: "IgnoreHostnameAnnotation",
+			config: Config{
+				IgnoreHostnameAnnotation: true,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "with-hostame",
+					Namespace: "default",
+					Annotations: map[string]string{
+						hostnameAnnotationKey: "annotation.with-hostname.internal",
+					},
+				},
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("with-hostname.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("with-hostname.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title: "FQDNTemplate",
+			config: Config{
+				FQDNTemplate: "{{.Name}}.zero.internal, {{.Name}}.one.internal. ,  {{.Name}}.two.internal  ",
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "fqdn-with-hostnames"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("fqdn-with-hostnames.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "fqdn-without-hostnames"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: nil,
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("fqdn-without-hostnames.zero.internal", "A", "1.2.3.4"),
+				newTestEndpoint("fqdn-without-hostnames.one.internal", "A", "1.2.3.4"),
+				newTestEndpoint("fqdn-without-hostnames.two.internal", "A", "1.2.3.4"),
+				newTestEndpoint("fqdn-with-hostnames.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title: "CombineFQDN",
+			config: Config{
+				FQDNTemplate:             "combine-{{.Name}}.internal",
+				CombineFQDNAndAnnotation: true,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "fqdn-with-hostnames"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: hostnames("fqdn-with-hostnames.internal"),
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("fqdn-with-hostnames.internal", "A", "1.2.3.4"),
+				newTestEndpoint("combine-fqdn-with-hostnames.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title: "CanonicalHostnames",
+			config: Config{
+				FQDNTemplate:             "{{.Name}}.Template.internal,Shared.Example.internal.",
+				CombineFQDNAndAnnotation: true,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "route",
+					Namespace: "default",
+					Annotations: map[string]string{
+						hostnameAnnotationKey:           "Annotation.Example.internal.,SHARED.example.internal,Excluded.Example.internal.",
+						annotations.ExcludeHostnamesKey: "excluded.example.internal",
+					},
+				},
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: hostnames("Spec.Example.internal", "shared.example.internal"),
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("spec.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("shared.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("annotation.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("route.template.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:      "TTL",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "valid-ttl",
+						Namespace:   "default",
+						Annotations: map[string]string{ttlAnnotationKey: "15s"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("valid-ttl.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "invalid-ttl",
+						Namespace:   "default",
+						Annotations: map[string]string{ttlAnnotationKey: "abc"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("invalid-ttl.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("invalid-ttl.internal", "A", "1.2.3.4"),
+				newTestEndpointWithTTL("valid-ttl.internal", "A", 15, "1.2.3.4"),
+			},
+		},
+		{
+			title: "NamespaceTTL",
+			config: Config{
+				GatewayNamespaceTTL: map[string]string{"team-a": "5m"},
+			},
+			namespaces: namespaces("default", "team-a", "team-b"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Protocol:      v1.HTTPProtocolType,
+						AllowedRoutes: allowAllNamespaces,
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("team-a", "default-ttl"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("default-ttl.team-a.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "annotated-ttl",
+						Namespace:   "team-a",
+						Annotations: map[string]string{ttlAnnotationKey: "15s"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("annotated-ttl.team-a.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("team-b", "default-ttl"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("default-ttl.team-b.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpointWithTTL("default-ttl.team-a.internal", "A", 300, "1.2.3.4"),
+				newTestEndpointWithTTL("annotated-ttl.team-a.internal", "A", 15, "1.2.3.4"),
+				newTestEndpoint("default-ttl.team-b.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title: "ParentMatchingIntersection",
+			config: Config{
+				GatewayParentMatching: gatewayParentMatchingIntersection,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "wildcard"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{
+							Hostname: hostnamePtr("*.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "specific"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{
+							Hostname: hostnamePtr("both.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "both"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("both.example.internal", "wildcard.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "wildcard"),
+								gwParentRef("default", "specific"),
+							},
+						},
+					},
+					Status: httpRouteStatus(
+						gwParentRef("default", "wildcard"),
+						gwParentRef("default", "specific"),
+					),
+				},
+				{
+					ObjectMeta: objectMeta("default", "missing"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("missing.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "wildcard"),
+								gwParentRef("default", "missing"),
+							},
+						},
+					},
+					Status: httpRouteStatus(
+						gwParentRef("default", "wildcard"),
+						gwParentRef("default", "missing"),
+					),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("both.example.internal", "A", "1.2.3.4", "2.3.4.5"),
+			},
+		},
+		{
+			title: "TargetTransformer",
+			config: Config{
+				GatewayTargetTransformer: func(host, target string, meta *metav1.ObjectMeta) (string, bool) {
+					switch {
+					case meta.Name == "dropped":
+						return "", false
+					case host == "ip.example.internal":
+						return "203.0.113.10", true
+					default:
+						return strings.TrimSuffix(target, ".internal.cloud") + ".example.com", true
+					}
+				},
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: v1.GatewayStatus{
+					Addresses: []v1.GatewayStatusAddress{gwAddress(v1.HostnameAddressType, "lb.internal.cloud")},
+				},
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "transformed"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("alias.example.internal", "ip.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "dropped"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("dropped.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("alias.example.internal", "CNAME", "lb.example.com"),
+				newTestEndpoint("ip.example.internal", "A", "203.0.113.10"),
+			},
+		},
+		{
+			title:      "ExcludeHostnamesAnnotation",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+					Annotations: map[string]string{
+						hostnameAnnotationKey:           "annotation.example.internal, other.example.internal",
+						annotations.ExcludeHostnamesKey: "other.example.internal, *.legacy.example.internal, *.example.org",
+					},
+				},
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames(
+						"managed.example.internal",
+						"app.legacy.example.internal",
+						"*.legacy.example.internal",
+						"*.wildcard.example.internal",
+					),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("annotation.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("managed.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("*.wildcard.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"Excluding hostname *.legacy.example.internal of HTTPRoute default/test",
+			},
+		},
+		{
+			title:      "RouteLabels",
+			config:     Config{GatewayRouteLabels: true},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "httproute/default/test").
+					WithLabel(endpoint.RouteKindLabelKey, "HTTPRoute").
+					WithLabel(endpoint.RouteNamespaceLabelKey, "default").
+					WithLabel(endpoint.RouteNameLabelKey, "test"),
+			},
+		},
+		{
+			title:      "IncludeUnaccepted",
+			config:     Config{GatewayIncludeUnaccepted: true},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "accepted"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+						Hostnames: hostnames("accepted.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "not-accepted"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+						Hostnames: hostnames("not-accepted.example.internal"),
+					},
+					Status: v1.HTTPRouteStatus{
+						RouteStatus: v1.RouteStatus{
+							Parents: []v1.RouteParentStatus{{
+								ParentRef: gwParentRef("default", "test"),
+								Conditions: []metav1.Condition{{
+									Type:   string(v1.RouteConditionAccepted),
+									Status: metav1.ConditionFalse,
+								}},
+							}},
+						},
+					},
+				},
+				{
+					ObjectMeta: objectMeta("default", "without-status"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+						Hostnames: hostnames("without-status.example.internal"),
+					},
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("accepted.example.internal", "A", "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "httproute/default/accepted"),
+				newTestEndpoint("not-accepted.example.internal", "A", "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "httproute/default/not-accepted").
+					WithLabel(endpoint.ProvisionalLabelKey, "true"),
+				newTestEndpoint("without-status.example.internal", "A", "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "httproute/default/without-status").
+					WithLabel(endpoint.ProvisionalLabelKey, "true"),
+			},
+			logExpectations: []string{
+				"Gateway default/test has not accepted HTTPRoute default/not-accepted, publishing its hosts as provisional",
+				"Gateway default/test has not accepted HTTPRoute default/without-status, publishing its hosts as provisional",
+			},
+		},
+		{
+			title:      "GatewayTTL",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Namespace:   "default",
+					Annotations: map[string]string{ttlAnnotationKey: "5m"},
+				},
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "inherit"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("inherit.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "override",
+						Namespace:   "default",
+						Annotations: map[string]string{ttlAnnotationKey: "15s"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("override.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpointWithTTL("inherit.internal", "A", 300, "1.2.3.4"),
+				newTestEndpointWithTTL("override.internal", "A", 15, "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"Conflicting TTLs of httproute/default/override and gateway/default/test, using 15 of the Route",
+			},
+		},
+		{
+			title:      "ListenerTTL",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+					Annotations: map[string]string{
+						ttlAnnotationKey:           "5m",
+						annotations.ListenerTTLKey: "canary=30s, invalid=never, stable",
+					},
+				},
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{
+							Name:     "stable",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("stable.ttl.internal"),
+						},
+						{
+							Name:     "canary",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("canary.ttl.internal"),
+						},
+						{
+							Name:     "invalid",
+							Protocol: v1.HTTPProtocolType,
+							Hostname: hostnamePtr("invalid.ttl.internal"),
+						},
+					},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "listeners"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpointWithTTL("stable.ttl.internal", "A", 300, "1.2.3.4"),
+				newTestEndpointWithTTL("canary.ttl.internal", "A", 30, "1.2.3.4"),
+				newTestEndpointWithTTL("invalid.ttl.internal", "A", 300, "1.2.3.4"),
+			},
+			logExpectations: []string{
+				`gateway/default/test: "never" is not a valid TTL value`,
+				`gateway/default/test: "stable" is not a valid listener TTL, expected NAME=TTL`,
+			},
+		},
+		{
+			title:      "SharedHostname",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "one"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "two"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("2.3.4.5", "1.2.3.4"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "b",
+						Namespace:   "default",
+						Annotations: map[string]string{ttlAnnotationKey: "120"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("shared.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "two"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "two")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "a",
+						Namespace:   "default",
+						Annotations: map[string]string{ttlAnnotationKey: "60"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("shared.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "one"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "one")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpointWithTTL("shared.example.internal", "A", 60, "1.2.3.4", "2.3.4.5"),
+			},
+			logExpectations: []string{
+				"Conflicting TTLs for A record shared.example.internal from httproute/default/a and httproute/default/b, using 60 of httproute/default/a",
+			},
+		},
+		{
+			title:      "ProviderAnnotations",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "provider-annotations",
+					Namespace: "default",
+					Annotations: map[string]string{
+						annotations.SetIdentifierKey: "test-set-identifier",
+						aliasAnnotationKey:           "true",
+					},
+				},
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: hostnames("provider-annotations.com"),
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("provider-annotations.com", "A", "1.2.3.4").
+					WithProviderSpecific("alias", "true").
+					WithSetIdentifier("test-set-identifier"),
+			},
+		},
+		{
+			title:      "GeoAnnotations",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "geo",
+					Namespace: "default",
+					Annotations: map[string]string{
+						annotations.SetIdentifierKey:  "us-ca",
+						annotations.GeoCountryKey:     "US",
+						annotations.GeoSubdivisionKey: "california",
+					},
+				},
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: hostnames("geo.example.internal"),
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("geo.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific("aws/geolocation-country-code", "US").
+					WithSetIdentifier("us-ca"),
+			},
+			logExpectations: []string{
+				`Ignoring invalid external-dns.alpha.kubernetes.io/geo-subdivision annotation "california" of httproute/default/geo`,
+			},
+		},
+		{
+			title: "GeoAnnotationsWeighted",
+			config: Config{
+				GatewayWeightedTargets: true,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "weighted",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.SetIdentifierKey: "us",
+							annotations.GeoCountryKey:    "US",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: hostnames("weighted.example.internal"),
+						Rules: []v1.HTTPRouteRule{{
+							BackendRefs: []v1.HTTPBackendRef{
+								{BackendRef: backendRef("v1", 60)},
+								{BackendRef: backendRef("v2", 30)},
+							},
+						}},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "provider-specific",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.SetIdentifierKey:                                      "eu",
+							annotations.GeoCountryKey:                                         "DE",
+							"external-dns.alpha.kubernetes.io/aws-geolocation-continent-code": "EU",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: hostnames("provider-specific.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("weighted.example.internal", "A", "1.2.3.4").
+					WithSetIdentifier("us").
+					WithProviderSpecific("aws/weight", "90"),
+				newTestEndpoint("provider-specific.example.internal", "A", "1.2.3.4").
+					WithSetIdentifier("eu").
+					WithProviderSpecific("aws/geolocation-continent-code", "EU"),
+			},
+			logExpectations: []string{
+				"Ignoring the geo annotations of httproute/default/weighted because its records have the provider-specific property aws/weight",
+				"Ignoring the geo annotations of httproute/default/provider-specific because its records have the provider-specific property aws/geolocation-continent-code",
+			},
+		},
+		{
+			title:      "FailoverAnnotations",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "primary"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "secondary"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("5.6.7.8"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "primary",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.FailoverKey:      "primary",
+							annotations.HealthCheckIDKey: "hc-1",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "primary"),
+							},
+						},
+						Hostnames: hostnames("failover.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "primary")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "secondary",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.FailoverKey:      "Secondary",
+							annotations.SetIdentifierKey: "backup",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "secondary"),
+							},
+						},
+						Hostnames: hostnames("failover.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "secondary")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "invalid",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.FailoverKey:      "active",
+							annotations.SetIdentifierKey: "invalid",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "primary"),
+							},
+						},
+						Hostnames: hostnames("invalid.failover.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "primary")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "geo",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.FailoverKey:      "primary",
+							annotations.SetIdentifierKey: "us",
+							annotations.GeoCountryKey:    "US",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "primary"),
+							},
+						},
+						Hostnames: hostnames("geo.failover.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "primary")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "health-check",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.HealthCheckIDKey: "hc-2",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "primary"),
+							},
+						},
+						Hostnames: hostnames("health-check.failover.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "primary")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("failover.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific("aws/failover", "PRIMARY").
+					WithProviderSpecific("aws/health-check-id", "hc-1").
+					WithSetIdentifier("primary"),
+				newTestEndpoint("failover.example.internal", "A", "5.6.7.8").
+					WithProviderSpecific("aws/failover", "SECONDARY").
+					WithSetIdentifier("backup"),
+				newTestEndpoint("invalid.failover.example.internal", "A", "1.2.3.4").
+					WithSetIdentifier("invalid"),
+				newTestEndpoint("geo.failover.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific("aws/geolocation-country-code", "US").
+					WithSetIdentifier("us"),
+				newTestEndpoint("health-check.failover.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				`Ignoring invalid external-dns.alpha.kubernetes.io/failover annotation "active" of httproute/default/invalid, expected primary or secondary`,
+				"Ignoring external-dns.alpha.kubernetes.io/failover annotation of httproute/default/geo because it also has the external-dns.alpha.kubernetes.io/geo-country annotation",
+				"Ignoring external-dns.alpha.kubernetes.io/health-check-id annotation of httproute/default/health-check without the external-dns.alpha.kubernetes.io/failover annotation",
+			},
+		},
+		{
+			title:      "FailoverAnnotationsWebhookRoutingPolicy",
+			config:     Config{GatewayRoutingPolicyProvider: "webhook"},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "primary",
+					Namespace: "default",
+					Annotations: map[string]string{
+						annotations.FailoverKey:      "primary",
+						annotations.HealthCheckIDKey: "hc-1",
+					},
+				},
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+					},
+					Hostnames: hostnames("failover.example.internal"),
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("failover.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific("webhook/failover", "PRIMARY").
+					WithProviderSpecific("webhook/health-check-id", "hc-1").
+					WithSetIdentifier("primary"),
+			},
+		},
+		{
+			title:      "GatewayWeights",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "east",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.GatewayWeightKey: "80"},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "west",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.GatewayWeightKey: "20"},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("5.6.7.8"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "unweighted",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.GatewayWeightKey: "heavy"},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("9.9.9.9"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "regions"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "east"),
+								gwParentRef("default", "west"),
+							},
+						},
+						Hostnames: hostnames("regions.weights.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "east"), gwParentRef("default", "west")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "canary",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.SetIdentifierKey: "canary"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "east"),
+								gwParentRef("default", "west"),
+							},
+						},
+						Hostnames: hostnames("canary.weights.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "east"), gwParentRef("default", "west")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "mixed"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "east"),
+								gwParentRef("default", "unweighted"),
+							},
+						},
+						Hostnames: hostnames("mixed.weights.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "east"), gwParentRef("default", "unweighted")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "failover",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.FailoverKey: "primary"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "east"),
+								gwParentRef("default", "west"),
+							},
+						},
+						Hostnames: hostnames("failover.weights.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "east"), gwParentRef("default", "west")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("regions.weights.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific("aws/weight", "80").
+					WithSetIdentifier("default-east"),
+				newTestEndpoint("regions.weights.example.internal", "A", "5.6.7.8").
+					WithProviderSpecific("aws/weight", "20").
+					WithSetIdentifier("default-west"),
+				newTestEndpoint("canary.weights.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific("aws/weight", "80").
+					WithSetIdentifier("canary-default-east"),
+				newTestEndpoint("canary.weights.example.internal", "A", "5.6.7.8").
+					WithProviderSpecific("aws/weight", "20").
+					WithSetIdentifier("canary-default-west"),
+				newTestEndpoint("mixed.weights.example.internal", "A", "1.2.3.4", "9.9.9.9"),
+				newTestEndpoint("failover.weights.example.internal", "A", "1.2.3.4", "5.6.7.8").
+					WithProviderSpecific("aws/failover", "PRIMARY").
+					WithSetIdentifier("primary"),
+			},
+			logExpectations: []string{
+				`gateway/default/unweighted: "heavy" is not a valid gateway weight, expected an integer from 0 to 255`,
+				"Ignoring Gateway weights of host mixed.weights.example.internal of HTTPRoute default/mixed, which Gateways without a weight publish as well",
+				"Ignoring Gateway weights of host failover.weights.example.internal of HTTPRoute default/failover because it sets the provider-specific property aws/failover",
+			},
+		},
+		{
+			title: "SetIdentifierTemplate",
+			config: Config{
+				GatewayClusterName:           "east",
+				GatewaySetIdentifierTemplate: `{{ .Cluster }}-{{ .Name }}{{ with .SetIdentifier }}-{{ . }}{{ end }}`,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "plain"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: hostnames("plain.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "annotated",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.SetIdentifierKey: "primary"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: hostnames("annotated.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("plain.example.internal", "A", "1.2.3.4").
+					WithSetIdentifier("east-plain"),
+				newTestEndpoint("annotated.example.internal", "A", "1.2.3.4").
+					WithSetIdentifier("east-annotated-primary"),
+			},
+		},
+		{
+			title: "RouteErrorsSkipRoute",
+			config: Config{
+				GatewaySetIdentifierTemplate: `{{ index .Route.Spec.Hostnames 0 }}`,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "valid"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: hostnames("valid.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					// The set identifier template fails for Routes without spec hostnames.
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "invalid",
+						Namespace:   "default",
+						Annotations: map[string]string{hostnameAnnotationKey: "invalid.example.internal"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("valid.example.internal", "A", "1.2.3.4").
+					WithSetIdentifier("valid.example.internal"),
+			},
+			logExpectations: []string{
+				"Skipped 1 HTTPRoutes that failed to generate endpoints",
+				"failed to apply set identifier template on HTTPRoute default/invalid",
+			},
+		},
+		{
+			title: "ResourceTemplate",
+			config: Config{
+				GatewayClusterName:      "east",
+				GatewayResourceTemplate: `{{ .Cluster }}/{{ toLower .Kind }}/{{ .Namespace }}/{{ .Name }}`,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "app"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: hostnames("resource.example.internal"),
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("resource.example.internal", "A", "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "east/httproute/default/app"),
+			},
+		},
+		{
+			title: "DeniedHostnames",
+			config: Config{
+				GatewayDeniedHostnames: []string{"*.admin.example.internal", "Login.Example.Internal.", " "},
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "tenant"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: hostnames(
+						"app.example.internal",
+						"login.example.internal",
+						"admin.example.internal",
+						"console.admin.example.internal",
+						"*.admin.example.internal",
+					),
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("app.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("admin.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"Skipping denied host login.example.internal of HTTPRoute default/tenant",
+				"Skipping denied host console.admin.example.internal of HTTPRoute default/tenant",
+				"Skipping denied host *.admin.example.internal of HTTPRoute default/tenant",
+			},
+		},
+		{
+			title:      "Nameservers",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "delegated",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.NameserversKey: "ns1.example.net, NS2.example.net., 10.0.0.53, bad_ns,ns1.example.net,",
+							ttlAnnotationKey:           "3600",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: hostnames("sub.delegated.example.internal", "*.delegated.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "invalid",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.NameserversKey: "10.0.0.53"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: hostnames("invalid.delegated.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpointWithTTL("sub.delegated.example.internal", "NS", 3600, "ns1.example.net", "ns2.example.net"),
+				newTestEndpoint("invalid.delegated.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				`Ignoring invalid nameserver "10.0.0.53" of httproute/default/delegated, expected a hostname`,
+				`Ignoring invalid nameserver "bad_ns" of httproute/default/delegated, expected a hostname`,
+				"Skipping wildcard host *.delegated.example.internal of HTTPRoute default/delegated, which can't be delegated",
+				`Ignoring invalid nameserver "10.0.0.53" of httproute/default/invalid, expected a hostname`,
+			},
+		},
+		{
+			title: "TargetCIDRAllowlist",
+			config: Config{
+				GatewayTargetCIDRAllowlist: []string{"10.0.0.0/8", "2001:db8::/32"},
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: v1.GatewayStatus{
+					Addresses: []v1.GatewayStatusAddress{
+						gwAddress(v1.IPAddressType, "10.1.2.3"),
+						gwAddress(v1.IPAddressType, "1.2.3.4"),
+						gwAddress(v1.IPAddressType, "2001:db8::1"),
+						gwAddress(v1.IPAddressType, "2001:db9::1"),
+						gwAddress(v1.HostnameAddressType, "lb.example.internal"),
+					},
+				},
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "10.1.2.3"),
+				newTestEndpoint("test.example.internal", "AAAA", "2001:db8::1"),
+				newTestEndpoint("test.example.internal", "CNAME", "lb.example.internal"),
+			},
+			logExpectations: []string{
+				"Dropping target 1.2.3.4 of Gateway default/test outside of the target CIDR allowlist",
+				"Dropping target 2001:db9::1 of Gateway default/test outside of the target CIDR allowlist",
+			},
+		},
+		{
+			title: "HostnameSuffixes",
+			config: Config{
+				GatewayHostnameSuffixes: []string{"internal.example.com"},
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{Name: "any", Protocol: v1.HTTPProtocolType},
+						{Name: "wildcard", Protocol: v1.HTTPProtocolType, Hostname: hostnamePtr("*.example.com")},
+					},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "named"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test", withSectionName("any")),
+							},
+						},
+						Hostnames: hostnames("a.internal.example.com", "*.internal.example.com", "a.example.com"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test", withSectionName("any"))),
+				},
+				{
+					// The Route inherits the wildcard hostname of the Listener, which is broader than the suffix.
+					ObjectMeta: objectMeta("default", "inherited"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test", withSectionName("wildcard")),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test", withSectionName("wildcard"))),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("a.internal.example.com", "A", "1.2.3.4"),
+				newTestEndpoint("*.internal.example.com", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				`Skipping host a.example.com of HTTPRoute default/named without an allowed hostname suffix`,
+				`Skipping host *.example.com of HTTPRoute default/inherited without an allowed hostname suffix`,
+			},
+		},
+		{
+			title: "HostnameSuffixesParentless",
+			config: Config{
+				GatewayHostnameSuffixes: []string{"internal.example.com", "internal.example.org"},
+				GatewayParentlessRoutes: true,
+			},
+			namespaces: namespaces("default"),
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "parentless",
+					Namespace:   "default",
+					Annotations: map[string]string{targetAnnotationKey: "10.0.0.1"},
+				},
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("zone.internal.example.org", "zone.example.org"),
+				},
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("zone.internal.example.org", "A", "10.0.0.1"),
+			},
+			logExpectations: []string{
+				`Skipping host zone.example.org of HTTPRoute default/parentless without an allowed hostname suffix`,
+			},
+		},
+		{
+			title:      "CreatePTR",
+			config:     Config{GatewayCreatePTR: true},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4", "2001:db8::1"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Namespace:   "default",
+					Annotations: map[string]string{ttlAnnotationKey: "300"},
+				},
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: hostnames("test.example.internal", "*.wildcard.example.internal"),
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpointWithTTL("test.example.internal", "A", 300, "1.2.3.4"),
+				newTestEndpointWithTTL("test.example.internal", "AAAA", 300, "2001:db8::1"),
+				newTestEndpointWithTTL("*.wildcard.example.internal", "A", 300, "1.2.3.4"),
+				newTestEndpointWithTTL("*.wildcard.example.internal", "AAAA", 300, "2001:db8::1"),
+				newTestEndpointWithTTL("4.3.2.1.in-addr.arpa", "PTR", 300, "test.example.internal").
+					WithLabel(endpoint.ResourceLabelKey, "httproute/default/test"),
+				newTestEndpointWithTTL("1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", "PTR", 300, "test.example.internal").
+					WithLabel(endpoint.ResourceLabelKey, "httproute/default/test"),
+			},
+		},
+		{
+			title:      "InfrastructureTargetAnnotations",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "infrastructure"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+						Infrastructure: &v1.GatewayInfrastructure{
+							Annotations: map[v1.AnnotationKey]v1.AnnotationValue{annotations.TargetKey: "10.0.0.1"},
+						},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "metadata",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.TargetKey: "203.0.113.1"},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+						Infrastructure: &v1.GatewayInfrastructure{
+							Annotations: map[v1.AnnotationKey]v1.AnnotationValue{annotations.TargetKey: "10.0.0.1"},
+						},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "infrastructure"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "infrastructure"),
+							},
+						},
+						Hostnames: hostnames("infrastructure.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "infrastructure")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "metadata"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "metadata"),
+							},
+						},
+						Hostnames: hostnames("metadata.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "metadata")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("infrastructure.example.internal", "A", "10.0.0.1"),
+				newTestEndpoint("metadata.example.internal", "A", "203.0.113.1"),
+			},
+		},
+		{
+			title:      "GatewayAliasAnnotation",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+					Annotations: map[string]string{
+						aliasAnnotationKey:    "true",
+						annotations.TargetKey: "lb.example.internal",
+					},
+				},
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "inherited"),
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: hostnames("inherited.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "both",
+						Namespace: "default",
+						Annotations: map[string]string{
+							aliasAnnotationKey: "true",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: hostnames("both.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "overridden",
+						Namespace: "default",
+						Annotations: map[string]string{
+							aliasAnnotationKey: "false",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Hostnames: hostnames("overridden.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("inherited.example.internal", "CNAME", "lb.example.internal").
+					WithProviderSpecific("alias", "true"),
+				newTestEndpoint("both.example.internal", "CNAME", "lb.example.internal").
+					WithProviderSpecific("alias", "true"),
+				newTestEndpoint("overridden.example.internal", "CNAME", "lb.example.internal"),
+			},
+		},
+		{
+			title:      "AccessAnnotation",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "ips"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("10.0.0.1", "203.0.113.1", "fd00::1", "2001:db8::1"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "hostname"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: v1.GatewayStatus{
+						Addresses: []v1.GatewayStatusAddress{gwAddress(v1.HostnameAddressType, "lb.example.internal")},
+					},
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "public",
+						Namespace:   "default",
+						Annotations: map[string]string{accessAnnotationKey: "public"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "ips"),
+							},
+						},
+						Hostnames: hostnames("public.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "ips")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "private",
+						Namespace:   "default",
+						Annotations: map[string]string{accessAnnotationKey: "private"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "ips"),
+							},
+						},
+						Hostnames: hostnames("private.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "ips")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "any",
+						Namespace:   "default",
+						Annotations: nil,
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "ips"),
+							},
+						},
+						Hostnames: hostnames("any.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "ips")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "invalid",
+						Namespace:   "default",
+						Annotations: map[string]string{accessAnnotationKey: "internal"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "ips"),
+							},
+						},
+						Hostnames: hostnames("invalid.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "ips")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "public-hostname",
+						Namespace:   "default",
+						Annotations: map[string]string{accessAnnotationKey: "public"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "hostname"),
+							},
+						},
+						Hostnames: hostnames("public-hostname.example.internal"),
+					},
+					Status: httpRouteStatus(gwParentRef("default", "hostname")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("public.example.internal", "A", "203.0.113.1"),
+				newTestEndpoint("public.example.internal", "AAAA", "2001:db8::1"),
+				newTestEndpoint("private.example.internal", "A", "10.0.0.1"),
+				newTestEndpoint("private.example.internal", "AAAA", "fd00::1"),
+				newTestEndpoint("any.example.internal", "A", "10.0.0.1", "203.0.113.1"),
+				newTestEndpoint("any.example.internal", "AAAA", "fd00::1", "2001:db8::1"),
+				newTestEndpoint("invalid.example.internal", "A", "10.0.0.1", "203.0.113.1"),
+				newTestEndpoint("invalid.example.internal", "AAAA", "fd00::1", "2001:db8::1"),
+				newTestEndpoint("public-hostname.example.internal", "CNAME", "lb.example.internal"),
+			},
+		},
+		{
+			title:      "DifferentHostnameDifferentGateway",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "one"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{
+							Hostname: hostnamePtr("*.one.internal"),
+							Protocol: v1.HTTPProtocolType,
+						}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "two"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{
+							Hostname: hostnamePtr("*.two.internal"),
+							Protocol: v1.HTTPProtocolType,
+						}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "one"),
+							gwParentRef("default", "two"),
+						},
+					},
+					Hostnames: hostnames("test.one.internal", "test.two.internal"),
+				},
+				Status: httpRouteStatus(
+					gwParentRef("default", "one"),
+					gwParentRef("default", "two"),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.one.internal", "A", "1.2.3.4"),
+				newTestEndpoint("test.two.internal", "A", "2.3.4.5"),
+			},
+		},
+		{
+			title:      "AllowedRoutesSameNamespace",
+			config:     Config{},
+			namespaces: namespaces("same-namespace", "other-namespace"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("same-namespace", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Protocol: v1.HTTPProtocolType,
+						AllowedRoutes: &v1.AllowedRoutes{
+							Namespaces: &v1.RouteNamespaces{
+								From: &fromSame,
+							},
+						},
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("same-namespace", "test"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("same-namespace.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("same-namespace", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("same-namespace", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("other-namespace", "test"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("other-namespace.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("same-namespace", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("same-namespace", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("same-namespace.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:  "AllowedRoutesNamespaceSelector",
+			config: Config{},
+			namespaces: []*corev1.Namespace{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "default",
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "foo",
+						Labels: map[string]string{"team": "foo"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "bar",
+						Labels: map[string]string{"team": "bar"},
+					},
+				},
+			},
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Protocol: v1.HTTPProtocolType,
+						AllowedRoutes: &v1.AllowedRoutes{
+							Namespaces: &v1.RouteNamespaces{
+								From: &fromSelector,
+								Selector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"team": "foo"},
+								},
+							},
+						},
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("foo", "test"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("foo.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("bar", "test"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("bar.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:  "NamespaceSelectorIgnoresAnnotations",
+			config: Config{},
+			namespaces: []*corev1.Namespace{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "default",
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "labeled",
+						Labels: map[string]string{"team": "foo"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "annotated",
+						Annotations: map[string]string{"team": "foo"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "other",
+						Annotations: map[string]string{"team": "bar"},
+					},
+				},
+			},
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Name:     "http",
+						Protocol: v1.HTTPProtocolType,
+						AllowedRoutes: &v1.AllowedRoutes{
+							Namespaces: &v1.RouteNamespaces{
+								From: &fromSelector,
+								Selector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"team": "foo"},
+								},
+							},
+						},
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("labeled", "test"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("labeled.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("annotated", "test"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("annotated.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("other", "test"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("other.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("labeled.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:  "NamespaceSelectorAnnotations",
+			config: Config{GatewayNamespaceAnnotations: true},
+			namespaces: []*corev1.Namespace{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "default",
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "labeled",
+						Labels: map[string]string{"team": "foo"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "annotated",
+						Annotations: map[string]string{"team": "foo"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "other",
+						Annotations: map[string]string{"team": "bar"},
+					},
+				},
+			},
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Name:     "http",
+						Protocol: v1.HTTPProtocolType,
+						AllowedRoutes: &v1.AllowedRoutes{
+							Namespaces: &v1.RouteNamespaces{
+								From: &fromSelector,
+								Selector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"team": "foo"},
+								},
+							},
+						},
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("labeled", "test"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("labeled.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("annotated", "test"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("annotated.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("other", "test"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("other.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("labeled.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("annotated.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				`Gateway default/test section "http" allows HTTPRoute annotated/test by the annotations of its namespace`,
+			},
+		},
+		{
+			title:      "MissingNamespace",
+			config:     Config{},
+			namespaces: nil,
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Protocol: v1.HTTPProtocolType,
+						AllowedRoutes: &v1.AllowedRoutes{
+							Namespaces: &v1.RouteNamespaces{
+								// Namespace selector triggers namespace lookup.
+								From: &fromSelector,
+								Selector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"foo": "bar"},
+								},
+							},
+						},
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: hostnames("example.internal"),
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: nil,
+		},
+		{
+			title: "AnnotationOverride",
+			config: Config{
+				GatewayNamespace: "gateway-namespace",
+			},
+			namespaces: namespaces("gateway-namespace", "route-namespace"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "overriden-gateway",
+						Namespace: "gateway-namespace",
+						Annotations: map[string]string{
+							targetAnnotationKey: "4.3.2.1",
+						},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{
+							Protocol:      v1.HTTPProtocolType,
+							AllowedRoutes: allowAllNamespaces,
+						}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("route-namespace", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("gateway-namespace", "overriden-gateway"),
+						},
+					},
+				},
+				Status: httpRouteStatus( // The route is attached to both gateways.
+					gwParentRef("gateway-namespace", "overriden-gateway"),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "4.3.2.1"),
+			},
+		},
+		{
+			title: "MutlipleGatewaysOneAnnotationOverride",
+			config: Config{
+				GatewayNamespace: "gateway-namespace",
+			},
+			namespaces: namespaces("gateway-namespace", "route-namespace"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "overriden-gateway",
+						Namespace: "gateway-namespace",
+						Annotations: map[string]string{
+							targetAnnotationKey: "4.3.2.1",
+						},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{
+							Protocol:      v1.HTTPProtocolType,
+							AllowedRoutes: allowAllNamespaces,
+						}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("gateway-namespace", "test"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{
+							Protocol:      v1.HTTPProtocolType,
+							AllowedRoutes: allowAllNamespaces,
+						}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("route-namespace", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("gateway-namespace", "overriden-gateway"),
+							gwParentRef("gateway-namespace", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(
+					gwParentRef("gateway-namespace", "overriden-gateway"),
+					gwParentRef("gateway-namespace", "test"),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "4.3.2.1", "2.3.4.5"),
+			},
+		},
+		{
+			title:      "MultipleGatewaysMultipleRoutes",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "one"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "two"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "one"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("test.one.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "one"),
+							},
+						},
+					},
+					Status: httpRouteStatus(
+						gwParentRef("default", "one"),
+					),
+				},
+				{
+					ObjectMeta: objectMeta("default", "two"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("test.two.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "two"),
+							},
+						},
+					},
+					Status: httpRouteStatus(
+						gwParentRef("default", "two"),
+					),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.one.internal", "A", "1.2.3.4"),
+				newTestEndpoint("test.two.internal", "A", "2.3.4.5"),
+			},
+			logExpectations: []string{
+				"Endpoints generated from HTTPRoute default/one: [test.one.internal 0 IN A  1.2.3.4 []]",
+				"Endpoints generated from HTTPRoute default/two: [test.two.internal 0 IN A  2.3.4.5 []]",
+			},
+		},
+		{
+			title: "NoParentRefs",
+			config: Config{
+				GatewayNamespace: "gateway-namespace",
+			},
+			namespaces: namespaces("gateway-namespace", "route-namespace"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("gateway-namespace", "test"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{
+							Protocol:      v1.HTTPProtocolType,
+							AllowedRoutes: allowAllNamespaces,
+						}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("route-namespace", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+				},
+				Status: httpRouteStatus(gwParentRef("gateway-namespace", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{},
+			logExpectations: []string{
+				"No parent references found for HTTPRoute route-namespace/test",
+			},
+		},
+		{
+			title: "ParentRefsMismatch",
+			config: Config{
+				GatewayNamespace: "gateway-namespace",
+			},
+			namespaces: namespaces("gateway-namespace", "route-namespace"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("gateway-namespace", "test"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{
+							Protocol:      v1.HTTPProtocolType,
+							AllowedRoutes: allowAllNamespaces,
+						}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("route-namespace", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("gateway-namespace", "default-gateway"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("gateway-namespace", "other-gateway")),
+			}},
+			endpoints: []*endpoint.Endpoint{},
+			logExpectations: []string{
+				"Parent reference gateway-namespace/other-gateway not found in routeParentRefs for HTTPRoute route-namespace/test",
+			},
+		},
+		{
+			title: "RequireProgrammed",
+			config: Config{
+				GatewayRequireProgrammed: true,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "programmed"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatusWithConditions(v1.GatewayConditionProgrammed, metav1.ConditionTrue, "1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "not-programmed"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatusWithConditions(v1.GatewayConditionProgrammed, metav1.ConditionFalse, "2.3.4.5"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "unknown"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("3.4.5.6"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "programmed"),
+							gwParentRef("default", "not-programmed"),
+							gwParentRef("default", "unknown"),
+						},
+					},
+				},
+				Status: httpRouteStatus(
+					gwParentRef("default", "programmed"),
+					gwParentRef("default", "not-programmed"),
+					gwParentRef("default", "unknown"),
+				),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"Gateway default/not-programmed has not been programmed for HTTPRoute default/test",
+				"Gateway default/unknown has not been programmed for HTTPRoute default/test",
+			},
+		},
+		{
+			title: "RequireReferenceGrant",
+			config: Config{
+				GatewayRequireReferenceGrant: true,
+			},
+			namespaces: namespaces("gateway-namespace", "granted", "not-granted"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("gateway-namespace", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Protocol:      v1.HTTPProtocolType,
+						AllowedRoutes: allowAllNamespaces,
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			referenceGrants: []*v1beta1.ReferenceGrant{{
+				ObjectMeta: objectMeta("gateway-namespace", "grant"),
+				Spec: v1beta1.ReferenceGrantSpec{
+					From: []v1beta1.ReferenceGrantFrom{{
+						Group:     gatewayGroup,
+						Kind:      "HTTPRoute",
+						Namespace: "granted",
+					}},
+					To: []v1beta1.ReferenceGrantTo{{
+						Group: gatewayGroup,
+						Kind:  gatewayKind,
+					}},
+				},
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("granted", "test"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("granted.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("gateway-namespace", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("gateway-namespace", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("not-granted", "test"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("not-granted.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("gateway-namespace", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("gateway-namespace", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("granted.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"No ReferenceGrant allows HTTPRoute not-granted/test to reference Gateway gateway-namespace/test",
+			},
+		},
+		{
+			title: "RequireReferenceGrantSameNamespace",
+			config: Config{
+				GatewayRequireReferenceGrant: true,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:      "GatewayAddressTypes",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: v1.GatewayStatus{
+					Addresses: []v1.GatewayStatusAddress{
+						gwAddress(v1.HostnameAddressType, "lb.example.internal"),
+						gwAddress(v1.IPAddressType, "1.2.3.4"),
+					},
+				},
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("test.example.internal", "CNAME", "lb.example.internal"),
+			},
+		},
+		{
+			title:      "PreferredAddressTypeHostname",
+			config:     Config{GatewayPreferredAddressType: "Hostname"},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: v1.GatewayStatus{
+					Addresses: []v1.GatewayStatusAddress{
+						gwAddress(v1.HostnameAddressType, "lb.example.internal"),
+						gwAddress(v1.IPAddressType, "1.2.3.4"),
+					},
+				},
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "CNAME", "lb.example.internal"),
+			},
+		},
+		{
+			title:      "PreferredAddressTypeIPAddress",
+			config:     Config{GatewayPreferredAddressType: "IPAddress"},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: v1.GatewayStatus{
+					Addresses: []v1.GatewayStatusAddress{
+						gwAddress(v1.HostnameAddressType, "lb.example.internal"),
+						gwAddress(v1.IPAddressType, "1.2.3.4"),
+					},
+				},
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:      "PreferredAddressTypeAbsent",
+			config:     Config{GatewayPreferredAddressType: "Hostname"},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: v1.GatewayStatus{
+					Addresses: []v1.GatewayStatusAddress{
+						gwAddress(v1.IPAddressType, "1.2.3.4"),
+						gwAddress(v1.IPAddressType, "2001:db8::1"),
+					},
+				},
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("test.example.internal", "AAAA", "2001:db8::1"),
+			},
+		},
+		{
+			title:      "DualStackGateway",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "status"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4", "2001:db8::1", "2.3.4.5", "2001:db8::2"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "annotation",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.TargetKey: "3.4.5.6,2001:db8::3"},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "status"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("status.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "status"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "status")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "annotation"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("annotation.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "annotation"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "annotation")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("status.example.internal", "A", "1.2.3.4", "2.3.4.5"),
+				newTestEndpoint("status.example.internal", "AAAA", "2001:db8::1", "2001:db8::2"),
+				newTestEndpoint("annotation.example.internal", "A", "3.4.5.6"),
+				newTestEndpoint("annotation.example.internal", "AAAA", "2001:db8::3"),
+			},
+		},
+		{
+			title:      "RecordTypeAnnotation",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: v1.GatewayStatus{
+					Addresses: []v1.GatewayStatusAddress{
+						gwAddress(v1.IPAddressType, "1.2.3.4"),
+						gwAddress(v1.HostnameAddressType, "lb.example.internal"),
+					},
+				},
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "forced",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.RecordTypeKey: "cname"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("forced.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "invalid",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.RecordTypeKey: "ALIAS"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("invalid.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("forced.example.internal", "CNAME", "1.2.3.4", "lb.example.internal"),
+				newTestEndpoint("invalid.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("invalid.example.internal", "CNAME", "lb.example.internal"),
+			},
+			logExpectations: []string{
+				`httproute/default/invalid: "ALIAS" is not a valid record type`,
+			},
+		},
+		{
+			title: "WeightedTargets",
+			config: Config{
+				GatewayWeightedTargets: true,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "blue",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.SetIdentifierKey: "blue",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("weighted.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Rules: []v1.HTTPRouteRule{{
+							BackendRefs: []v1.HTTPBackendRef{
+								{BackendRef: backendRef("blue-v1", 60)},
+								{BackendRef: backendRef("blue-v2", 30)},
+							},
+						}},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "green",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.SetIdentifierKey: "green",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("weighted.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Rules: []v1.HTTPRouteRule{{
+							BackendRefs: []v1.HTTPBackendRef{
+								{BackendRef: backendRef("green-v1", 5)},
+								{BackendRef: backendRef("green-v2", 5)},
+							},
+						}},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "single",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.SetIdentifierKey: "single",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("single.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Rules: []v1.HTTPRouteRule{{
+							BackendRefs: []v1.HTTPBackendRef{
+								{BackendRef: backendRef("single")},
+							},
+						}},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "single-weighted",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.SetIdentifierKey: "single-weighted",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("single-weighted.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+						Rules: []v1.HTTPRouteRule{{
+							BackendRefs: []v1.HTTPBackendRef{
+								{BackendRef: backendRef("single", 50)},
+							},
+						}},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("weighted.example.internal", "A", "1.2.3.4").
+					WithSetIdentifier("blue").
+					WithProviderSpecific("aws/weight", "90"),
+				newTestEndpoint("weighted.example.internal", "A", "1.2.3.4").
+					WithSetIdentifier("green").
+					WithProviderSpecific("aws/weight", "10"),
+				newTestEndpoint("single.example.internal", "A", "1.2.3.4").
+					WithSetIdentifier("single"),
+				newTestEndpoint("single-weighted.example.internal", "A", "1.2.3.4").
+					WithSetIdentifier("single-weighted"),
+			},
+		},
+		{
+			title: "WeightedTargetsWebhookRoutingPolicy",
+			config: Config{
+				GatewayWeightedTargets:       true,
+				GatewayRoutingPolicyProvider: "webhook",
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blue",
+					Namespace: "default",
+					Annotations: map[string]string{
+						annotations.SetIdentifierKey: "blue",
+					},
+				},
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("weighted.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Rules: []v1.HTTPRouteRule{{
+						BackendRefs: []v1.HTTPBackendRef{
+							{BackendRef: backendRef("blue-v1", 60)},
+							{BackendRef: backendRef("blue-v2", 30)},
+						},
+					}},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("weighted.example.internal", "A", "1.2.3.4").
+					WithSetIdentifier("blue").
+					WithProviderSpecific("webhook/weight", "90"),
+			},
+		},
+		{
+			title: "CertificateHostnames",
+			config: Config{
+				GatewayCertificateHostnames: true,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{
+						Name:     "https",
+						Protocol: v1.HTTPSProtocolType,
+						TLS: &v1.GatewayTLSConfig{
+							CertificateRefs: []v1.SecretObjectReference{{Name: "wildcard"}},
+						},
+					}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			secrets: []*corev1.Secret{
+				tlsSecret(t, "default", "wildcard", "*.example.internal"),
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal", "test.example.org"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title: "CertificateHostnamesCrossNamespace",
+			config: Config{
+				GatewayCertificateHostnames: true,
+			},
+			namespaces: namespaces("default", "certs"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{
+							Name:     "granted",
+							Protocol: v1.HTTPSProtocolType,
+							TLS: &v1.GatewayTLSConfig{
+								CertificateRefs: []v1.SecretObjectReference{{
+									Name:      "granted",
+									Namespace: namespacePtr("certs"),
+								}},
+							},
+						},
+						{
+							Name:     "not-granted",
+							Protocol: v1.HTTPSProtocolType,
+							TLS: &v1.GatewayTLSConfig{
+								CertificateRefs: []v1.SecretObjectReference{{
+									Name:      "not-granted",
+									Namespace: namespacePtr("certs"),
+								}},
+							},
+						},
+					},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			referenceGrants: []*v1beta1.ReferenceGrant{{
+				ObjectMeta: objectMeta("certs", "grant"),
+				Spec: v1beta1.ReferenceGrantSpec{
+					From: []v1beta1.ReferenceGrantFrom{{
+						Group:     gatewayGroup,
+						Kind:      gatewayKind,
+						Namespace: "default",
+					}},
+					To: []v1beta1.ReferenceGrantTo{{
+						Kind: "Secret",
+						Name: objectNamePtr("granted"),
+					}},
+				},
+			}},
+			secrets: []*corev1.Secret{
+				tlsSecret(t, "certs", "granted", "granted.example.internal"),
+				tlsSecret(t, "certs", "not-granted", "not-granted.example.internal"),
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "granted"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("granted.example.internal", "other.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test", withSectionName("granted")),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test", withSectionName("granted"))),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("granted.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"No ReferenceGrant allows Gateway default/test to reference Secret certs/not-granted",
+			},
+		},
+		{
+			title:      "ListenerTargetAnnotation",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+					Annotations: map[string]string{
+						annotations.TargetKey + ".internal": "10.0.0.1",
+						annotations.TargetKey + ".external": "203.0.113.1",
+					},
+				},
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{
+							Name:     "internal",
+							Hostname: hostnamePtr("*.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						},
+						{
+							Name:     "external",
+							Hostname: hostnamePtr("*.example.org"),
+							Protocol: v1.HTTPProtocolType,
+						},
+						{
+							Name:     "default",
+							Hostname: hostnamePtr("*.example.net"),
+							Protocol: v1.HTTPProtocolType,
+						},
+					},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("app.example.internal", "app.example.org", "app.example.net"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("app.example.internal", "A", "10.0.0.1"),
+				newTestEndpoint("app.example.org", "A", "203.0.113.1"),
+				newTestEndpoint("app.example.net", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title: "BackendHostnames",
+			config: Config{
+				GatewayResolveBackends:  true,
+				GatewayBackendHostnames: true,
+			},
+			namespaces: namespaces("default", "not-granted"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			services: []*corev1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "legacy",
+						Namespace:   "default",
+						Annotations: map[string]string{hostnameAnnotationKey: "legacy.example.internal, Test.example.internal."},
+					},
+					Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "10.0.0.1"},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "api",
+						Namespace:   "not-granted",
+						Annotations: map[string]string{hostnameAnnotationKey: "not-granted.example.internal"},
+					},
+					Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "10.0.0.2"},
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("test.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Rules: []v1.HTTPRouteRule{{
+						BackendRefs: []v1.HTTPBackendRef{
+							{BackendRef: backendRef("legacy")},
+							{BackendRef: namespacedBackendRef("not-granted", "api")},
+						},
+					}},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("legacy.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title: "CustomParentGroupKind",
+			config: Config{
+				GatewayParentGroup: "gateway.vendor.example",
+				GatewayParentKind:  "VendorGateway",
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "vendor"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("vendor.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test", withGroupKind("gateway.vendor.example", "VendorGateway")),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test", withGroupKind("gateway.vendor.example", "VendorGateway"))),
+				},
+				{
+					ObjectMeta: objectMeta("default", "standard"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("standard.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("vendor.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:      "GatewayProviderSpecific",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "first",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.CloudflareProxiedKey:                 "true",
+							annotations.AWSPrefix + "evaluate-target-health": "false",
+						},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "second",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.CloudflareProxiedKey:          "false",
+							annotations.CloudflareRecordCommentKey:    "shared",
+							annotations.AWSPrefix + "health-check-id": "abc",
+						},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "inherited"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("inherited.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "first"),
+								gwParentRef("default", "second"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "first"), gwParentRef("default", "second")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "overridden",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.CloudflareProxiedKey: "true"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("overridden.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "second"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "second")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("inherited.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific("aws/evaluate-target-health", "false").
+					WithProviderSpecific(annotations.CloudflareProxiedKey, "true").
+					WithProviderSpecific("aws/health-check-id", "abc").
+					WithProviderSpecific(annotations.CloudflareRecordCommentKey, "shared"),
+				newTestEndpoint("overridden.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific(annotations.CloudflareProxiedKey, "true").
+					WithProviderSpecific("aws/health-check-id", "abc").
+					WithProviderSpecific(annotations.CloudflareRecordCommentKey, "shared"),
+			},
+		},
+		{
+			title:      "LenientProtocols",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{Name: "http", Protocol: v1.HTTPProtocolType, Port: 80, Hostname: hostnamePtr("*.http.internal")},
+						{Name: "https", Protocol: v1.HTTPSProtocolType, Port: 443, Hostname: hostnamePtr("*.https.internal")},
+					},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "plain"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("plain.http.internal", "plain.https.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "secure",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.ListenerProtocolKey: "HTTPS"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("secure.http.internal", "secure.https.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("plain.http.internal", "A", "1.2.3.4"),
+				newTestEndpoint("plain.https.internal", "A", "1.2.3.4"),
+				newTestEndpoint("secure.http.internal", "A", "1.2.3.4"),
+				newTestEndpoint("secure.https.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:      "StrictProtocols",
+			config:     Config{GatewayStrictProtocols: true},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{Name: "http", Protocol: v1.HTTPProtocolType, Port: 80, Hostname: hostnamePtr("*.http.internal")},
+						{Name: "https", Protocol: v1.HTTPSProtocolType, Port: 443, Hostname: hostnamePtr("*.https.internal")},
+					},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "plain"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("plain.http.internal", "plain.https.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "secure",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.ListenerProtocolKey: "HTTPS"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("secure.http.internal", "secure.https.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "lowercase",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.ListenerProtocolKey: " https"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("lowercase.http.internal", "lowercase.https.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "unknown",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.ListenerProtocolKey: "QUIC"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("unknown.http.internal", "unknown.https.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "test"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("plain.http.internal", "A", "1.2.3.4"),
+				newTestEndpoint("secure.https.internal", "A", "1.2.3.4"),
+				newTestEndpoint("lowercase.https.internal", "A", "1.2.3.4"),
+				newTestEndpoint("unknown.http.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				`Ignoring invalid external-dns.alpha.kubernetes.io/listener-protocol annotation "QUIC" of httproute/default/unknown, expected one of HTTP, HTTPS, TLS, TCP, UDP`,
+			},
+		},
+		{
+			title:      "WildcardApex",
+			config:     Config{GatewayWildcardApex: true},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "ip"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType, Hostname: hostnamePtr("*.ip.internal")}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "lb"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType, Hostname: hostnamePtr("*.lb.internal")}},
+					},
+					Status: v1.GatewayStatus{
+						Addresses: []v1.GatewayStatusAddress{gwAddress(v1.HostnameAddressType, "lb.example.internal")},
+					},
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "ip"),
+							gwParentRef("default", "lb"),
+						},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "ip"), gwParentRef("default", "lb")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("*.ip.internal", "A", "1.2.3.4"),
+				newTestEndpoint("ip.internal", "A", "1.2.3.4"),
+				newTestEndpoint("*.lb.internal", "CNAME", "lb.example.internal"),
+				newTestEndpoint("lb.internal", "CNAME", "lb.example.internal").
+					WithProviderSpecific("alias", "true"),
+			},
+		},
+		{
+			title:      "InternalHostname",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("203.0.113.1", "10.0.0.1", "fd00::1"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Namespace:   "default",
+					Annotations: map[string]string{annotations.InternalHostnameKey: "app.corp.internal"},
+				},
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("app.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("app.example.internal", "A", "203.0.113.1"),
+				newTestEndpoint("app.corp.internal", "A", "10.0.0.1"),
+				newTestEndpoint("app.corp.internal", "AAAA", "fd00::1"),
+			},
+		},
+		{
+			title:      "MaxRouteEndpoints",
+			config:     Config{GatewayMaxRouteEndpoints: 2},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "small"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("a.small.internal", "b.small.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "large"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("a.large.internal", "b.large.internal", "c.large.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("a.small.internal", "A", "1.2.3.4"),
+				newTestEndpoint("b.small.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"Skipping HTTPRoute default/large because its 3 endpoints exceed the maximum of 2",
+			},
+		},
+		{
+			title:      "PinnedGatewayAddresses",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("10.0.0.1", "10.0.0.2", "2001:db8::1"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "single",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.GatewayAddressKey: "10.0.0.2"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("single.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "multiple",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.GatewayAddressKey: "10.0.0.1, 2001:DB8:0::1"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("multiple.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "missing",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.GatewayAddressKey: "10.0.0.2,10.0.0.9"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("missing.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "stale",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.GatewayAddressKey: "10.0.0.9"},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("stale.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("single.example.internal", "A", "10.0.0.2"),
+				newTestEndpoint("multiple.example.internal", "A", "10.0.0.1"),
+				newTestEndpoint("multiple.example.internal", "AAAA", "2001:db8::1"),
+				newTestEndpoint("missing.example.internal", "A", "10.0.0.2"),
+				newTestEndpoint("stale.example.internal", "A", "10.0.0.1", "10.0.0.2"),
+				newTestEndpoint("stale.example.internal", "AAAA", "2001:db8::1"),
+			},
+			logExpectations: []string{
+				"Ignoring gateway address 10.0.0.9 of HTTPRoute default/missing, which none of its Gateways has",
+				"None of the gateway addresses 10.0.0.9 of HTTPRoute default/stale are addresses of its Gateways, publishing all of their addresses",
+			},
+		},
+		{
+			title:      "RequireBackends",
+			config:     Config{GatewayRequireBackends: true},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "with-backends"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("with-backends.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+						Rules: []v1.HTTPRouteRule{
+							{},
+							{BackendRefs: []v1.HTTPBackendRef{{BackendRef: backendRef("app")}}},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "without-backends"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("without-backends.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+						Rules: []v1.HTTPRouteRule{{}},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("with-backends.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:      "Comment",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "comment",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.CommentKey: " owned by team-a "},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("comment.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "provider-specific",
+						Namespace: "default",
+						Annotations: map[string]string{
+							annotations.CommentKey:                 "owned by team-a",
+							annotations.CloudflareRecordCommentKey: "owned by team-b",
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("provider-specific.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("comment.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific(annotations.CloudflareRecordCommentKey, "owned by team-a"),
+				newTestEndpoint("provider-specific.example.internal", "A", "1.2.3.4").
+					WithProviderSpecific(annotations.CloudflareRecordCommentKey, "owned by team-b"),
+			},
+			logExpectations: []string{
+				"Ignoring external-dns.alpha.kubernetes.io/comment annotation of httproute/default/provider-specific because it sets the provider-specific property external-dns.alpha.kubernetes.io/cloudflare-record-comment",
+			},
+		},
+		{
+			title:      "InvalidHostnames",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Namespace:   "default",
+					Annotations: map[string]string{hostnameAnnotationKey: "1.2.3.4"},
+				},
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: hostnames("valid.example.internal", "in_valid.example.internal"),
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("valid.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"Ignoring 2 invalid hostnames of HTTPRoute default/test: hostname \"in_valid.example.internal\" has the invalid label \"in_valid\"\nhostname \"1.2.3.4\" is an IP address",
+			},
+		},
+		{
+			title:      "FallbackTargets",
+			config:     Config{GatewayFallbackTargets: []string{"192.0.2.1", "maintenance.example.internal"}},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "pending"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+				},
+				{
+					ObjectMeta: objectMeta("default", "ready"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "pending"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("pending.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "pending")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "pending")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "both"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("both.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "pending"),
+								gwParentRef("default", "ready"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "pending"), gwParentRef("default", "ready")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("pending.example.internal", "A", "192.0.2.1").
+					WithLabel(endpoint.ResourceLabelKey, "httproute/default/pending").
+					WithLabel(endpoint.FallbackLabelKey, "true"),
+				newTestEndpoint("pending.example.internal", "CNAME", "maintenance.example.internal").
+					WithLabel(endpoint.ResourceLabelKey, "httproute/default/pending").
+					WithLabel(endpoint.FallbackLabelKey, "true"),
+				newTestEndpoint("both.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:      "ListenerHostnameAnnotation",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "annotated",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.ListenerHostnameKey: "*.example.internal"},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{
+							{Name: "default", Protocol: v1.HTTPProtocolType},
+							{Name: "explicit", Protocol: v1.HTTPProtocolType, Hostname: hostnamePtr("explicit.other.internal")},
+						},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "invalid",
+						Namespace:   "default",
+						Annotations: map[string]string{annotations.ListenerHostnameKey: "1.2.3.4"},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("5.6.7.8"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "default"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("app.example.internal", "app.other.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "annotated")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "annotated")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "explicit"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("explicit.other.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "annotated", withSectionName("explicit"))},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "annotated", withSectionName("explicit"))),
+				},
+				{
+					ObjectMeta: objectMeta("default", "no-overlap"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("app.elsewhere.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "annotated", withSectionName("default"))},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "annotated", withSectionName("default"))),
+				},
+				{
+					ObjectMeta: objectMeta("default", "invalid"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("app.elsewhere.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "invalid")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "invalid")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("app.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("explicit.other.internal", "A", "1.2.3.4"),
+				newTestEndpoint("app.elsewhere.internal", "A", "5.6.7.8"),
+			},
+			logExpectations: []string{
+				`Ignoring invalid listener hostname "1.2.3.4" of Gateway default/invalid`,
+			},
+		},
+		{
+			title: "ResolveBackends",
+			config: Config{
+				GatewayResolveBackends: true,
+			},
+			namespaces: namespaces("default", "granted", "not-granted"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "unprogrammed"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+				},
+				{
+					ObjectMeta: objectMeta("default", "programmed"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+			},
+			referenceGrants: []*v1beta1.ReferenceGrant{{
+				ObjectMeta: objectMeta("granted", "grant"),
+				Spec: v1beta1.ReferenceGrantSpec{
+					From: []v1beta1.ReferenceGrantFrom{{
+						Group:     gatewayGroup,
+						Kind:      "HTTPRoute",
+						Namespace: "default",
+					}},
+					To: []v1beta1.ReferenceGrantTo{{Kind: "Service"}},
+				},
+			}},
+			services: []*corev1.Service{
+				{
+					ObjectMeta: objectMeta("default", "lb"),
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.1"},
+					Status: corev1.ServiceStatus{
+						LoadBalancer: corev1.LoadBalancerStatus{
+							Ingress: []corev1.LoadBalancerIngress{{IP: "5.6.7.8"}},
+						},
+					},
+				},
+				{
+					ObjectMeta: objectMeta("granted", "api"),
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "10.0.0.2"},
+				},
+				{
+					ObjectMeta: objectMeta("not-granted", "api"),
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "10.0.0.3"},
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "unprogrammed"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("unprogrammed.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "unprogrammed"),
+							},
+						},
+						Rules: []v1.HTTPRouteRule{{
+							BackendRefs: []v1.HTTPBackendRef{
+								{BackendRef: backendRef("lb")},
+								{BackendRef: backendRef("missing")},
+								{BackendRef: namespacedBackendRef("granted", "api")},
+								{BackendRef: namespacedBackendRef("not-granted", "api")},
+							},
+						}},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "unprogrammed")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "programmed"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("programmed.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "programmed"),
+							},
+						},
+						Rules: []v1.HTTPRouteRule{{
+							BackendRefs: []v1.HTTPBackendRef{
+								{BackendRef: backendRef("lb")},
+							},
+						}},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "programmed")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("unprogrammed.example.internal", "A", "5.6.7.8", "10.0.0.2"),
+				newTestEndpoint("programmed.example.internal", "A", "1.2.3.4"),
+			},
+			logExpectations: []string{
+				"No ReferenceGrant allows HTTPRoute default/unprogrammed to reference Service not-granted/api",
+			},
+		},
+		{
+			title: "BackendZone",
+			config: Config{
+				GatewayResolveBackends: true,
+				GatewayBackendZone:     "zone-a",
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+			}},
+			services: []*corev1.Service{
+				{
+					ObjectMeta: objectMeta("default", "hinted"),
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "10.0.0.1"},
+				},
+				{
+					ObjectMeta: objectMeta("default", "unhinted"),
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "10.0.0.2"},
+				},
+			},
+			endpointSlices: []*discoveryv1.EndpointSlice{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "hinted-abcde",
+						Namespace: "default",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "hinted"},
+					},
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Endpoints: []discoveryv1.Endpoint{
+						zoneEndpoint("10.1.0.1", "zone-a", true),
+						zoneEndpoint("10.1.0.2", "zone-b", true),
+						zoneEndpoint("10.1.0.3", "zone-a", false),
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "unhinted-abcde",
+						Namespace: "default",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "unhinted"},
+					},
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Endpoints: []discoveryv1.Endpoint{
+						zoneEndpoint("10.1.1.1", "zone-a", true),
+						{Addresses: []string{"10.1.1.2"}},
+					},
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "hinted"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("hinted.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+						Rules: []v1.HTTPRouteRule{{
+							BackendRefs: []v1.HTTPBackendRef{{BackendRef: backendRef("hinted")}},
+						}},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+				{
+					ObjectMeta: objectMeta("default", "unhinted"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("unhinted.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+						Rules: []v1.HTTPRouteRule{{
+							BackendRefs: []v1.HTTPBackendRef{{BackendRef: backendRef("unhinted")}},
+						}},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("hinted.example.internal", "A", "10.1.0.1"),
+				newTestEndpoint("unhinted.example.internal", "A", "10.0.0.2"),
+			},
+			logExpectations: []string{
+				"Ignoring the topology hints of Service default/unhinted because EndpointSlice unhinted-abcde has endpoints without hints",
+			},
+		},
+		{
+			title: "EndpointsTypeAnnotation",
+			config: Config{
+				GatewayResolveBackends: true,
+				GatewayResolveNodes:    true,
+			},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "host-network"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+					Status: gatewayStatus("10.1.0.1", "10.1.0.2", "1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "unprogrammed"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+					},
+				},
+			},
+			services: []*corev1.Service{{
+				ObjectMeta: objectMeta("default", "node-port"),
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort, ClusterIP: "10.0.0.1"},
+			}},
+			nodes: []*corev1.Node{
+				{
+					ObjectMeta: objectMeta("", "node-1"),
+					Status: corev1.NodeStatus{
+						Addresses: []corev1.NodeAddress{
+							{Type: corev1.NodeInternalIP, Address: "10.1.0.1"},
+							{Type: corev1.NodeExternalIP, Address: "203.0.113.1"},
+							{Type: corev1.NodeExternalIP, Address: "2001:db8::1"},
+						},
+					},
+				},
+				{
+					ObjectMeta: objectMeta("", "node-2"),
+					Status: corev1.NodeStatus{
+						Addresses: []corev1.NodeAddress{
+							{Type: corev1.NodeInternalIP, Address: "10.1.0.2"},
+							{Type: corev1.NodeExternalIP, Address: "203.0.113.2"},
+						},
+					},
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("default", "default"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("default.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "host-network"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "host-network")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "external",
+						Namespace: "default",
+						Annotations: map[string]string{
+							endpointsTypeAnnotationKey: EndpointsTypeNodeExternalIP,
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("external.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "host-network"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "host-network")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "host",
+						Namespace: "default",
+						Annotations: map[string]string{
+							endpointsTypeAnnotationKey: EndpointsTypeHostIP,
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("host.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "host-network"),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "host-network")),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backend",
+						Namespace: "default",
+						Annotations: map[string]string{
+							endpointsTypeAnnotationKey: EndpointsTypeNodeExternalIP,
+						},
+					},
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("backend.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("default", "unprogrammed"),
+							},
+						},
+						Rules: []v1.HTTPRouteRule{{
+							BackendRefs: []v1.HTTPBackendRef{
+								{BackendRef: backendRef("node-port")},
+							},
+						}},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "unprogrammed")),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("default.example.internal", "A", "1.2.3.4", "10.1.0.1", "10.1.0.2"),
+				newTestEndpoint("external.example.internal", "A", "1.2.3.4", "203.0.113.1", "203.0.113.2"),
+				newTestEndpoint("external.example.internal", "AAAA", "2001:db8::1"),
+				newTestEndpoint("host.example.internal", "A", "1.2.3.4", "10.1.0.1", "10.1.0.2"),
+				newTestEndpoint("backend.example.internal", "A", "203.0.113.1", "203.0.113.2"),
+				newTestEndpoint("backend.example.internal", "AAAA", "2001:db8::1"),
+			},
+		},
+	}
 	for _, tt := range tests {
 		t.Run(tt.title, func(t *testing.T) {
 			if len(tt.logExpectations) == 0 {
@@ -1554,6 +6528,14 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 				require.NoError(t, err, "failed to create Gateway")
 
 			}
+			for _, rg := range tt.referenceGrants {
+				_, err := gwClient.GatewayV1beta1().ReferenceGrants(rg.Namespace).Create(ctx, rg, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create ReferenceGrant")
+			}
+			for _, gc := range tt.gatewayClasses {
+				_, err := gwClient.GatewayV1beta1().GatewayClasses().Create(ctx, gc, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create GatewayClass")
+			}
 			for _, rt := range tt.routes {
 				_, err := gwClient.GatewayV1beta1().HTTPRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
 				require.NoError(t, err, "failed to create HTTPRoute")
@@ -1563,12 +6545,32 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 				_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
 				require.NoError(t, err, "failed to create Namespace")
 			}
+			for _, secret := range tt.secrets {
+				_, err := kubeClient.CoreV1().Secrets(secret.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create Secret")
+			}
+			for _, svc := range tt.services {
+				_, err := kubeClient.CoreV1().Services(svc.Namespace).Create(ctx, svc, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create Service")
+			}
+			for _, es := range tt.endpointSlices {
+				_, err := kubeClient.DiscoveryV1().EndpointSlices(es.Namespace).Create(ctx, es, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create EndpointSlice")
+			}
+			for _, nd := range tt.nodes {
+				_, err := kubeClient.CoreV1().Nodes().Create(ctx, nd, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create Node")
+			}
+			for _, cm := range tt.configMaps {
+				_, err := kubeClient.CoreV1().ConfigMaps(cm.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create ConfigMap")
+			}
 
 			clients := new(MockClientGenerator)
 			clients.On("GatewayClient").Return(gwClient, nil)
 			clients.On("KubeClient").Return(kubeClient, nil)
 
-			src, err := NewGatewayHTTPRouteSource(clients, &tt.config)
+			src, err := NewGatewayHTTPRouteSource(ctx, clients, &tt.config)
 			require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
 
 			hook := testutils.LogsUnderTestWithLogLevel(log.DebugLevel, t)
@@ -1584,4 +6586,893 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 	}
 }
 
+func TestGatewayHTTPRouteHostnamesParityWithService(t *testing.T) {
+	t.Parallel()
+
+	annots := map[string]string{hostnameAnnotationKey: "annotation.example.internal"}
+	tests := []struct {
+		title       string
+		annotations map[string]string
+		config      Config
+	}{
+		{
+			title:       "AnnotationOnly",
+			annotations: annots,
+		},
+		{
+			title:       "AnnotationWithTemplate",
+			annotations: annots,
+			config:      Config{FQDNTemplate: "{{.Name}}.template.internal"},
+		},
+		{
+			title:  "TemplateWithoutAnnotation",
+			config: Config{FQDNTemplate: "{{.Name}}.template.internal"},
+		},
+		{
+			title:       "CombineAnnotationAndTemplate",
+			annotations: annots,
+			config:      Config{FQDNTemplate: "{{.Name}}.template.internal", CombineFQDNAndAnnotation: true},
+		},
+		{
+			title:       "IgnoreAnnotationWithTemplate",
+			annotations: annots,
+			config:      Config{FQDNTemplate: "{{.Name}}.template.internal", IgnoreHostnameAnnotation: true},
+		},
+		{
+			title:       "IgnoreAnnotationWithoutTemplate",
+			annotations: annots,
+			config:      Config{IgnoreHostnameAnnotation: true},
+		},
+		{
+			title:       "IgnoreAnnotationCombinedWithTemplate",
+			annotations: annots,
+			config: Config{
+				FQDNTemplate:             "{{.Name}}.template.internal",
+				CombineFQDNAndAnnotation: true,
+				IgnoreHostnameAnnotation: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			kubeClient := kubefake.NewSimpleClientset()
+			_, err := kubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, metav1.CreateOptions{})
+			require.NoError(t, err, "failed to create Namespace")
+			_, err = kubeClient.CoreV1().Services("default").Create(ctx, &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Annotations: tt.annotations},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "1.2.3.4"}}},
+				},
+			}, metav1.CreateOptions{})
+			require.NoError(t, err, "failed to create Service")
+
+			gwClient := gatewayfake.NewSimpleClientset()
+			_, err = gwClient.GatewayV1beta1().Gateways("default").Create(ctx, &v1beta1.Gateway{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}, metav1.CreateOptions{})
+			require.NoError(t, err, "failed to create Gateway")
+			_, err = gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, &v1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Annotations: tt.annotations},
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+					},
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}, metav1.CreateOptions{})
+			require.NoError(t, err, "failed to create HTTPRoute")
+
+			clients := new(MockClientGenerator)
+			clients.On("GatewayClient").Return(gwClient, nil)
+			clients.On("KubeClient").Return(kubeClient, nil)
+
+			rtSrc, err := NewGatewayHTTPRouteSource(ctx, clients, &tt.config)
+			require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+			svcSrc, err := NewServiceSource(ctx, kubeClient, "", "", tt.config.FQDNTemplate, tt.config.CombineFQDNAndAnnotation,
+				"", false, false, false, []string{}, tt.config.IgnoreHostnameAnnotation, labels.Everything(), false, false, false)
+			require.NoError(t, err, "failed to create Service Source")
+
+			rtEndpoints, err := rtSrc.Endpoints(ctx)
+			require.NoError(t, err, "failed to get HTTPRoute Endpoints")
+			svcEndpoints, err := svcSrc.Endpoints(ctx)
+			require.NoError(t, err, "failed to get Service Endpoints")
+			require.ElementsMatch(t, gatewayDNSNames(svcEndpoints), gatewayDNSNames(rtEndpoints))
+		})
+	}
+}
+
+func gatewayDNSNames(endpoints []*endpoint.Endpoint) []string {
+	names := make([]string, 0, len(endpoints))
+	for _, ep := range endpoints {
+		names = append(names, ep.DNSName)
+	}
+	return names
+}
+
+func TestGatewayHTTPRouteSourceContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gwClient := gatewayfake.NewSimpleClientset()
+	_, err := gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, &v1beta1.HTTPRoute{
+		ObjectMeta: objectMeta("default", "test"),
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create HTTPRoute")
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubefake.NewSimpleClientset(), nil)
+
+	srcCtx, cancelSrc := context.WithCancel(ctx)
+	defer cancelSrc()
+	src, err := NewGatewayHTTPRouteSource(srcCtx, clients, &Config{})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = src.Endpoints(canceled)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGatewayHTTPRouteSourceLogFields(t *testing.T) {
+	ctx := context.Background()
+	gwClient := gatewayfake.NewSimpleClientset()
+	_, err := gwClient.GatewayV1beta1().Gateways("default").Create(ctx, &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "default",
+			Annotations: map[string]string{annotations.TtlKey: "120"},
+		},
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{{
+				Name:     "foo",
+				Protocol: v1.HTTPProtocolType,
+				Hostname: hostnamePtr("a.example.internal"),
+			}},
+		},
+		Status: gatewayStatus("1.2.3.4"),
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Gateway")
+	for _, rt := range []struct {
+		name     string
+		hostname v1.Hostname
+		ref      v1.ParentReference
+	}{
+		{"log-fields-missing", "b.example.internal", gwParentRef("default", "missing")},
+		{"log-fields-test", "b.example.internal", gwParentRef("default", "test", withSectionName("foo"))},
+		{"log-fields-ttl", "a.example.internal", gwParentRef("default", "test")},
+	} {
+		ref := rt.ref
+		_, err := gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        rt.name,
+				Namespace:   "default",
+				Annotations: map[string]string{annotations.TtlKey: "60"},
+			},
+			Spec: v1.HTTPRouteSpec{
+				Hostnames: []v1.Hostname{rt.hostname},
+				CommonRouteSpec: v1.CommonRouteSpec{
+					ParentRefs: []v1.ParentReference{ref},
+				},
+			},
+			Status: httpRouteStatus(ref),
+		}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create HTTPRoute")
+	}
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubefake.NewSimpleClientset(), nil)
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+
+	hook := testutils.LogsUnderTestWithLogLevel(log.DebugLevel, t)
+	_, err = src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+
+	fields := func(msg string) log.Fields {
+		for _, entry := range hook.AllEntries() {
+			if strings.Contains(entry.Message, msg) {
+				return entry.Data
+			}
+		}
+		return nil
+	}
+	require.Equal(t, log.Fields{
+		"routeKind":        "HTTPRoute",
+		"routeNamespace":   "default",
+		"routeName":        "log-fields-missing",
+		"gatewayNamespace": "default",
+		"gatewayName":      "missing",
+	}, fields("Gateway default/missing not found for HTTPRoute default/log-fields-missing"))
+	require.Equal(t, log.Fields{
+		"routeKind":        "HTTPRoute",
+		"routeNamespace":   "default",
+		"routeName":        "log-fields-test",
+		"gatewayNamespace": "default",
+		"gatewayName":      "test",
+		"section":          v1.SectionName("foo"),
+	}, fields("does not match HTTPRoute default/log-fields-test"))
+	require.Equal(t, log.Fields{
+		"routeKind":        "HTTPRoute",
+		"routeNamespace":   "default",
+		"routeName":        "log-fields-ttl",
+		"gatewayNamespace": "default",
+		"gatewayName":      "test",
+		"host":             "a.example.internal",
+		"routeTTL":         int64(60),
+		"gatewayTTL":       int64(120),
+	}, fields("Conflicting TTLs of httproute/default/log-fields-ttl"))
+}
+
+func TestGatewayHTTPRouteSourceUnsuffixedHostLogLevel(t *testing.T) {
+	for _, tt := range []struct {
+		title string
+		warn  bool
+		level log.Level
+	}{
+		{title: "Debug", level: log.DebugLevel},
+		{title: "Warn", warn: true, level: log.WarnLevel},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			ctx := context.Background()
+			gwClient := gatewayfake.NewSimpleClientset()
+			_, err := gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, &v1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Namespace:   "default",
+					Annotations: map[string]string{targetAnnotationKey: "10.0.0.1"},
+				},
+				Spec: v1.HTTPRouteSpec{
+					Hostnames: []v1.Hostname{"a.example.org"},
+				},
+			}, metav1.CreateOptions{})
+			require.NoError(t, err, "failed to create HTTPRoute")
+
+			clients := new(MockClientGenerator)
+			clients.On("GatewayClient").Return(gwClient, nil)
+			clients.On("KubeClient").Return(kubefake.NewSimpleClientset(), nil)
+			src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{
+				GatewayHostnameSuffixes:    []string{"internal.example.org"},
+				GatewayParentlessRoutes:    true,
+				GatewayWarnUnsuffixedHosts: tt.warn,
+			})
+			require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+
+			hook := testutils.LogsUnderTestWithLogLevel(log.DebugLevel, t)
+			endpoints, err := src.Endpoints(ctx)
+			require.NoError(t, err, "failed to get Endpoints")
+			require.Empty(t, endpoints)
+
+			var levels []log.Level
+			for _, entry := range hook.AllEntries() {
+				if entry.Message == "Skipping host a.example.org of HTTPRoute default/test without an allowed hostname suffix" {
+					levels = append(levels, entry.Level)
+				}
+			}
+			require.Equal(t, []log.Level{tt.level}, levels)
+		})
+	}
+}
+
+func TestGatewayHTTPRouteSourceStrictHostnames(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		title            string
+		listenerHostname *v1.Hostname
+		hostnames        []v1.Hostname
+		annotation       string
+		hosts            []string
+		strictHosts      []string
+	}{
+		{
+			title:            "SpecListenerHostname",
+			listenerHostname: hostnamePtr("*.example.internal"),
+			hostnames:        []v1.Hostname{"spec.example.internal"},
+			hosts:            []string{"spec.example.internal"},
+			strictHosts:      []string{"spec.example.internal"},
+		},
+		{
+			title:       "SpecNoListenerHostname",
+			hostnames:   []v1.Hostname{"spec.example.internal"},
+			hosts:       []string{"spec.example.internal"},
+			strictHosts: []string{"spec.example.internal"},
+		},
+		{
+			title:            "AnnotationListenerHostname",
+			listenerHostname: hostnamePtr("*.example.internal"),
+			annotation:       "annotation.example.internal",
+			hosts:            []string{"*.example.internal", "annotation.example.internal"},
+			strictHosts:      []string{"annotation.example.internal"},
+		},
+		{
+			title:       "AnnotationNoListenerHostname",
+			annotation:  "annotation.example.internal",
+			hosts:       []string{"annotation.example.internal"},
+			strictHosts: []string{"annotation.example.internal"},
+		},
+		{
+			title:            "NoneListenerHostname",
+			listenerHostname: hostnamePtr("*.example.internal"),
+			hosts:            []string{"*.example.internal"},
+			strictHosts:      []string{"*.example.internal"},
+		},
+		{
+			title: "NoneNoListenerHostname",
+		},
+	} {
+		for _, strict := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/strict=%t", tt.title, strict), func(t *testing.T) {
+				t.Parallel()
+
+				ctx := context.Background()
+				gwClient := gatewayfake.NewSimpleClientset()
+				_, err := gwClient.GatewayV1beta1().Gateways("default").Create(ctx, &v1beta1.Gateway{
+					ObjectMeta: objectMeta("default", "test"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType, Hostname: tt.listenerHostname}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				}, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create Gateway")
+				rt := &v1beta1.HTTPRoute{
+					ObjectMeta: objectMeta("default", "test"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: tt.hostnames,
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("default", "test")),
+				}
+				if tt.annotation != "" {
+					rt.Annotations = map[string]string{hostnameAnnotationKey: tt.annotation}
+				}
+				_, err = gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, rt, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create HTTPRoute")
+
+				clients := new(MockClientGenerator)
+				clients.On("GatewayClient").Return(gwClient, nil)
+				clients.On("KubeClient").Return(kubefake.NewSimpleClientset(), nil)
+				src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{GatewayStrictHostnames: strict})
+				require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+				endpoints, err := src.Endpoints(ctx)
+				require.NoError(t, err, "failed to get Endpoints")
+
+				var hosts []string
+				for _, ep := range endpoints {
+					hosts = append(hosts, ep.DNSName)
+				}
+				want := tt.hosts
+				if strict {
+					want = tt.strictHosts
+				}
+				require.ElementsMatch(t, want, hosts)
+			})
+		}
+	}
+}
+
+func TestGatewayHTTPRouteSourceStableOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gwClient := gatewayfake.NewSimpleClientset()
+	kubeClient := kubefake.NewSimpleClientset()
+	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Namespace")
+	_, err = gwClient.GatewayV1beta1().Gateways("default").Create(ctx, &v1beta1.Gateway{
+		ObjectMeta: objectMeta("default", "test"),
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+		},
+		Status: gatewayStatus("2001:db8::1", "1.2.3.4", "5.6.7.8"),
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Gateway")
+	for i := range 20 {
+		name := fmt.Sprintf("route-%02d", 19-i)
+		_, err := gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, &v1beta1.HTTPRoute{
+			ObjectMeta: objectMeta("default", name),
+			Spec: v1.HTTPRouteSpec{
+				Hostnames: []v1.Hostname{
+					v1.Hostname(name + ".b.example.internal"),
+					v1.Hostname(name + ".a.example.internal"),
+					"shared.example.internal",
+				},
+				CommonRouteSpec: v1.CommonRouteSpec{
+					ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+				},
+			},
+			Status: httpRouteStatus(gwParentRef("default", "test")),
+		}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create HTTPRoute")
+	}
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubeClient, nil)
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+
+	first, err := src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	require.Len(t, first, 2*(2*20+1))
+	require.True(t, slices.IsSortedFunc(first, func(a, b *endpoint.Endpoint) int {
+		return cmp.Or(strings.Compare(a.DNSName, b.DNSName), strings.Compare(a.RecordType, b.RecordType))
+	}), "expected Endpoints to be sorted")
+	for range 10 {
+		endpoints, err := src.Endpoints(ctx)
+		require.NoError(t, err, "failed to get Endpoints")
+		require.Equal(t, first, endpoints, "expected Endpoints to be returned in the same order")
+	}
+
+	concurrent, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{GatewayResolveConcurrency: 8})
+	require.NoError(t, err, "failed to create concurrent Gateway HTTPRoute Source")
+	for range 10 {
+		endpoints, err := concurrent.Endpoints(ctx)
+		require.NoError(t, err, "failed to get Endpoints concurrently")
+		require.Equal(t, first, endpoints, "expected Endpoints resolved concurrently to match those resolved serially")
+	}
+}
+
+func TestGatewayHTTPRouteSourceFallbackTargets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gwClient := gatewayfake.NewSimpleClientset()
+	kubeClient := kubefake.NewSimpleClientset()
+	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Namespace")
+	gw, err := gwClient.GatewayV1beta1().Gateways("default").Create(ctx, &v1beta1.Gateway{
+		ObjectMeta: objectMeta("default", "test"),
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Gateway")
+	_, err = gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, &v1beta1.HTTPRoute{
+		ObjectMeta: objectMeta("default", "test"),
+		Spec: v1.HTTPRouteSpec{
+			Hostnames: []v1.Hostname{"test.example.internal"},
+			CommonRouteSpec: v1.CommonRouteSpec{
+				ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+			},
+		},
+		Status: httpRouteStatus(gwParentRef("default", "test")),
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create HTTPRoute")
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubeClient, nil)
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{GatewayFallbackTargets: []string{"192.0.2.1"}})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("test.example.internal", "A", "192.0.2.1").
+			WithLabel(endpoint.ResourceLabelKey, "httproute/default/test").
+			WithLabel(endpoint.FallbackLabelKey, "true"),
+	})
+
+	// Once the Gateway has addresses, they replace the fallback targets.
+	gw.Status = gatewayStatus("1.2.3.4")
+	_, err = gwClient.GatewayV1beta1().Gateways("default").UpdateStatus(ctx, gw, metav1.UpdateOptions{})
+	require.NoError(t, err, "failed to update Gateway status")
+	require.Eventually(t, func() bool {
+		endpoints, err = src.Endpoints(ctx)
+		return err == nil && len(endpoints) == 1 && endpoints[0].Targets[0] == "1.2.3.4"
+	}, 10*time.Second, 10*time.Millisecond, "expected the Gateway's address to replace the fallback target")
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("test.example.internal", "A", "1.2.3.4").
+			WithLabel(endpoint.ResourceLabelKey, "httproute/default/test"),
+	})
+}
+
+// gatewayFakeHostResolver resolves the hostnames of its map and counts the lookups of each hostname.
+type gatewayFakeHostResolver struct {
+	addrs   map[string][]string
+	mu      sync.Mutex
+	lookups map[string]int
+}
+
+func (r *gatewayFakeHostResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	r.mu.Lock()
+	r.lookups[host]++
+	r.mu.Unlock()
+	addrs, ok := r.addrs[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	var result []net.IPAddr
+	for _, addr := range addrs {
+		result = append(result, net.IPAddr{IP: net.ParseIP(addr)})
+	}
+	return result, nil
+}
+
+func TestGatewayHTTPRouteSourceResolveHostnameTargets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gwClient := gatewayfake.NewSimpleClientset()
+	for name, lb := range map[string]string{"resolvable": "lb.example.net", "unresolvable": "broken.example.net"} {
+		_, err := gwClient.GatewayV1beta1().Gateways("default").Create(ctx, &v1beta1.Gateway{
+			ObjectMeta: objectMeta("default", name),
+			Spec: v1.GatewaySpec{
+				Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+			},
+			Status: v1.GatewayStatus{Addresses: []v1.GatewayStatusAddress{gwAddress(v1.HostnameAddressType, lb)}},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create Gateway")
+	}
+	for name, gw := range map[string]string{"a": "resolvable", "b": "resolvable", "c": "unresolvable"} {
+		_, err := gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, &v1beta1.HTTPRoute{
+			ObjectMeta: objectMeta("default", name),
+			Spec: v1.HTTPRouteSpec{
+				Hostnames: []v1.Hostname{v1.Hostname(name + ".example.internal")},
+				CommonRouteSpec: v1.CommonRouteSpec{
+					ParentRefs: []v1.ParentReference{gwParentRef("default", gw)},
+				},
+			},
+			Status: httpRouteStatus(gwParentRef("default", gw)),
+		}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create HTTPRoute")
+	}
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubefake.NewSimpleClientset(), nil)
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{
+		GatewayResolveHostnameTargets: true,
+		GatewayResolveConcurrency:     4,
+	})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+	resolver := &gatewayFakeHostResolver{
+		addrs:   map[string][]string{"lb.example.net": {"2001:db8::1", "1.2.3.4"}},
+		lookups: make(map[string]int),
+	}
+	src.(*gatewayRouteSource).hostResolver = resolver
+
+	hook := testutils.LogsUnderTestWithLogLevel(log.DebugLevel, t)
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("a.example.internal", "A", "1.2.3.4"),
+		newTestEndpoint("a.example.internal", "AAAA", "2001:db8::1"),
+		newTestEndpoint("b.example.internal", "A", "1.2.3.4"),
+		newTestEndpoint("b.example.internal", "AAAA", "2001:db8::1"),
+		newTestEndpoint("c.example.internal", "CNAME", "broken.example.net"),
+	})
+	testutils.TestHelperLogContains("Failed to resolve hostname target broken.example.net, publishing it as a CNAME record", hook, t)
+	// Each hostname is only looked up once per call of Endpoints.
+	require.Equal(t, map[string]int{"lb.example.net": 1, "broken.example.net": 1}, resolver.lookups)
+}
+
+// gatewayBlockingHostResolver blocks the lookups of slow.example.net until they're released or
+// their context is done.
+type gatewayBlockingHostResolver struct {
+	release chan struct{}
+}
+
+func (r *gatewayBlockingHostResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	if host == "slow.example.net" {
+		select {
+		case <-r.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return []net.IPAddr{{IP: net.ParseIP("1.2.3.4")}}, nil
+}
+
+func TestGatewayRouteResolverHostAddresses(t *testing.T) {
+	t.Parallel()
+
+	hostResolver := &gatewayBlockingHostResolver{release: make(chan struct{})}
+	resolver := &gatewayRouteResolver{src: &gatewayRouteSource{hostResolver: hostResolver}}
+
+	slow := make(chan []string)
+	go func() {
+		slow <- resolver.hostAddresses(context.Background(), "slow.example.net")
+	}()
+	// The lookup of another hostname isn't blocked by the pending lookup.
+	require.Equal(t, []string{"1.2.3.4"}, resolver.hostAddresses(context.Background(), "fast.example.net"))
+	close(hostResolver.release)
+	require.Equal(t, []string{"1.2.3.4"}, <-slow)
+
+	// The lookup gives up when the context of the sync is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hostResolver.release = make(chan struct{})
+	resolver.hostAddrs = nil
+	require.Empty(t, resolver.hostAddresses(ctx, "slow.example.net"))
+}
+
+func TestGatewayHTTPRouteSourceOnDemandNamespaces(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gwClient := gatewayfake.NewSimpleClientset()
+	kubeClient := kubefake.NewSimpleClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: map[string]string{"team": "a"}},
+	})
+	listsNamespaces := func() bool {
+		return slices.ContainsFunc(kubeClient.Actions(), func(action k8stesting.Action) bool {
+			return action.Matches("list", "namespaces")
+		})
+	}
+	createGatewayAndRoute := func(name string, allow *v1.AllowedRoutes) {
+		_, err := gwClient.GatewayV1beta1().Gateways("default").Create(ctx, &v1beta1.Gateway{
+			ObjectMeta: objectMeta("default", name),
+			Spec: v1.GatewaySpec{
+				Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType, AllowedRoutes: allow}},
+			},
+			Status: gatewayStatus("1.2.3.4"),
+		}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create Gateway")
+		_, err = gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, &v1beta1.HTTPRoute{
+			ObjectMeta: objectMeta("default", name),
+			Spec: v1.HTTPRouteSpec{
+				Hostnames: []v1.Hostname{v1.Hostname(name + ".example.internal")},
+				CommonRouteSpec: v1.CommonRouteSpec{
+					ParentRefs: []v1.ParentReference{gwParentRef("default", name)},
+				},
+			},
+			Status: httpRouteStatus(gwParentRef("default", name)),
+		}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create HTTPRoute")
+	}
+	createGatewayAndRoute("same", nil)
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubeClient, nil)
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{GatewayOnDemandNamespaces: true})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("same.example.internal", "A", "1.2.3.4"),
+	})
+	require.False(t, listsNamespaces(), "expected Namespaces not to be listed without Listeners selecting them")
+
+	selector := v1.NamespacesFromSelector
+	createGatewayAndRoute("selector", &v1.AllowedRoutes{
+		Namespaces: &v1.RouteNamespaces{
+			From:     &selector,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+		},
+	})
+	require.Eventually(t, func() bool {
+		endpoints, err := src.Endpoints(ctx)
+		return err == nil && len(endpoints) == 2
+	}, 5*time.Second, 10*time.Millisecond, "expected the Route selected by namespace labels to be published")
+	require.True(t, listsNamespaces(), "expected Namespaces to be listed once a Listener selects them")
+}
+
+func TestGatewayRouteSkipsMetric(t *testing.T) {
+	// Not parallel, since the metric is shared with all other tests.
+	fromSame := v1.NamespacesFromSame
+	gateways := []*v1beta1.Gateway{
+		{
+			ObjectMeta: objectMeta("default", "http"),
+			Spec: v1.GatewaySpec{
+				Listeners: []v1.Listener{{
+					Hostname: hostnamePtr("*.example.internal"),
+					Protocol: v1.HTTPProtocolType,
+					AllowedRoutes: &v1.AllowedRoutes{
+						Namespaces: &v1.RouteNamespaces{From: &fromSame},
+					},
+				}},
+			},
+			Status: gatewayStatus("1.2.3.4"),
+		},
+		{
+			ObjectMeta: objectMeta("default", "tcp"),
+			Spec: v1.GatewaySpec{
+				Listeners: []v1.Listener{{Protocol: v1.TCPProtocolType}},
+			},
+			Status: gatewayStatus("2.3.4.5"),
+		},
+	}
+	route := func(namespace, name, gateway, hostname string) *v1beta1.HTTPRoute {
+		ref := gwParentRef("default", gateway)
+		return &v1beta1.HTTPRoute{
+			ObjectMeta: objectMeta(namespace, name),
+			Spec: v1.HTTPRouteSpec{
+				CommonRouteSpec: v1.CommonRouteSpec{ParentRefs: []v1.ParentReference{ref}},
+				Hostnames:       []v1.Hostname{v1.Hostname(hostname)},
+			},
+			Status: httpRouteStatus(ref),
+		}
+	}
+	noParentRef := route("default", "no-parent-ref", "http", "no-parent-ref.example.internal")
+	noParentRef.Spec.ParentRefs = nil
+	notAccepted := route("default", "not-accepted", "http", "not-accepted.example.internal")
+	notAccepted.Status.Parents[0].Conditions[0].Status = metav1.ConditionFalse
+	routes := []*v1beta1.HTTPRoute{
+		noParentRef,
+		notAccepted,
+		route("default", "gateway-not-found", "missing", "gateway-not-found.example.internal"),
+		route("default", "protocol-mismatch", "tcp", "protocol-mismatch.example.internal"),
+		route("other", "namespace-not-allowed", "http", "namespace-not-allowed.example.internal"),
+		route("default", "no-matching-host", "http", "no-matching-host.example.org"),
+		route("default", "published", "http", "published.example.internal"),
+	}
+
+	ctx := context.Background()
+	gwClient := gatewayfake.NewSimpleClientset()
+	for _, gw := range gateways {
+		_, err := gwClient.GatewayV1beta1().Gateways(gw.Namespace).Create(ctx, gw, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create Gateway")
+	}
+	for _, rt := range routes {
+		_, err := gwClient.GatewayV1beta1().HTTPRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create HTTPRoute")
+	}
+	kubeClient := kubefake.NewSimpleClientset()
+	for _, name := range []string{"default", "other"} {
+		_, err := kubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create Namespace")
+	}
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubeClient, nil)
+
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+
+	reasons := []string{
+		gwSkipNoParentRef,
+		gwSkipGatewayNotFound,
+		gwSkipNotAccepted,
+		gwSkipProtocolMismatch,
+		gwSkipNamespaceNotAllowed,
+		gwSkipNoMatchingHost,
+	}
+	before := make(map[string]float64, len(reasons))
+	for _, reason := range reasons {
+		before[reason] = gatewayRouteSkips(t, "HTTPRoute", reason)
+	}
+
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("published.example.internal", "A", "1.2.3.4"),
+	})
+
+	for _, reason := range reasons {
+		require.Equal(t, before[reason]+1, gatewayRouteSkips(t, "HTTPRoute", reason), "unexpected skips for reason %q", reason)
+	}
+}
+
+func gatewayRouteSkips(t *testing.T, kind, reason string) float64 {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, gatewayRouteSkipsTotal.CounterVec.WithLabelValues(kind, reason).Write(&m))
+	return m.GetCounter().GetValue()
+}
+
+func BenchmarkGatewayHTTPRouteSourceEndpoints(b *testing.B) {
+	const numGateways, numRoutes = 100, 5000
+
+	ctx := context.Background()
+	var gateways []*v1beta1.Gateway
+	var gwObjects, kubeObjects []runtime.Object
+	kubeObjects = append(kubeObjects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+	for i := range numGateways {
+		name := fmt.Sprintf("gw-%d", i)
+		secret := tlsSecret(b, "default", name, fmt.Sprintf("*.%s.example.internal", name))
+		secret.ResourceVersion = "1"
+		kubeObjects = append(kubeObjects, secret)
+		gateways = append(gateways, &v1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, ResourceVersion: "1"},
+			Spec: v1.GatewaySpec{
+				Listeners: []v1.Listener{{
+					Name:     "https",
+					Protocol: v1.HTTPSProtocolType,
+					TLS: &v1.GatewayTLSConfig{
+						CertificateRefs: []v1.SecretObjectReference{{Name: v1.ObjectName(name)}},
+					},
+				}},
+			},
+			Status: gatewayStatus(fmt.Sprintf("10.0.%d.%d", i/256, i%256)),
+		})
+	}
+	for i := range numRoutes {
+		ref := gwParentRef("default", fmt.Sprintf("gw-%d", i%numGateways))
+		gwObjects = append(gwObjects, &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("rt-%d", i), ResourceVersion: "1"},
+			Spec: v1.HTTPRouteSpec{
+				CommonRouteSpec: v1.CommonRouteSpec{ParentRefs: []v1.ParentReference{ref}},
+				Hostnames:       []v1.Hostname{v1.Hostname(fmt.Sprintf("rt-%d.gw-%d.example.internal", i, i%numGateways))},
+			},
+			Status: httpRouteStatus(ref),
+		})
+	}
+
+	gwClient := gatewayfake.NewSimpleClientset(gwObjects...)
+	// Gateways are created through the client, since the fake object tracker files the v1beta1
+	// Gateway, an alias of the v1 Gateway, under the v1 resource.
+	for _, gw := range gateways {
+		_, err := gwClient.GatewayV1beta1().Gateways(gw.Namespace).Create(ctx, gw, metav1.CreateOptions{})
+		require.NoError(b, err, "failed to create Gateway")
+	}
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubefake.NewSimpleClientset(kubeObjects...), nil)
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{GatewayCertificateHostnames: true})
+	require.NoError(b, err, "failed to create Gateway HTTPRoute Source")
+	gwCache := src.(*gatewayRouteSource).gwCache
+
+	for _, bb := range []struct {
+		name        string
+		gwCache     *gatewayListenersCache
+		concurrency int
+	}{
+		{name: "Uncached"},
+		{name: "Cached", gwCache: gwCache},
+		{name: "Concurrency4", gwCache: gwCache, concurrency: 4},
+		{name: "Concurrency16", gwCache: gwCache, concurrency: 16},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			src.(*gatewayRouteSource).gwCache = bb.gwCache
+			src.(*gatewayRouteSource).resolveConcurrency = bb.concurrency
+			for b.Loop() {
+				endpoints, err := src.Endpoints(ctx)
+				require.NoError(b, err, "failed to get Endpoints")
+				require.Len(b, endpoints, numRoutes)
+			}
+		})
+	}
+}
+
 func hostnamePtr(val v1.Hostname) *v1.Hostname { return &val }
+
+func preciseHostnamePtr(val v1.PreciseHostname) *v1.PreciseHostname { return &val }
+
+func objectMeta(namespace, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+	}
+}
+
+func namespacePtr(val v1.Namespace) *v1.Namespace { return &val }
+
+func objectNamePtr(val v1.ObjectName) *v1.ObjectName { return &val }
+
+// tlsSecret returns a TLS Secret holding a self-signed certificate for the given DNS names.
+func tlsSecret(t testing.TB, namespace, name string, dnsNames ...string) *corev1.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "failed to generate key")
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err, "failed to create certificate")
+	return &corev1.Secret{
+		ObjectMeta: objectMeta(namespace, name),
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		},
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+	gwinformers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
+)
+
+// gatewayFactoryKey identifies a shared informer factory by its client and the namespace, labels,
+// and fields its informers are limited to.
+type gatewayFactoryKey struct {
+	client    any
+	namespace string
+	labels    string
+	fields    string
+}
+
+// gatewaySharedFactory is an informer factory along with the number of sources using it and the
+// channel that stops its informers once none does anymore.
+type gatewaySharedFactory struct {
+	factory any
+	refs    int
+	stopCh  chan struct{}
+}
+
+// gatewayInformerFactories shares informer factories among the Gateway sources of the process, so
+// that running several of them, e.g. for HTTPRoutes and GRPCRoutes, doesn't multiply the watches
+// and caches of the Gateways, Namespaces, and other resources they all need. A factory only starts
+// the informers that haven't been started yet, so each source registers the informers it needs and
+// starts the factory with the channel returned by acquire. It is safe for concurrent use.
+type gatewayInformerFactories struct {
+	mu        sync.Mutex
+	factories map[gatewayFactoryKey]*gatewaySharedFactory
+}
+
+var gatewayFactories = &gatewayInformerFactories{factories: make(map[gatewayFactoryKey]*gatewaySharedFactory)}
+
+// acquire returns the factory of the key, creating it with newFactory if no source uses it yet,
+// along with the channel to start its informers with. The factory is released once the context is
+// done, and its informers are stopped once no source uses it anymore.
+func (r *gatewayInformerFactories) acquire(ctx context.Context, key gatewayFactoryKey, newFactory func() any) (any, <-chan struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shared, ok := r.factories[key]
+	if !ok {
+		shared = &gatewaySharedFactory{factory: newFactory(), stopCh: make(chan struct{})}
+		r.factories[key] = shared
+	}
+	shared.refs++
+	go func() {
+		<-ctx.Done()
+		r.release(key, shared)
+	}()
+	return shared.factory, shared.stopCh
+}
+
+func (r *gatewayInformerFactories) release(key gatewayFactoryKey, shared *gatewaySharedFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shared.refs--
+	if shared.refs > 0 {
+		return
+	}
+	close(shared.stopCh)
+	if r.factories[key] == shared {
+		delete(r.factories, key)
+	}
+}
+
+// sharedGatewayInformerFactory returns the shared factory of Gateway API informers of the client,
+// limited to the namespace, labels, and fields, and the channel to start its informers with.
+func sharedGatewayInformerFactory(ctx context.Context, client gateway.Interface, namespace string, labelSelector labels.Selector, fieldSelector fields.Selector) (gwinformers.SharedInformerFactory, <-chan struct{}) {
+	key := gatewayFactoryKey{client: client, namespace: namespace}
+	if labelSelector != nil && !labelSelector.Empty() {
+		key.labels = labelSelector.String()
+	}
+	if fieldSelector != nil && !fieldSelector.Empty() {
+		key.fields = fieldSelector.String()
+	}
+	factory, stopCh := gatewayFactories.acquire(ctx, key, func() any {
+		return newGatewayInformerFactory(client, namespace, labelSelector, fieldSelector)
+	})
+	return factory.(gwinformers.SharedInformerFactory), stopCh
+}
+
+// sharedKubeInformerFactory returns the shared factory of Kubernetes informers of the client and
+// the channel to start its informers with.
+func sharedKubeInformerFactory(ctx context.Context, client kubernetes.Interface) (kubeinformers.SharedInformerFactory, <-chan struct{}) {
+	factory, stopCh := gatewayFactories.acquire(ctx, gatewayFactoryKey{client: client}, func() any {
+		return kubeinformers.NewSharedInformerFactory(client, 0)
+	})
+	return factory.(kubeinformers.SharedInformerFactory), stopCh
+}
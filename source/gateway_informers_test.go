@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	kubetesting "k8s.io/client-go/testing"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+)
+
+func TestGatewaySourcesShareInformers(t *testing.T) {
+	t.Parallel()
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gatewayfake.NewSimpleClientset(), nil)
+	clients.On("KubeClient").Return(kubefake.NewSimpleClientset(), nil)
+
+	httpCtx, httpCancel := context.WithCancel(context.Background())
+	defer httpCancel()
+	httpSrc, err := NewGatewayHTTPRouteSource(httpCtx, clients, &Config{})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+	grpcCtx, grpcCancel := context.WithCancel(context.Background())
+	defer grpcCancel()
+	grpcSrc, err := NewGatewayGRPCRouteSource(grpcCtx, clients, &Config{})
+	require.NoError(t, err, "failed to create Gateway GRPCRoute Source")
+
+	httpRouteSrc, grpcRouteSrc := httpSrc.(*gatewayRouteSource), grpcSrc.(*gatewayRouteSource)
+	gwInformer := httpRouteSrc.gwInformer.Informer()
+	nsInformer := httpRouteSrc.nsInformer.Informer()
+	require.Same(t, gwInformer, grpcRouteSrc.gwInformer.Informer(), "expected the Gateway informer to be shared")
+	require.Same(t, nsInformer, grpcRouteSrc.nsInformer.Informer(), "expected the Namespace informer to be shared")
+	require.NotSame(t, httpRouteSrc.rtInformer.Informer(), grpcRouteSrc.rtInformer.Informer(), "expected each kind of Route to have its own informer")
+
+	// The informers keep running as long as any source uses them.
+	httpCancel()
+	time.Sleep(100 * time.Millisecond)
+	require.False(t, gwInformer.IsStopped(), "expected the Gateway informer to keep running")
+	require.False(t, nsInformer.IsStopped(), "expected the Namespace informer to keep running")
+	_, err = grpcSrc.Endpoints(grpcCtx)
+	require.NoError(t, err, "failed to get Endpoints")
+
+	grpcCancel()
+	require.Eventually(t, func() bool {
+		return gwInformer.IsStopped() && nsInformer.IsStopped()
+	}, 10*time.Second, 10*time.Millisecond, "expected the informers to stop once no source uses them")
+}
+
+func TestSharedGatewayInformerFactory(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := gatewayfake.NewSimpleClientset()
+	lbls := labels.SelectorFromSet(labels.Set{"app": "web"})
+
+	factory, stopCh := sharedGatewayInformerFactory(ctx, client, "default", lbls, nil)
+	same, _ := sharedGatewayInformerFactory(ctx, client, "default", labels.SelectorFromSet(labels.Set{"app": "web"}), nil)
+	require.Same(t, factory, same, "expected the factory to be shared")
+	other, _ := sharedGatewayInformerFactory(ctx, client, "other", lbls, nil)
+	require.NotSame(t, factory, other, "expected a factory per namespace")
+	other, _ = sharedGatewayInformerFactory(ctx, client, "default", nil, nil)
+	require.NotSame(t, factory, other, "expected a factory per label selector")
+	other, _ = sharedGatewayInformerFactory(ctx, client, "default", lbls, fields.OneTermNotEqualSelector("metadata.name", "test"))
+	require.NotSame(t, factory, other, "expected a factory per field selector")
+	other, _ = sharedGatewayInformerFactory(ctx, gatewayfake.NewSimpleClientset(), "default", lbls, nil)
+	require.NotSame(t, factory, other, "expected a factory per client")
+
+	cancel()
+	select {
+	case <-stopCh:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the factory to be stopped once released")
+	}
+	released, _ := sharedGatewayInformerFactory(context.Background(), client, "default", lbls, nil)
+	require.NotSame(t, factory, released, "expected a new factory after the previous one was released")
+}
+
+func TestGatewayRouteFieldSelector(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gwClient := gatewayfake.NewSimpleClientset()
+	listed := make(chan string, 10)
+	gwClient.PrependReactor("list", "httproutes", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		listed <- action.(kubetesting.ListAction).GetListRestrictions().Fields.String()
+		return false, nil, nil
+	})
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubefake.NewSimpleClientset(), nil)
+
+	_, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{GatewayRouteFieldSelector: "metadata.name!=canary"})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+	select {
+	case fieldSelector := <-listed:
+		require.Equal(t, "metadata.name!=canary", fieldSelector, "expected the field selector to be applied to the list options")
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected HTTPRoutes to be listed")
+	}
+
+	for _, tt := range []struct {
+		selector    string
+		expectedErr string
+	}{
+		{selector: "metadata.namespace=team-a,metadata.name!=canary"},
+		{selector: "spec.hostnames=example.com", expectedErr: `field "spec.hostnames" is not supported`},
+		{selector: "metadata.name", expectedErr: `invalid field selector "metadata.name" of HTTPRoutes`},
+	} {
+		_, err := gatewayRouteFieldSelector(tt.selector, "HTTPRoute")
+		if tt.expectedErr == "" {
+			require.NoError(t, err, "expected field selector %q to be valid", tt.selector)
+		} else {
+			require.ErrorContains(t, err, tt.expectedErr)
+		}
+	}
+}
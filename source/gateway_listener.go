@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+	informers_v1beta1 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1beta1"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// gatewayListenerSource publishes the hostnames of Gateway Listeners, regardless of
+// whether any Routes are attached to them.
+type gatewayListenerSource struct {
+	gwName        string
+	gwNamespace   string
+	gwLabels      labels.Selector
+	gwClasses     []string
+	gwAnnotations labels.Selector
+	gwInformer    informers_v1beta1.GatewayInformer
+
+	controllerValue      string
+	requireProgrammed    bool
+	preferredAddressType v1.AddressType
+	targetCIDRs          []netip.Prefix
+	hostSuffixes         []string
+	multiLabelWildcards  bool
+}
+
+// NewGatewaySource creates a new Gateway source with the given config.
+func NewGatewaySource(ctx context.Context, clients ClientGenerator, config *Config) (Source, error) {
+	if err := validateGatewayName(config.GatewayName); err != nil {
+		return nil, err
+	}
+	gwLabels, err := getLabelSelector(config.GatewayLabelFilter)
+	if err != nil {
+		return nil, err
+	}
+	gwAnnotations, err := getAnnotationSelector(config.AnnotationFilter)
+	if err != nil {
+		return nil, err
+	}
+	targetCIDRs, err := gatewayTargetCIDRs(config.GatewayTargetCIDRAllowlist)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := clients.GatewayClient()
+	if err != nil {
+		return nil, err
+	}
+
+	informerFactory, stopCh := sharedGatewayInformerFactory(ctx, client, config.GatewayNamespace, gwLabels, nil)
+	gwInformer := informerFactory.Gateway().V1beta1().Gateways()
+	gwInformer.Informer() // Register with factory before starting.
+
+	informerFactory.Start(stopCh)
+	if err := informers.WaitForCacheSync(ctx, informerFactory); err != nil {
+		return nil, err
+	}
+
+	return &gatewayListenerSource{
+		gwName:        config.GatewayName,
+		gwNamespace:   config.GatewayNamespace,
+		gwLabels:      gwLabels,
+		gwClasses:     gatewayClassFilter(config.GatewayClassFilter),
+		gwAnnotations: gwAnnotations,
+		gwInformer:    gwInformer,
+
+		controllerValue:      gatewayControllerValue(config.GatewayControllerValue),
+		requireProgrammed:    config.GatewayRequireProgrammed,
+		preferredAddressType: v1.AddressType(config.GatewayPreferredAddressType),
+		targetCIDRs:          targetCIDRs,
+		hostSuffixes:         gatewayHostSuffixes(config.GatewayHostnameSuffixes),
+		multiLabelWildcards:  config.GatewayMultiLabelWildcards,
+	}, nil
+}
+
+func (src *gatewayListenerSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debug("Adding event handlers for Gateway")
+	src.gwInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+}
+
+func (src *gatewayListenerSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	gateways, err := src.gwInformer.Lister().Gateways(src.gwNamespace).List(src.gwLabels)
+	if err != nil {
+		return nil, err
+	}
+	var endpoints []*endpoint.Endpoint
+	for _, gw := range gateways {
+		// Stop early if the caller is no longer interested in the result.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// Filter by annotations.
+		annots := gw.Annotations
+		if !src.gwAnnotations.Matches(labels.Set(annots)) {
+			continue
+		}
+
+		// Check controller annotation to see if we are responsible.
+		if v, ok := annots[controllerAnnotationKey]; ok && v != src.controllerValue {
+			log.Debugf("Skipping Gateway %s/%s because controller value does not match, found: %s, required: %s",
+				gw.Namespace, gw.Name, v, src.controllerValue)
+			continue
+		}
+
+		if !gatewayNameMatches(src.gwName, gw.Name) {
+			continue
+		}
+		if len(src.gwClasses) > 0 && !slices.Contains(src.gwClasses, string(gw.Spec.GatewayClassName)) {
+			continue
+		}
+		if src.requireProgrammed && !gwIsProgrammed(gw.Status.Conditions) {
+			log.Debugf("Skipping Gateway %s/%s because it has not been programmed", gw.Namespace, gw.Name)
+			continue
+		}
+
+		// Create endpoints from Listener hostnames and targets.
+		var gwEndpoints []*endpoint.Endpoint
+		resource := fmt.Sprintf("gateway/%s/%s", gw.Namespace, gw.Name)
+		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(annots)
+		ttl := annotations.TTLFromAnnotations(annots, resource)
+		listenerTTLs := annotations.ListenerTTLsFromAnnotations(annots, resource)
+		listenerAddrs := annotations.ListenerAddressesFromAnnotations(annots, resource)
+		excludedHosts := annotations.ExcludeHostnamesFromAnnotations(annots)
+		forcedType := annotations.RecordTypeFromAnnotations(annots, resource)
+		for i := range gw.Spec.Listeners {
+			lis := &gw.Spec.Listeners[i]
+			if lis.Hostname == nil || *lis.Hostname == "" {
+				continue
+			}
+			host, ok := gwHost(string(*lis.Hostname))
+			if !ok {
+				log.Debugf("Skipping invalid hostname %q of Gateway %s/%s section %q", *lis.Hostname, gw.Namespace, gw.Name, lis.Name)
+				continue
+			}
+			if !gwHostHasSuffix(host, src.hostSuffixes) {
+				log.Debugf("Skipping hostname %s of Gateway %s/%s section %q without an allowed hostname suffix", host, gw.Namespace, gw.Name, lis.Name)
+				continue
+			}
+			if gwHostExcluded(host, excludedHosts, src.multiLabelWildcards) {
+				log.Debugf("Skipping excluded hostname %s of Gateway %s/%s section %q", host, gw.Namespace, gw.Name, lis.Name)
+				continue
+			}
+			lisTTL, ok := listenerTTLs[string(lis.Name)]
+			if !ok {
+				lisTTL = ttl
+			}
+			for recordType, tgts := range gwAllowedTargets(gw, gwListenerTargets(gw, lis, listenerAddrs[string(lis.Name)], "", src.preferredAddressType, nil), src.targetCIDRs).withRecordType(forcedType) {
+				if ep := endpointForHostnameAndType(host, recordType, uniqueTargets(tgts), lisTTL, providerSpecific, setIdentifier, resource); ep != nil {
+					gwEndpoints = append(gwEndpoints, ep)
+				}
+			}
+		}
+		log.Debugf("Endpoints generated from Gateway %s/%s: %v", gw.Namespace, gw.Name, gwEndpoints)
+
+		endpoints = append(endpoints, gwEndpoints...)
+	}
+	// Listeners of the same Gateway may share hostnames.
+	return gwMergeEndpoints(endpoints), nil
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+	v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+func TestGatewaySourceEndpoints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		title     string
+		config    Config
+		gateways  []*v1beta1.Gateway
+		endpoints []*endpoint.Endpoint
+	}{
+		{
+			title: "ListenerHostnames",
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: objectMeta("default", "test"),
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{
+							Name:     "http",
+							Hostname: hostnamePtr("test.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						},
+						{
+							Name:     "https",
+							Hostname: hostnamePtr("test.example.internal"),
+							Protocol: v1.HTTPSProtocolType,
+						},
+						{
+							Name:     "wildcard",
+							Hostname: hostnamePtr("*.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						},
+						{
+							Name:     "any",
+							Protocol: v1.HTTPProtocolType,
+						},
+					},
+				},
+				Status: gatewayStatus("1.2.3.4", "2001:db8::1"),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("test.example.internal", "AAAA", "2001:db8::1"),
+				newTestEndpoint("*.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("*.example.internal", "AAAA", "2001:db8::1"),
+			},
+		},
+		{
+			title: "TargetAnnotations",
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+					Annotations: map[string]string{
+						annotations.TargetKey:               "lb.example.internal",
+						annotations.TargetKey + ".internal": "10.0.0.1",
+						ttlAnnotationKey:                    "300",
+					},
+				},
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{
+							Name:     "internal",
+							Hostname: hostnamePtr("internal.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						},
+						{
+							Name:     "external",
+							Hostname: hostnamePtr("external.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						},
+					},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpointWithTTL("internal.example.internal", "A", 300, "10.0.0.1"),
+				newTestEndpointWithTTL("external.example.internal", "CNAME", 300, "lb.example.internal"),
+			},
+		},
+		{
+			title: "ExcludeHostnames",
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+					Annotations: map[string]string{
+						annotations.ExcludeHostnamesKey: "secret.example.internal,*.admin.example.internal",
+					},
+				},
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{
+						{
+							Name:     "public",
+							Hostname: hostnamePtr("public.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						},
+						{
+							Name:     "secret",
+							Hostname: hostnamePtr("secret.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						},
+						{
+							Name:     "admin",
+							Hostname: hostnamePtr("ops.admin.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						},
+					},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("public.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title: "GatewayFilters",
+			config: Config{
+				GatewayName:        "test",
+				GatewayClassFilter: "public",
+			},
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "test"),
+					Spec: v1.GatewaySpec{
+						GatewayClassName: "public",
+						Listeners: []v1.Listener{{
+							Hostname: hostnamePtr("test.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: objectMeta("default", "other-name"),
+					Spec: v1.GatewaySpec{
+						GatewayClassName: "public",
+						Listeners: []v1.Listener{{
+							Hostname: hostnamePtr("other-name.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+				{
+					ObjectMeta: objectMeta("other", "test"),
+					Spec: v1.GatewaySpec{
+						GatewayClassName: "internal",
+						Listeners: []v1.Listener{{
+							Hostname: hostnamePtr("other-class.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						}},
+					},
+					Status: gatewayStatus("3.4.5.6"),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("test.example.internal", "A", "1.2.3.4"),
+			},
+		},
+		{
+			title:  "ControllerValue",
+			config: Config{GatewayControllerValue: "public"},
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: objectMeta("default", "unannotated"),
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{
+							Hostname: hostnamePtr("unannotated.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						}},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "public",
+						Namespace:   "default",
+						Annotations: map[string]string{controllerAnnotationKey: "public"},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{
+							Hostname: hostnamePtr("public.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						}},
+					},
+					Status: gatewayStatus("2.3.4.5"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "default-controller",
+						Namespace:   "default",
+						Annotations: map[string]string{controllerAnnotationKey: controllerAnnotationValue},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{{
+							Hostname: hostnamePtr("default-controller.example.internal"),
+							Protocol: v1.HTTPProtocolType,
+						}},
+					},
+					Status: gatewayStatus("3.4.5.6"),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("unannotated.example.internal", "A", "1.2.3.4"),
+				newTestEndpoint("public.example.internal", "A", "2.3.4.5"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			gwClient := gatewayfake.NewSimpleClientset()
+			for _, gw := range tt.gateways {
+				_, err := gwClient.GatewayV1beta1().Gateways(gw.Namespace).Create(ctx, gw, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create Gateway")
+			}
+
+			clients := new(MockClientGenerator)
+			clients.On("GatewayClient").Return(gwClient, nil)
+
+			src, err := NewGatewaySource(ctx, clients, &tt.config)
+			require.NoError(t, err, "failed to create Gateway Source")
+
+			endpoints, err := src.Endpoints(ctx)
+			require.NoError(t, err, "failed to get Endpoints")
+			validateEndpoints(t, endpoints, tt.endpoints)
+		})
+	}
+}
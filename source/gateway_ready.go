@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	errGatewayNotSynced   = errors.New("informers have not synced")
+	errGatewayNoEndpoints = errors.New("endpoints have not been generated yet")
+)
+
+// GatewayRouteReadiness is implemented by the Gateway API Route sources. It reports whether the
+// source is ready to serve endpoints, e.g. to back a readiness probe.
+type GatewayRouteReadiness interface {
+	// Ready returns nil once the informers of the source have synced and its last call of
+	// Endpoints succeeded, or an error describing why the source isn't ready.
+	Ready() error
+}
+
+// gatewayReadiness records the outcome of the last call of Endpoints. It is safe for concurrent use.
+type gatewayReadiness struct {
+	mu   sync.RWMutex
+	done bool
+	err  error
+}
+
+func (r *gatewayReadiness) observe(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.done = true
+	r.err = err
+}
+
+func (r *gatewayReadiness) ready() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if !r.done {
+		return errGatewayNoEndpoints
+	}
+	if r.err != nil {
+		return fmt.Errorf("last endpoints failed: %w", r.err)
+	}
+	return nil
+}
+
+func (src *gatewayRouteSource) Ready() error {
+	if err := src.synced(); err != nil {
+		return err
+	}
+	return src.readiness.ready()
+}
+
+// synced returns errGatewayNotSynced unless all informers of the source have synced.
+func (src *gatewayRouteSource) synced() error {
+	informers := []cache.SharedIndexInformer{
+		src.gwInformer.Informer(),
+		src.rtInformer.Informer(),
+	}
+	src.nsMu.Lock()
+	if src.nsInformer != nil {
+		informers = append(informers, src.nsInformer.Informer())
+	}
+	src.nsMu.Unlock()
+	if src.rgInformer != nil {
+		informers = append(informers, src.rgInformer.Informer())
+	}
+	if src.gcInformer != nil {
+		informers = append(informers, src.gcInformer.Informer())
+	}
+	if src.secInformer != nil {
+		informers = append(informers, src.secInformer.Informer())
+	}
+	if src.svcInformer != nil {
+		informers = append(informers, src.svcInformer.Informer())
+	}
+	if src.ndInformer != nil {
+		informers = append(informers, src.ndInformer.Informer())
+	}
+	for _, inf := range informers {
+		if !inf.HasSynced() {
+			return errGatewayNotSynced
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+)
+
+var _ GatewayRouteReadiness = &gatewayRouteSource{}
+
+func TestGatewayHTTPRouteSourceReady(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gatewayfake.NewSimpleClientset(), nil)
+	clients.On("KubeClient").Return(kubefake.NewSimpleClientset(), nil)
+
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+	readiness := src.(GatewayRouteReadiness)
+
+	require.ErrorIs(t, readiness.Ready(), errGatewayNoEndpoints, "expected source not to be ready before generating endpoints")
+
+	_, err = src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	require.NoError(t, readiness.Ready(), "expected source to be ready after generating endpoints")
+
+	errFailed := errors.New("failed")
+	src.(*gatewayRouteSource).readiness.observe(errFailed)
+	require.ErrorIs(t, readiness.Ready(), errFailed, "expected source not to be ready after failing to generate endpoints")
+}
+
+func TestGatewayHTTPRouteSourceListBackoff(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kubeClient := kubefake.NewSimpleClientset()
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gatewayfake.NewSimpleClientset(), nil)
+	clients.On("KubeClient").Return(kubeClient, nil)
+
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{
+		GatewayListBackoff: time.Millisecond,
+		GatewayListRetries: 2,
+	})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+	// Swap in an informer of a factory that is never started, so that it never syncs.
+	gwSrc := src.(*gatewayRouteSource)
+	gwSrc.ndInformer = kubeinformers.NewSharedInformerFactory(kubeClient, 0).Core().V1().Nodes()
+	gwSrc.ndInformer.Informer()
+
+	before := gatewayListErrors(t, "HTTPRoute", gwListErrorTransient)
+	_, err = src.Endpoints(ctx)
+	require.ErrorIs(t, err, errGatewayNotSynced, "expected Endpoints to fail while informers haven't synced")
+	require.Equal(t, before+3, gatewayListErrors(t, "HTTPRoute", gwListErrorTransient), "expected the initial attempt and each retry to be counted")
+}
+
+func gatewayListErrors(t *testing.T, kind, errType string) float64 {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, gatewayListErrorsTotal.CounterVec.WithLabelValues(kind, errType).Write(&m))
+	return m.GetCounter().GetValue()
+}
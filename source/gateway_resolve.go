@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// GatewayHostTargets holds the targets of the hostnames of Gateway API Routes by hostname and record type.
+type GatewayHostTargets map[string]map[string]endpoint.Targets
+
+// ResolveGatewayRoutes resolves the hostnames of the Routes and their targets like the Gateway
+// Route sources configured by config do, but against the given Gateways and Namespaces instead of
+// the resources watched by informers, so that the resolution can be embedded and tested on its own.
+//
+// The Routes must be *v1.HTTPRoute, *v1beta1.HTTPRoute, *v1.GRPCRoute, *v1alpha2.TLSRoute,
+// *v1alpha2.TCPRoute, or *v1alpha2.UDPRoute objects. Options that look up further resources, such
+// as certificate hostnames, backend resolution, Nodes, and GatewayClass parameters, have no effect,
+// and no ReferenceGrants exist. The targets of hostnames shared by several Routes are combined.
+func ResolveGatewayRoutes(config *Config, routes []runtime.Object, gateways []*v1beta1.Gateway, namespaces []*corev1.Namespace) (GatewayHostTargets, error) {
+	resolvers := make(map[string]*gatewayRouteResolver)
+	hostTargets := make(GatewayHostTargets)
+	for _, obj := range routes {
+		rt, kind, err := gwRouteOf(obj)
+		if err != nil {
+			return nil, err
+		}
+		c, ok := resolvers[kind]
+		if !ok {
+			src, err := newGatewayRouteSourceFromConfig(config, kind)
+			if err != nil {
+				return nil, err
+			}
+			c = newGatewayRouteResolver(src, gatewayListenersCacheView{}, src.selectGateways(gateways), namespaces, nil, nil, nil)
+			resolvers[kind] = c
+		}
+		if !c.src.selectsRoute(rt) || c.src.filterReason(rt) != "" {
+			continue
+		}
+		hosts, err := c.resolve(context.Background(), rt)
+		if err != nil {
+			return nil, err
+		}
+		for host, h := range hosts {
+			if hostTargets[host] == nil {
+				hostTargets[host] = make(map[string]endpoint.Targets)
+			}
+			for recordType, tgts := range h.targets {
+				hostTargets[host][recordType] = uniqueTargets(append(hostTargets[host][recordType], tgts...))
+			}
+		}
+	}
+	return hostTargets, nil
+}
+
+// selectGateways returns the Gateways in the namespace and with the labels of the source's
+// Gateway filters, like its Gateway informer lists them.
+func (src *gatewayRouteSource) selectGateways(gateways []*v1beta1.Gateway) []*v1beta1.Gateway {
+	return slices.DeleteFunc(slices.Clone(gateways), func(gw *v1beta1.Gateway) bool {
+		return (src.gwNamespace != "" && gw.Namespace != src.gwNamespace) || !src.gwLabels.Matches(labels.Set(gw.Labels))
+	})
+}
+
+// selectsRoute returns whether the Route is in the namespace and has the labels and fields of the
+// source's Route filters, like its Route informer lists them.
+func (src *gatewayRouteSource) selectsRoute(rt gatewayRoute) bool {
+	meta := rt.Metadata()
+	return (src.rtNamespace == "" || meta.Namespace == src.rtNamespace) && src.rtLabels.Matches(labels.Set(meta.Labels)) &&
+		src.rtFields.Matches(fields.Set{"metadata.name": meta.Name, "metadata.namespace": meta.Namespace})
+}
+
+// gwRouteOf returns the Route of the object along with its kind.
+func gwRouteOf(obj runtime.Object) (gatewayRoute, string, error) {
+	switch rt := obj.(type) {
+	case *v1.HTTPRoute:
+		return &gatewayHTTPRoute{*rt}, "HTTPRoute", nil
+	case *v1beta1.HTTPRoute:
+		return &gatewayHTTPRoute{v1.HTTPRoute(*rt)}, "HTTPRoute", nil
+	case *v1.GRPCRoute:
+		return &gatewayGRPCRoute{*rt}, "GRPCRoute", nil
+	case *v1alpha2.TLSRoute:
+		return &gatewayTLSRoute{*rt}, "TLSRoute", nil
+	case *v1alpha2.TCPRoute:
+		return &gatewayTCPRoute{*rt}, "TCPRoute", nil
+	case *v1alpha2.UDPRoute:
+		return &gatewayUDPRoute{*rt}, "UDPRoute", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported Gateway API Route %T", obj)
+	}
+}
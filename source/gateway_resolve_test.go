@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+func TestResolveGatewayRoutes(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		FQDNTemplate:       "{{.Name}}.tcp.internal",
+		GatewayLabelFilter: "app=dns",
+	}
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: objectMeta("", "default")},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team", Labels: map[string]string{"team": "a"}}},
+	}
+	fromSelector := v1.NamespacesFromSelector
+	gateways := []*v1beta1.Gateway{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "dns"}},
+			Spec: v1.GatewaySpec{
+				Listeners: []v1.Listener{
+					{Name: "http", Protocol: v1.HTTPProtocolType, Port: 80, Hostname: hostnamePtr("*.web.internal")},
+					{
+						Name:     "team",
+						Protocol: v1.HTTPProtocolType,
+						Port:     80,
+						Hostname: hostnamePtr("*.team.internal"),
+						AllowedRoutes: &v1.AllowedRoutes{Namespaces: &v1.RouteNamespaces{
+							From:     &fromSelector,
+							Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+						}},
+					},
+				},
+			},
+			Status: gatewayStatus("1.2.3.4", "2001:db8::1"),
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "tcp", Namespace: "default", Labels: map[string]string{"app": "dns"}},
+			Spec: v1.GatewaySpec{
+				Listeners: []v1.Listener{{Name: "tcp", Protocol: v1.TCPProtocolType, Port: 5432}},
+			},
+			Status: gatewayStatus("lb.tcp.internal"),
+		},
+		{
+			// Excluded by the Gateway label filter.
+			ObjectMeta: objectMeta("default", "unlabeled"),
+			Spec: v1.GatewaySpec{
+				Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+			},
+			Status: gatewayStatus("5.6.7.8"),
+		},
+	}
+	routes := []runtime.Object{
+		&v1beta1.HTTPRoute{
+			ObjectMeta: objectMeta("default", "app"),
+			Spec: v1.HTTPRouteSpec{
+				Hostnames:       []v1.Hostname{"app.web.internal", "app.team.internal"},
+				CommonRouteSpec: v1.CommonRouteSpec{ParentRefs: []v1.ParentReference{gwParentRef("default", "web")}},
+			},
+			Status: httpRouteStatus(gwParentRef("default", "web")),
+		},
+		&v1beta1.HTTPRoute{
+			ObjectMeta: objectMeta("team", "team"),
+			Spec: v1.HTTPRouteSpec{
+				Hostnames:       []v1.Hostname{"app.team.internal"},
+				CommonRouteSpec: v1.CommonRouteSpec{ParentRefs: []v1.ParentReference{gwParentRef("default", "web")}},
+			},
+			Status: httpRouteStatus(gwParentRef("default", "web")),
+		},
+		&v1beta1.HTTPRoute{
+			ObjectMeta: objectMeta("default", "unlabeled"),
+			Spec: v1.HTTPRouteSpec{
+				Hostnames:       []v1.Hostname{"unlabeled.web.internal"},
+				CommonRouteSpec: v1.CommonRouteSpec{ParentRefs: []v1.ParentReference{gwParentRef("default", "unlabeled")}},
+			},
+			Status: httpRouteStatus(gwParentRef("default", "unlabeled")),
+		},
+		&v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "ignored",
+				Namespace:   "default",
+				Annotations: map[string]string{annotations.IgnoreKey: "true"},
+			},
+			Spec: v1.HTTPRouteSpec{
+				Hostnames:       []v1.Hostname{"ignored.web.internal"},
+				CommonRouteSpec: v1.CommonRouteSpec{ParentRefs: []v1.ParentReference{gwParentRef("default", "web")}},
+			},
+			Status: httpRouteStatus(gwParentRef("default", "web")),
+		},
+		&v1alpha2.TCPRoute{
+			ObjectMeta: objectMeta("default", "db"),
+			Spec: v1alpha2.TCPRouteSpec{
+				CommonRouteSpec: v1.CommonRouteSpec{ParentRefs: []v1.ParentReference{gwParentRef("default", "tcp")}},
+			},
+			Status: v1alpha2.TCPRouteStatus{RouteStatus: gwRouteStatus(gwParentRef("default", "tcp"))},
+		},
+	}
+
+	hostTargets, err := ResolveGatewayRoutes(config, routes, gateways, namespaces)
+	require.NoError(t, err, "failed to resolve Routes")
+	require.Equal(t, GatewayHostTargets{
+		"app.web.internal": {
+			endpoint.RecordTypeA:    {"1.2.3.4"},
+			endpoint.RecordTypeAAAA: {"2001:db8::1"},
+		},
+		"app.team.internal": {
+			endpoint.RecordTypeA:    {"1.2.3.4"},
+			endpoint.RecordTypeAAAA: {"2001:db8::1"},
+		},
+		"db.tcp.internal": {
+			endpoint.RecordTypeCNAME: {"lb.tcp.internal"},
+		},
+	}, hostTargets)
+
+	// The sources publish the same hosts and targets.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gwClient := gatewayfake.NewSimpleClientset()
+	kubeClient := kubefake.NewSimpleClientset()
+	for _, ns := range namespaces {
+		_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create Namespace")
+	}
+	for _, gw := range gateways {
+		_, err := gwClient.GatewayV1beta1().Gateways(gw.Namespace).Create(ctx, gw, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create Gateway")
+	}
+	for _, obj := range routes {
+		switch rt := obj.(type) {
+		case *v1beta1.HTTPRoute:
+			_, err = gwClient.GatewayV1beta1().HTTPRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
+		case *v1alpha2.TCPRoute:
+			_, err = gwClient.GatewayV1alpha2().TCPRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
+		}
+		require.NoError(t, err, "failed to create Route")
+	}
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubeClient, nil)
+	published := make(GatewayHostTargets)
+	for _, newSource := range []func(context.Context, ClientGenerator, *Config) (Source, error){NewGatewayHTTPRouteSource, NewGatewayTCPRouteSource} {
+		src, err := newSource(ctx, clients, config)
+		require.NoError(t, err, "failed to create Gateway Route Source")
+		endpoints, err := src.Endpoints(ctx)
+		require.NoError(t, err, "failed to get Endpoints")
+		for _, ep := range endpoints {
+			if published[ep.DNSName] == nil {
+				published[ep.DNSName] = make(map[string]endpoint.Targets)
+			}
+			targets := slices.Clone(ep.Targets)
+			slices.Sort(targets)
+			published[ep.DNSName][ep.RecordType] = targets
+		}
+	}
+	require.Equal(t, hostTargets, published, "expected the sources to publish the resolved hosts and targets")
+}
+
+func TestResolveGatewayRoutesUnsupportedRoute(t *testing.T) {
+	t.Parallel()
+
+	_, err := ResolveGatewayRoutes(&Config{}, []runtime.Object{&corev1.Service{}}, nil, nil)
+	require.ErrorContains(t, err, "unsupported Gateway API Route *v1.Service")
+}
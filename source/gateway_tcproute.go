@@ -17,6 +17,8 @@ limitations under the License.
 package source
 
 import (
+	"context"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -26,8 +28,8 @@ import (
 )
 
 // NewGatewayTCPRouteSource creates a new Gateway TCPRoute source with the given config.
-func NewGatewayTCPRouteSource(clients ClientGenerator, config *Config) (Source, error) {
-	return newGatewayRouteSource(clients, config, "TCPRoute", func(factory informers.SharedInformerFactory) gatewayRouteInformer {
+func NewGatewayTCPRouteSource(ctx context.Context, clients ClientGenerator, config *Config) (Source, error) {
+	return newGatewayRouteSource(ctx, clients, config, "TCPRoute", func(factory informers.SharedInformerFactory) gatewayRouteInformer {
 		return &gatewayTCPRouteInformer{factory.Gateway().V1alpha2().TCPRoutes()}
 	})
 }
@@ -41,6 +43,26 @@ func (rt *gatewayTCPRoute) ParentRefs() []v1.ParentReference { return rt.route.S
 func (rt *gatewayTCPRoute) Protocol() v1.ProtocolType        { return v1.TCPProtocolType }
 func (rt *gatewayTCPRoute) RouteStatus() v1.RouteStatus      { return rt.route.Status.RouteStatus }
 
+// gatewayTCPRouteTemplate extends a TCPRoute with the Port and Protocol of a Listener it's
+// matched to, for the FQDN template.
+type gatewayTCPRouteTemplate struct {
+	*v1alpha2.TCPRoute
+	Port     v1.PortNumber
+	Protocol string
+}
+
+func (rt *gatewayTCPRoute) listenerTemplateObject(lis *v1.Listener) kubeObject {
+	return &gatewayTCPRouteTemplate{TCPRoute: &rt.route, Port: lis.Port, Protocol: string(lis.Protocol)}
+}
+
+func (rt *gatewayTCPRoute) BackendRefs() []v1.BackendRef {
+	var refs []v1.BackendRef
+	for _, rule := range rt.route.Spec.Rules {
+		refs = append(refs, rule.BackendRefs...)
+	}
+	return refs
+}
+
 type gatewayTCPRouteInformer struct {
 	informers_v1a2.TCPRouteInformer
 }
@@ -87,7 +87,7 @@ func TestGatewayTCPRouteSourceEndpoints(t *testing.T) {
 	_, err = gwClient.GatewayV1alpha2().TCPRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
 	require.NoError(t, err, "failed to create TCPRoute")
 
-	src, err := NewGatewayTCPRouteSource(clients, &Config{
+	src, err := NewGatewayTCPRouteSource(ctx, clients, &Config{
 		FQDNTemplate:             "{{.Name}}-template.foobar.internal",
 		CombineFQDNAndAnnotation: true,
 	})
@@ -99,4 +99,182 @@ func TestGatewayTCPRouteSourceEndpoints(t *testing.T) {
 		newTestEndpoint("api-annotation.foobar.internal", "A", ips...),
 		newTestEndpoint("api-template.foobar.internal", "A", ips...),
 	})
+
+	// Only the hostname annotation is used if the template is ignored for TCPRoutes.
+	src, err = NewGatewayTCPRouteSource(ctx, clients, &Config{
+		FQDNTemplate:                "{{.Name}}-template.foobar.internal",
+		CombineFQDNAndAnnotation:    true,
+		GatewayIgnoreL4FQDNTemplate: true,
+	})
+	require.NoError(t, err, "failed to create Gateway TCPRoute Source")
+
+	endpoints, err = src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("api-annotation.foobar.internal", "A", ips...),
+	})
+}
+
+func TestGatewayTCPRouteSourceListenerTemplate(t *testing.T) {
+	t.Parallel()
+
+	gwClient := gatewayfake.NewSimpleClientset()
+	kubeClient := kubefake.NewSimpleClientset()
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubeClient, nil)
+
+	ctx := context.Background()
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+		},
+	}
+	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Namespace")
+
+	ips := []string{"10.64.0.1", "10.64.0.2"}
+	gw := &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "internal",
+			Namespace: "default",
+		},
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{
+				{Name: "postgres", Protocol: v1.TCPProtocolType, Port: 5432},
+				{Name: "redis", Protocol: v1.TCPProtocolType, Port: 6379},
+			},
+		},
+		Status: gatewayStatus(ips...),
+	}
+	_, err = gwClient.GatewayV1beta1().Gateways(gw.Namespace).Create(ctx, gw, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Gateway")
+
+	rt := &v1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "default",
+		},
+		Spec: v1alpha2.TCPRouteSpec{
+			CommonRouteSpec: v1.CommonRouteSpec{
+				ParentRefs: []v1.ParentReference{
+					gwParentRef("default", "internal"),
+				},
+			},
+		},
+		Status: v1alpha2.TCPRouteStatus{
+			RouteStatus: gwRouteStatus(gwParentRef("default", "internal")),
+		},
+	}
+	_, err = gwClient.GatewayV1alpha2().TCPRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create TCPRoute")
+
+	src, err := NewGatewayTCPRouteSource(ctx, clients, &Config{
+		FQDNTemplate: "{{ .Name }}-{{ .Port }}-{{ .Protocol | toLower }}.foobar.internal",
+	})
+	require.NoError(t, err, "failed to create Gateway TCPRoute Source")
+
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("db-5432-tcp.foobar.internal", "A", ips...),
+		newTestEndpoint("db-6379-tcp.foobar.internal", "A", ips...),
+	})
+}
+
+func TestGatewayTCPRouteSourceStrictHostnames(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gwClient := gatewayfake.NewSimpleClientset()
+	_, err := gwClient.GatewayV1beta1().Gateways("default").Create(ctx, &v1beta1.Gateway{
+		ObjectMeta: objectMeta("default", "test"),
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{{Protocol: v1.TCPProtocolType, Hostname: hostnamePtr("*.example.internal")}},
+		},
+		Status: gatewayStatus("1.2.3.4"),
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Gateway")
+	_, err = gwClient.GatewayV1alpha2().TCPRoutes("default").Create(ctx, &v1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "default",
+			Annotations: map[string]string{hostnameAnnotationKey: "annotation.example.internal"},
+		},
+		Spec: v1alpha2.TCPRouteSpec{
+			CommonRouteSpec: v1.CommonRouteSpec{
+				ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+			},
+		},
+		Status: v1alpha2.TCPRouteStatus{
+			RouteStatus: gwRouteStatus(gwParentRef("default", "test")),
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create TCPRoute")
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubefake.NewSimpleClientset(), nil)
+
+	// TCPRoutes can't have hostnames in their spec, so they still adopt those of their Listeners.
+	src, err := NewGatewayTCPRouteSource(ctx, clients, &Config{GatewayStrictHostnames: true})
+	require.NoError(t, err, "failed to create Gateway TCPRoute Source")
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("*.example.internal", "A", "1.2.3.4"),
+		newTestEndpoint("annotation.example.internal", "A", "1.2.3.4"),
+	})
+}
+
+func TestGatewayTCPRouteSourceListenerRecordTypes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gwClient := gatewayfake.NewSimpleClientset()
+	_, err := gwClient.GatewayV1beta1().Gateways("default").Create(ctx, &v1beta1.Gateway{
+		ObjectMeta: objectMeta("default", "test"),
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{
+				{Name: "ldap", Protocol: v1.TCPProtocolType, Port: 389},
+				{Name: "other", Protocol: v1.TCPProtocolType, Port: 9000},
+			},
+		},
+		Status: gatewayStatus("10.0.0.1"),
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Gateway")
+	for _, section := range []v1.SectionName{"ldap", "other"} {
+		ref := gwParentRef("default", "test", withSectionName(section))
+		_, err = gwClient.GatewayV1alpha2().TCPRoutes("default").Create(ctx, &v1alpha2.TCPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        string(section),
+				Namespace:   "default",
+				Annotations: map[string]string{hostnameAnnotationKey: string(section) + ".example.internal"},
+			},
+			Spec: v1alpha2.TCPRouteSpec{
+				CommonRouteSpec: v1.CommonRouteSpec{
+					ParentRefs: []v1.ParentReference{ref},
+				},
+			},
+			Status: v1alpha2.TCPRouteStatus{
+				RouteStatus: gwRouteStatus(ref),
+			},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create TCPRoute")
+	}
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubefake.NewSimpleClientset(), nil)
+
+	// Only the hosts matched on the named Listener get SRV records.
+	src, err := NewGatewayTCPRouteSource(ctx, clients, &Config{GatewayListenerRecordTypes: []string{"tcp/ldap=SRV"}})
+	require.NoError(t, err, "failed to create Gateway TCPRoute Source")
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("ldap.example.internal", "A", "10.0.0.1"),
+		newTestEndpoint("_ldap._tcp.ldap.example.internal", "SRV", "0 0 389 ldap.example.internal"),
+		newTestEndpoint("other.example.internal", "A", "10.0.0.1"),
+	})
 }
@@ -17,18 +17,32 @@ limitations under the License.
 package source
 
 import (
+	"context"
+	"fmt"
+	"net/netip"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
+	v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 func TestGatewayMatchingHost(t *testing.T) {
 	tests := []struct {
-		desc string
-		a, b string
-		host string
-		ok   bool
+		desc       string
+		a, b       string
+		multiLabel bool
+		host       string
+		ok         bool
 	}{
 		{
 			desc: "ipv4-rejected",
@@ -72,11 +86,39 @@ func TestGatewayMatchingHost(t *testing.T) {
 			ok:   true,
 		},
 		{
-			desc: "wildcard-matches-multiple-subdomains",
+			desc: "wildcard-doesnt-match-multiple-subdomains",
 			a:    "*.example.net",
 			b:    "foo.bar.test.example.net",
-			host: "foo.bar.test.example.net",
-			ok:   true,
+			ok:   false,
+		},
+		{
+			desc:       "multi-label-wildcard-matches-multiple-subdomains",
+			a:          "*.example.net",
+			b:          "foo.bar.test.example.net",
+			multiLabel: true,
+			host:       "foo.bar.test.example.net",
+			ok:         true,
+		},
+		{
+			desc: "wildcard-doesnt-match-nested-wildcard",
+			a:    "*.example.net",
+			b:    "*.test.example.net",
+			ok:   false,
+		},
+		{
+			desc:       "multi-label-wildcard-matches-nested-wildcard",
+			a:          "*.example.net",
+			b:          "*.test.example.net",
+			multiLabel: true,
+			host:       "*.test.example.net",
+			ok:         true,
+		},
+		{
+			desc:       "multi-label-wildcard-doesnt-match-parent",
+			a:          "*.example.net",
+			b:          "example.net",
+			multiLabel: true,
+			ok:         false,
 		},
 		{
 			desc: "wildcard-doesnt-match-parent",
@@ -94,10 +136,10 @@ func TestGatewayMatchingHost(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
 			for i := 0; i < 2; i++ {
-				if host, ok := gwMatchingHost(tt.a, tt.b); host != tt.host || ok != tt.ok {
+				if host, ok := gwMatchingHost(tt.a, tt.b, tt.multiLabel); host != tt.host || ok != tt.ok {
 					t.Errorf(
-						"gwMatchingHost(%q, %q); got: %q, %v; want: %q, %v",
-						tt.a, tt.b, host, ok, tt.host, tt.ok,
+						"gwMatchingHost(%q, %q, %v); got: %q, %v; want: %q, %v",
+						tt.a, tt.b, tt.multiLabel, host, ok, tt.host, tt.ok,
 					)
 				}
 				tt.a, tt.b = tt.b, tt.a
@@ -161,6 +203,477 @@ func TestGatewayMatchingProtocol(t *testing.T) {
 	}
 }
 
+func TestGatewayListenerProtocolMatches(t *testing.T) {
+	t.Parallel()
+
+	passthrough, terminate := v1.TLSModePassthrough, v1.TLSModeTerminate
+	tests := []struct {
+		desc  string
+		route v1.ProtocolType
+		mode  *v1.TLSModeType
+		ok    bool
+	}{
+		{desc: "tls-route-passthrough", route: v1.TLSProtocolType, mode: &passthrough, ok: true},
+		{desc: "tls-route-terminate", route: v1.TLSProtocolType, mode: &terminate, ok: true},
+		{desc: "tcp-route-passthrough", route: v1.TCPProtocolType, mode: &passthrough, ok: false},
+		{desc: "tcp-route-terminate", route: v1.TCPProtocolType, mode: &terminate, ok: true},
+		{desc: "tcp-route-default-mode", route: v1.TCPProtocolType, ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			lis := &v1.Listener{
+				Protocol: v1.TLSProtocolType,
+				TLS:      &v1.GatewayTLSConfig{Mode: tt.mode},
+			}
+			require.Equal(t, tt.ok, gwListenerProtocolMatches(tt.route, lis, false))
+		})
+	}
+}
+
+func TestGatewayListenerProtocolMatchesStrict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		route v1.ProtocolType
+		lis   v1.ProtocolType
+		ok    bool
+	}{
+		{route: v1.HTTPProtocolType, lis: v1.HTTPProtocolType, ok: true},
+		{route: v1.HTTPProtocolType, lis: v1.HTTPSProtocolType, ok: false},
+		{route: v1.HTTPSProtocolType, lis: v1.HTTPProtocolType, ok: false},
+		{route: v1.HTTPSProtocolType, lis: v1.HTTPSProtocolType, ok: true},
+		{route: v1.TCPProtocolType, lis: v1.TLSProtocolType, ok: false},
+		{route: v1.TLSProtocolType, lis: v1.TLSProtocolType, ok: true},
+	}
+	for _, tt := range tests {
+		lis := &v1.Listener{Protocol: tt.lis}
+		require.Equal(t, tt.ok, gwListenerProtocolMatches(tt.route, lis, true), "route %s, listener %s", tt.route, tt.lis)
+	}
+}
+
+func TestGatewayRouteKindAllowed(t *testing.T) {
+	t.Parallel()
+
+	customGroup := v1.Group("routes.example.internal")
+	coreGroup := v1.Group("")
+	tests := []struct {
+		desc  string
+		kinds []v1.RouteGroupKind
+		route schema.GroupKind
+		ok    bool
+	}{
+		{
+			desc:  "default-group",
+			kinds: []v1.RouteGroupKind{{Kind: "HTTPRoute"}},
+			route: schema.GroupKind{Group: gatewayGroup, Kind: "HTTPRoute"},
+			ok:    true,
+		},
+		{
+			desc:  "other-kind",
+			kinds: []v1.RouteGroupKind{{Kind: "GRPCRoute"}},
+			route: schema.GroupKind{Group: gatewayGroup, Kind: "HTTPRoute"},
+			ok:    false,
+		},
+		{
+			desc:  "custom-group",
+			kinds: []v1.RouteGroupKind{{Group: &customGroup, Kind: "HTTPRoute"}},
+			route: schema.GroupKind{Group: string(customGroup), Kind: "HTTPRoute"},
+			ok:    true,
+		},
+		{
+			desc:  "custom-group-not-allowed",
+			kinds: []v1.RouteGroupKind{{Kind: "HTTPRoute"}},
+			route: schema.GroupKind{Group: string(customGroup), Kind: "HTTPRoute"},
+			ok:    false,
+		},
+		{
+			desc:  "custom-group-other-route",
+			kinds: []v1.RouteGroupKind{{Group: &customGroup, Kind: "HTTPRoute"}},
+			route: schema.GroupKind{Group: gatewayGroup, Kind: "HTTPRoute"},
+			ok:    false,
+		},
+		{
+			desc:  "core-group",
+			kinds: []v1.RouteGroupKind{{Group: &coreGroup, Kind: "CustomRoute"}},
+			route: schema.GroupKind{Kind: "CustomRoute"},
+			ok:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			require.Equal(t, tt.ok, gwRouteKindAllowed(tt.kinds, tt.route))
+		})
+	}
+}
+
+func TestGatewayRouteGroupKind(t *testing.T) {
+	t.Parallel()
+
+	src := &gatewayRouteSource{rtKind: "CustomRoute"}
+	rt := &gatewayHTTPRoute{}
+	require.Equal(t, schema.GroupKind{Group: gatewayGroup, Kind: "CustomRoute"}, src.routeGroupKind(rt))
+
+	rt.route.TypeMeta = metav1.TypeMeta{APIVersion: "routes.example.internal/v1", Kind: "CustomRoute"}
+	require.Equal(t, schema.GroupKind{Group: "routes.example.internal", Kind: "CustomRoute"}, src.routeGroupKind(rt))
+}
+
+func TestGatewayAddressRecordType(t *testing.T) {
+	ipType := v1.IPAddressType
+	hostnameType := v1.HostnameAddressType
+	customType := v1.AddressType("example.com/custom")
+	tests := []struct {
+		desc       string
+		addr       v1.GatewayStatusAddress
+		recordType string
+	}{
+		{
+			desc:       "ipv4",
+			addr:       v1.GatewayStatusAddress{Type: &ipType, Value: "1.2.3.4"},
+			recordType: endpoint.RecordTypeA,
+		},
+		{
+			desc:       "ipv6",
+			addr:       v1.GatewayStatusAddress{Type: &ipType, Value: "2001:db8::1"},
+			recordType: endpoint.RecordTypeAAAA,
+		},
+		{
+			desc:       "default-type-is-ip",
+			addr:       v1.GatewayStatusAddress{Value: "1.2.3.4"},
+			recordType: endpoint.RecordTypeA,
+		},
+		{
+			desc:       "hostname",
+			addr:       v1.GatewayStatusAddress{Type: &hostnameType, Value: "lb.example.net"},
+			recordType: endpoint.RecordTypeCNAME,
+		},
+		{
+			desc:       "custom-type-hostname",
+			addr:       v1.GatewayStatusAddress{Type: &customType, Value: "lb.example.net"},
+			recordType: endpoint.RecordTypeCNAME,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if recordType := gwAddressRecordType(tt.addr); recordType != tt.recordType {
+				t.Errorf("gwAddressRecordType(%v); got: %q; want: %q", tt.addr, recordType, tt.recordType)
+			}
+		})
+	}
+}
+
+func TestGatewayDebounce(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	handler := gwDebounce(context.Background(), func() { calls.Add(1) }, 50*time.Millisecond)
+	for i := 0; i < 10; i++ {
+		handler()
+	}
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Events after the window has elapsed trigger another call.
+	handler()
+	require.Eventually(t, func() bool { return calls.Load() == 2 }, time.Second, 10*time.Millisecond)
+
+	// Events are passed through if debouncing is disabled.
+	handler = gwDebounce(context.Background(), func() { calls.Add(1) }, 0)
+	handler()
+	require.Equal(t, int32(3), calls.Load())
+
+	// No calls are made once the context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	handler = gwDebounce(ctx, func() { calls.Add(1) }, 10*time.Millisecond)
+	handler()
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestGatewayNamespaceTTLs(t *testing.T) {
+	t.Parallel()
+
+	ttls, err := gatewayNamespaceTTLs(map[string]string{"team-a": "5m", "team-b": "90s"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]endpoint.TTL{"team-a": 300, "team-b": 90}, ttls)
+
+	for _, value := range []string{"300", "0s", "-1m", "500ms"} {
+		_, err := gatewayNamespaceTTLs(map[string]string{"team-a": value})
+		require.Error(t, err, "expected TTL %q to be invalid", value)
+	}
+}
+
+func TestGatewayTargetCIDRs(t *testing.T) {
+	t.Parallel()
+
+	cidrs, err := gatewayTargetCIDRs([]string{"10.0.0.0/8", "2001:db8::1/32"})
+	require.NoError(t, err)
+	require.Equal(t, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("2001:db8::/32")}, cidrs)
+
+	for _, value := range []string{"10.0.0.0", "10.0.0.0/33", "example.com/8"} {
+		_, err := gatewayTargetCIDRs([]string{value})
+		require.Error(t, err, "expected CIDR %q to be invalid", value)
+	}
+}
+
+func TestGatewayResourceTemplate(t *testing.T) {
+	t.Parallel()
+
+	_, err := newGatewayRouteSourceFromConfig(&Config{GatewayResourceTemplate: "{{ .Name "}, "HTTPRoute")
+	require.ErrorContains(t, err, "failed to parse resource template")
+
+	rt := &gatewayHTTPRoute{v1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{
+		Name:      "app",
+		Namespace: "default",
+		Labels:    map[string]string{"owner": "team-a"},
+	}}}
+	src, err := newGatewayRouteSourceFromConfig(&Config{}, "HTTPRoute")
+	require.NoError(t, err)
+	resource, err := src.renderResource(rt, "httproute/default/app")
+	require.NoError(t, err)
+	require.Equal(t, "httproute/default/app", resource, "expected the default resource without a template")
+
+	tests := []struct {
+		template string
+		want     string
+		wantErr  string
+	}{
+		{template: `{{ .Cluster }}/{{ toLower .Kind }}/{{ .Namespace }}/{{ .Name }}`, want: "east/httproute/default/app"},
+		{template: ` {{ index .Labels "owner" }} `, want: "team-a"},
+		{template: `{{ index .Labels "team" }}`, wantErr: `resource template rendered the invalid resource ""`},
+		{template: `{{ .Namespace }},{{ .Name }}`, wantErr: `resource template rendered the invalid resource "default,app"`},
+		{template: `{{ .Namespace }} {{ .Name }}`, wantErr: `resource template rendered the invalid resource "default app"`},
+		{template: `{{ .Route.Spec.Missing }}`, wantErr: "failed to apply resource template on HTTPRoute default/app"},
+	}
+	for _, tt := range tests {
+		src, err := newGatewayRouteSourceFromConfig(&Config{GatewayClusterName: "east", GatewayResourceTemplate: tt.template}, "HTTPRoute")
+		require.NoError(t, err, "template %q", tt.template)
+		resource, err := src.renderResource(rt, "httproute/default/app")
+		if tt.wantErr != "" {
+			require.ErrorContains(t, err, tt.wantErr, "template %q", tt.template)
+			continue
+		}
+		require.NoError(t, err, "template %q", tt.template)
+		require.Equal(t, tt.want, resource, "template %q", tt.template)
+	}
+}
+
+func TestGatewayHostHasSuffix(t *testing.T) {
+	t.Parallel()
+
+	suffixes := gatewayHostSuffixes([]string{"Internal.Example.com.", "*.example.net", ".example.org"})
+	require.Equal(t, []string{"internal.example.com", "example.net", "example.org"}, suffixes)
+
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"internal.example.com", true},
+		{"a.internal.example.com", true},
+		{"*.internal.example.com", true},
+		{"*.example.com", false},
+		{"external.example.com", false},
+		{"notinternal.example.com", false},
+		{"*.example.net", true},
+		{"a.example.org", true},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, gwHostHasSuffix(tt.host, suffixes), "host %q", tt.host)
+	}
+	require.True(t, gwHostHasSuffix("example.com", nil), "expected any host to be allowed without suffixes")
+}
+
+func TestGatewayHostError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"", ""},
+		{"app.example.com", ""},
+		{"*.Example.com.", ""},
+		{"1.2.3.4", `hostname "1.2.3.4" is an IP address`},
+		{"app_1.example.com", `hostname "app_1.example.com" has the invalid label "app_1"`},
+		{"app.*.example.com", `hostname "app.*.example.com" has the invalid label "*"`},
+		{"app..example.com", `hostname "app..example.com" has the invalid label ""`},
+		{"-app.example.com", `hostname "-app.example.com" has the invalid label "-app"`},
+		{strings.Repeat("a", 64) + ".example.com", fmt.Sprintf("hostname %q has the invalid label %q", strings.Repeat("a", 64)+".example.com", strings.Repeat("a", 64))},
+		{strings.Repeat("a.", 128) + "com", fmt.Sprintf("hostname %q is longer than 255 characters", strings.Repeat("a.", 128)+"com")},
+	}
+	for _, tt := range tests {
+		err := gwHostError(tt.host)
+		_, valid := gwHost(tt.host)
+		require.Equal(t, valid, err == nil, "expected gwHostError to agree with gwHost for %q", tt.host)
+		if tt.want == "" {
+			require.NoError(t, err, "host %q", tt.host)
+		} else {
+			require.EqualError(t, err, tt.want, "host %q", tt.host)
+		}
+	}
+}
+
+func TestGatewayNameMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		name    string
+		ok      bool
+	}{
+		{pattern: "", name: "edge", ok: true},
+		{pattern: "edge", name: "edge", ok: true},
+		{pattern: "edge", name: "edge-a", ok: false},
+		{pattern: "edge-*", name: "edge-a", ok: true},
+		{pattern: "edge-*", name: "edge", ok: false},
+		{pattern: "edge-?", name: "edge-ab", ok: false},
+		{pattern: "edge-[ab]", name: "edge-b", ok: true},
+		{pattern: "*-edge", name: "eu-edge", ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.name, func(t *testing.T) {
+			require.Equal(t, tt.ok, gatewayNameMatches(tt.pattern, tt.name))
+		})
+	}
+
+	require.NoError(t, validateGatewayName("edge-*"))
+	require.Error(t, validateGatewayName("edge-["))
+}
+
+func TestGatewayGeoProperties(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc             string
+		annotations      map[string]string
+		providerSpecific endpoint.ProviderSpecific
+		setIdentifier    string
+		provider         string
+		expected         endpoint.ProviderSpecific
+	}{
+		{
+			desc:          "none",
+			setIdentifier: "eu",
+		},
+		{
+			desc:          "webhook",
+			annotations:   map[string]string{annotations.GeoCountryKey: "US", annotations.GeoSubdivisionKey: "CA"},
+			setIdentifier: "us",
+			provider:      "webhook",
+			expected: endpoint.ProviderSpecific{
+				{Name: "webhook/geolocation-country-code", Value: "US"},
+				{Name: "webhook/geolocation-subdivision-code", Value: "CA"},
+			},
+		},
+		{
+			desc:          "continent",
+			annotations:   map[string]string{annotations.GeoContinentKey: "EU"},
+			setIdentifier: "eu",
+			expected:      endpoint.ProviderSpecific{{Name: "aws/geolocation-continent-code", Value: "EU"}},
+		},
+		{
+			desc:          "invalid-continent",
+			annotations:   map[string]string{annotations.GeoContinentKey: "Europe"},
+			setIdentifier: "eu",
+		},
+		{
+			desc: "country-and-subdivision",
+			annotations: map[string]string{
+				annotations.GeoCountryKey:     "US",
+				annotations.GeoSubdivisionKey: "CA",
+			},
+			setIdentifier: "us-ca",
+			expected: endpoint.ProviderSpecific{
+				{Name: "aws/geolocation-country-code", Value: "US"},
+				{Name: "aws/geolocation-subdivision-code", Value: "CA"},
+			},
+		},
+		{
+			desc:          "default-country",
+			annotations:   map[string]string{annotations.GeoCountryKey: "*"},
+			setIdentifier: "default",
+			expected:      endpoint.ProviderSpecific{{Name: "aws/geolocation-country-code", Value: "*"}},
+		},
+		{
+			desc:          "invalid-country",
+			annotations:   map[string]string{annotations.GeoCountryKey: "usa"},
+			setIdentifier: "us",
+		},
+		{
+			desc:          "subdivision-without-country",
+			annotations:   map[string]string{annotations.GeoSubdivisionKey: "CA"},
+			setIdentifier: "ca",
+		},
+		{
+			desc: "continent-and-country",
+			annotations: map[string]string{
+				annotations.GeoContinentKey: "EU",
+				annotations.GeoCountryKey:   "DE",
+			},
+			setIdentifier: "de",
+			expected:      endpoint.ProviderSpecific{{Name: "aws/geolocation-country-code", Value: "DE"}},
+		},
+		{
+			desc:             "provider-specific-annotation",
+			annotations:      map[string]string{annotations.GeoCountryKey: "DE"},
+			providerSpecific: endpoint.ProviderSpecific{{Name: "aws/geolocation-country-code", Value: "FR"}},
+			setIdentifier:    "fr",
+		},
+		{
+			desc:        "without-set-identifier",
+			annotations: map[string]string{annotations.GeoCountryKey: "DE"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			require.Equal(t, tt.expected, gwGeoProperties(tt.annotations, tt.providerSpecific, tt.setIdentifier, "httproute/default/test", gatewayRoutingPolicyFor(tt.provider)))
+		})
+	}
+}
+
+func TestGatewayListenersCache(t *testing.T) {
+	gw := &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test", ResourceVersion: "1"},
+	}
+	gwl := gatewayListeners{gateway: gw, ttl: 300}
+
+	c := newGatewayListenersCache()
+	c.view().put(gw, gwl)
+	got, ok := c.view().get(gw)
+	require.True(t, ok, "expected cached listeners")
+	require.Equal(t, gwl, got)
+
+	// Entries of changed Gateways are ignored.
+	updated := gw.DeepCopy()
+	updated.ResourceVersion = "2"
+	_, ok = c.view().get(updated)
+	require.False(t, ok, "expected listeners of updated Gateway to not be cached")
+
+	// Gateways without resource versions aren't cached.
+	unversioned := gw.DeepCopy()
+	unversioned.ResourceVersion = ""
+	c.view().put(unversioned, gwl)
+	_, ok = c.view().get(unversioned)
+	require.False(t, ok, "expected listeners of unversioned Gateway to not be cached")
+
+	// Entries of deleted Gateways are dropped.
+	c.onDelete(cache.DeletedFinalStateUnknown{Key: "default/test", Obj: gw})
+	_, ok = c.view().get(gw)
+	require.False(t, ok, "expected listeners of deleted Gateway to not be cached")
+
+	// Entries built before a reset are discarded.
+	v := c.view()
+	c.reset()
+	v.put(gw, gwl)
+	_, ok = c.view().get(gw)
+	require.False(t, ok, "expected listeners built before reset to not be cached")
+
+	// A nil cache disables caching.
+	var nilCache *gatewayListenersCache
+	nilCache.view().put(gw, gwl)
+	_, ok = nilCache.view().get(gw)
+	require.False(t, ok, "expected nil cache to not cache listeners")
+}
+
 func TestIsDNS1123Domain(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -245,3 +758,17 @@ func TestIsDNS1123Domain(t *testing.T) {
 		})
 	}
 }
+
+func TestGatewayListenerRecordTypes(t *testing.T) {
+	recordTypes, err := gatewayListenerRecordTypes([]string{"TCP/ldap=SRV", "udp/dns=srv", "TCP/ldap=SRV"})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{
+		"TCP/ldap": {"SRV"},
+		"UDP/dns":  {"SRV"},
+	}, recordTypes)
+
+	for _, value := range []string{"TCP/ldap", "ldap=SRV", "/ldap=SRV", "TCP/=SRV", "TCP/ldap=TXT"} {
+		_, err := gatewayListenerRecordTypes([]string{value})
+		require.Error(t, err, "expected %q to be invalid", value)
+	}
+}
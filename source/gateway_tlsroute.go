@@ -17,6 +17,8 @@ limitations under the License.
 package source
 
 import (
+	"context"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -26,8 +28,8 @@ import (
 )
 
 // NewGatewayTLSRouteSource creates a new Gateway TLSRoute source with the given config.
-func NewGatewayTLSRouteSource(clients ClientGenerator, config *Config) (Source, error) {
-	return newGatewayRouteSource(clients, config, "TLSRoute", func(factory informers.SharedInformerFactory) gatewayRouteInformer {
+func NewGatewayTLSRouteSource(ctx context.Context, clients ClientGenerator, config *Config) (Source, error) {
+	return newGatewayRouteSource(ctx, clients, config, "TLSRoute", func(factory informers.SharedInformerFactory) gatewayRouteInformer {
 		return &gatewayTLSRouteInformer{factory.Gateway().V1alpha2().TLSRoutes()}
 	})
 }
@@ -41,6 +43,14 @@ func (rt *gatewayTLSRoute) ParentRefs() []v1.ParentReference { return rt.route.S
 func (rt *gatewayTLSRoute) Protocol() v1.ProtocolType        { return v1.TLSProtocolType }
 func (rt *gatewayTLSRoute) RouteStatus() v1.RouteStatus      { return rt.route.Status.RouteStatus }
 
+func (rt *gatewayTLSRoute) BackendRefs() []v1.BackendRef {
+	var refs []v1.BackendRef
+	for _, rule := range rt.route.Spec.Rules {
+		refs = append(refs, rule.BackendRefs...)
+	}
+	return refs
+}
+
 type gatewayTLSRouteInformer struct {
 	informers_v1a2.TLSRouteInformer
 }
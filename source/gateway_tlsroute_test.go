@@ -88,7 +88,7 @@ func TestGatewayTLSRouteSourceEndpoints(t *testing.T) {
 	_, err = gwClient.GatewayV1alpha2().TLSRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
 	require.NoError(t, err, "failed to create TLSRoute")
 
-	src, err := NewGatewayTLSRouteSource(clients, &Config{
+	src, err := NewGatewayTLSRouteSource(ctx, clients, &Config{
 		FQDNTemplate:             "{{.Name}}-template.foobar.internal",
 		CombineFQDNAndAnnotation: true,
 	})
@@ -102,3 +102,75 @@ func TestGatewayTLSRouteSourceEndpoints(t *testing.T) {
 		newTestEndpoint("api-template.foobar.internal", "A", ips...),
 	})
 }
+
+func TestGatewayTLSRouteSourcePassthroughListener(t *testing.T) {
+	t.Parallel()
+
+	gwClient := gatewayfake.NewSimpleClientset()
+	kubeClient := kubefake.NewSimpleClientset()
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubeClient, nil)
+
+	ctx := context.Background()
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+		},
+	}
+	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Namespace")
+
+	passthrough := v1.TLSModePassthrough
+	gw := &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "passthrough",
+			Namespace: "default",
+		},
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{{
+				Name:     "passthrough",
+				Hostname: hostnamePtr("*.passthrough.internal"),
+				Protocol: v1.TLSProtocolType,
+				TLS:      &v1.GatewayTLSConfig{Mode: &passthrough},
+			}},
+		},
+		Status: gatewayStatus("10.64.0.1"),
+	}
+	_, err = gwClient.GatewayV1beta1().Gateways(gw.Namespace).Create(ctx, gw, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Gateway")
+
+	rt := &v1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sni",
+			Namespace: "default",
+		},
+		Spec: v1alpha2.TLSRouteSpec{
+			Hostnames: []v1.Hostname{
+				"api.passthrough.internal",
+				"web.passthrough.internal",
+				"other.internal",
+			},
+			CommonRouteSpec: v1.CommonRouteSpec{
+				ParentRefs: []v1.ParentReference{
+					gwParentRef("default", "passthrough"),
+				},
+			},
+		},
+		Status: v1alpha2.TLSRouteStatus{
+			RouteStatus: gwRouteStatus(gwParentRef("default", "passthrough")),
+		},
+	}
+	_, err = gwClient.GatewayV1alpha2().TLSRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create TLSRoute")
+
+	src, err := NewGatewayTLSRouteSource(ctx, clients, &Config{})
+	require.NoError(t, err, "failed to create Gateway TLSRoute Source")
+
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("api.passthrough.internal", "A", "10.64.0.1"),
+		newTestEndpoint("web.passthrough.internal", "A", "10.64.0.1"),
+	})
+}
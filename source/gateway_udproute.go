@@ -17,6 +17,8 @@ limitations under the License.
 package source
 
 import (
+	"context"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -26,8 +28,8 @@ import (
 )
 
 // NewGatewayUDPRouteSource creates a new Gateway UDPRoute source with the given config.
-func NewGatewayUDPRouteSource(clients ClientGenerator, config *Config) (Source, error) {
-	return newGatewayRouteSource(clients, config, "UDPRoute", func(factory informers.SharedInformerFactory) gatewayRouteInformer {
+func NewGatewayUDPRouteSource(ctx context.Context, clients ClientGenerator, config *Config) (Source, error) {
+	return newGatewayRouteSource(ctx, clients, config, "UDPRoute", func(factory informers.SharedInformerFactory) gatewayRouteInformer {
 		return &gatewayUDPRouteInformer{factory.Gateway().V1alpha2().UDPRoutes()}
 	})
 }
@@ -41,6 +43,26 @@ func (rt *gatewayUDPRoute) ParentRefs() []v1.ParentReference { return rt.route.S
 func (rt *gatewayUDPRoute) Protocol() v1.ProtocolType        { return v1.UDPProtocolType }
 func (rt *gatewayUDPRoute) RouteStatus() v1.RouteStatus      { return rt.route.Status.RouteStatus }
 
+// gatewayUDPRouteTemplate extends a UDPRoute with the Port and Protocol of a Listener it's
+// matched to, for the FQDN template.
+type gatewayUDPRouteTemplate struct {
+	*v1alpha2.UDPRoute
+	Port     v1.PortNumber
+	Protocol string
+}
+
+func (rt *gatewayUDPRoute) listenerTemplateObject(lis *v1.Listener) kubeObject {
+	return &gatewayUDPRouteTemplate{UDPRoute: &rt.route, Port: lis.Port, Protocol: string(lis.Protocol)}
+}
+
+func (rt *gatewayUDPRoute) BackendRefs() []v1.BackendRef {
+	var refs []v1.BackendRef
+	for _, rule := range rt.route.Spec.Rules {
+		refs = append(refs, rule.BackendRefs...)
+	}
+	return refs
+}
+
 type gatewayUDPRouteInformer struct {
 	informers_v1a2.UDPRouteInformer
 }
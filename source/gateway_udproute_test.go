@@ -87,7 +87,7 @@ func TestGatewayUDPRouteSourceEndpoints(t *testing.T) {
 	_, err = gwClient.GatewayV1alpha2().UDPRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
 	require.NoError(t, err, "failed to create UDPRoute")
 
-	src, err := NewGatewayUDPRouteSource(clients, &Config{
+	src, err := NewGatewayUDPRouteSource(ctx, clients, &Config{
 		FQDNTemplate:             "{{.Name}}-template.foobar.internal",
 		CombineFQDNAndAnnotation: true,
 	})
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"slices"
+
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// GatewayRouteValidator is implemented by the Gateway API Route sources. It reports how each
+// Route is resolved without creating any endpoints, e.g. to print a dry-run report.
+type GatewayRouteValidator interface {
+	Validate(ctx context.Context) ([]*GatewayRouteReport, error)
+}
+
+// GatewayRouteReport describes whether a Route produces records and, if it doesn't, why.
+type GatewayRouteReport struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Hosts are the resolved hostnames that produce records.
+	Hosts []string `json:"hosts,omitempty"`
+	// InvalidHosts explains why each of the Route's hostnames that were ignored is invalid.
+	InvalidHosts []string `json:"invalidHosts,omitempty"`
+	// DeniedHosts are the resolved hostnames that were dropped by the denied hostnames.
+	DeniedHosts []string `json:"deniedHosts,omitempty"`
+	// Reason explains why the Route produces no records.
+	Reason  string                 `json:"reason,omitempty"`
+	Parents []*GatewayParentReport `json:"parents,omitempty"`
+}
+
+// GatewayParentReport describes how a parent Gateway of a Route was matched.
+type GatewayParentReport struct {
+	Gateway     string `json:"gateway"`
+	SectionName string `json:"sectionName,omitempty"`
+	// Reason explains why the parent was skipped.
+	Reason    string                   `json:"reason,omitempty"`
+	Listeners []*GatewayListenerReport `json:"listeners,omitempty"`
+}
+
+// GatewayListenerReport describes how a Listener of a parent Gateway was matched.
+type GatewayListenerReport struct {
+	Name string `json:"name"`
+	// Hosts are the hostnames of the Route that overlap the Listener.
+	Hosts []string `json:"hosts,omitempty"`
+	// Reason explains why the Listener was skipped.
+	Reason string `json:"reason,omitempty"`
+}
+
+// gatewayRejecter records the reason for skipping a part of a validation report.
+// Its implementations accept nil receivers, which are used outside of validation.
+type gatewayRejecter interface {
+	reject(reason string)
+}
+
+func (r *GatewayRouteReport) reject(reason string) {
+	if r != nil {
+		r.Reason = reason
+	}
+}
+
+func (r *GatewayParentReport) reject(reason string) {
+	if r != nil {
+		r.Reason = reason
+	}
+}
+
+func (r *GatewayListenerReport) reject(reason string) {
+	if r != nil {
+		r.Reason = reason
+	}
+}
+
+// reportListener adds the report of a Listener to the parent's report, if validating.
+func (r *GatewayParentReport) reportListener(name v1.SectionName) *GatewayListenerReport {
+	if r == nil {
+		return nil
+	}
+	lr := &GatewayListenerReport{Name: string(name)}
+	r.Listeners = append(r.Listeners, lr)
+	return lr
+}
+
+func (r *GatewayListenerReport) addHost(host string) {
+	if r != nil && !slices.Contains(r.Hosts, host) {
+		r.Hosts = append(r.Hosts, host)
+	}
+}
+
+// reportParent adds the report of a parent to the Route's report, if validating.
+func (c *gatewayRouteResolver) reportParent(namespace string, ref v1.ParentReference) *GatewayParentReport {
+	if c.report == nil {
+		return nil
+	}
+	pr := &GatewayParentReport{
+		Gateway:     namespace + "/" + string(ref.Name),
+		SectionName: string(sectionVal(ref.SectionName, "")),
+	}
+	c.report.Parents = append(c.report.Parents, pr)
+	return pr
+}
+
+// Validate resolves all Routes like Endpoints does and reports the matched parents, Listeners,
+// and hosts of each Route, or the reasons why they were skipped.
+func (src *gatewayRouteSource) Validate(ctx context.Context) ([]*GatewayRouteReport, error) {
+	routes, resolver, err := src.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*GatewayRouteReport, 0, len(routes))
+	for _, rt := range routes {
+		// Stop early if the caller is no longer interested in the result.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		meta := rt.Metadata()
+		report := &GatewayRouteReport{
+			Kind:      src.rtKind,
+			Namespace: meta.Namespace,
+			Name:      meta.Name,
+		}
+		reports = append(reports, report)
+		if reason := src.filterReason(rt); reason != "" {
+			report.Reason = reason
+			continue
+		}
+
+		resolver.report = report
+		hostTargets, err := resolver.resolve(ctx, rt)
+		resolver.report = nil
+		if err != nil {
+			return nil, err
+		}
+		for host, h := range hostTargets {
+			if len(h.targets) > 0 {
+				report.Hosts = append(report.Hosts, host)
+			}
+		}
+		slices.Sort(report.Hosts)
+		if len(report.Hosts) == 0 && report.Reason == "" {
+			if len(hostTargets) > 0 {
+				report.Reason = gwSkipNoTargets
+			} else {
+				report.Reason = gwSkipNoMatchingParent
+			}
+		}
+	}
+	return reports, nil
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+	v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+)
+
+var _ GatewayRouteValidator = &gatewayRouteSource{}
+
+func TestGatewayHTTPRouteSourceValidate(t *testing.T) {
+	t.Parallel()
+
+	gateways := []*v1beta1.Gateway{{
+		ObjectMeta: objectMeta("default", "gateway"),
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{
+				{
+					Name:     "http",
+					Hostname: hostnamePtr("*.example.internal"),
+					Protocol: v1.HTTPProtocolType,
+				},
+				{
+					Name:     "tcp",
+					Protocol: v1.TCPProtocolType,
+				},
+			},
+		},
+		Status: gatewayStatus("1.2.3.4"),
+	}}
+	route := func(name, hostname string) *v1beta1.HTTPRoute {
+		ref := gwParentRef("default", "gateway")
+		return &v1beta1.HTTPRoute{
+			ObjectMeta: objectMeta("default", name),
+			Spec: v1.HTTPRouteSpec{
+				CommonRouteSpec: v1.CommonRouteSpec{ParentRefs: []v1.ParentReference{ref}},
+				Hostnames:       []v1.Hostname{v1.Hostname(hostname)},
+			},
+			Status: httpRouteStatus(ref),
+		}
+	}
+	notAccepted := route("not-accepted", "not-accepted.example.internal")
+	notAccepted.Status.Parents[0].Conditions[0].Status = metav1.ConditionFalse
+	otherController := route("other-controller", "other-controller.example.internal")
+	otherController.Annotations = map[string]string{controllerAnnotationKey: "other"}
+	routes := []*v1beta1.HTTPRoute{
+		route("published", "published.example.internal"),
+		route("no-matching-host", "no-matching-host.example.org"),
+		route("invalid-host", "invalid_label.example.internal"),
+		notAccepted,
+		otherController,
+	}
+
+	ctx := context.Background()
+	gwClient := gatewayfake.NewSimpleClientset()
+	for _, gw := range gateways {
+		_, err := gwClient.GatewayV1beta1().Gateways(gw.Namespace).Create(ctx, gw, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create Gateway")
+	}
+	for _, rt := range routes {
+		_, err := gwClient.GatewayV1beta1().HTTPRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create HTTPRoute")
+	}
+	kubeClient := kubefake.NewSimpleClientset()
+	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Namespace")
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubeClient, nil)
+
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+
+	reports, err := src.(GatewayRouteValidator).Validate(ctx)
+	require.NoError(t, err, "failed to validate Routes")
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Name < reports[j].Name })
+
+	listeners := func(httpHosts []string, httpReason string) []*GatewayListenerReport {
+		return []*GatewayListenerReport{
+			{Name: "http", Hosts: httpHosts, Reason: httpReason},
+			{Name: "tcp", Reason: gwSkipProtocolMismatch},
+		}
+	}
+	require.Equal(t, []*GatewayRouteReport{
+		{
+			Kind:         "HTTPRoute",
+			Namespace:    "default",
+			Name:         "invalid-host",
+			InvalidHosts: []string{`hostname "invalid_label.example.internal" has the invalid label "invalid_label"`},
+			Reason:       gwSkipNoMatchingParent,
+			Parents: []*GatewayParentReport{{
+				Gateway:   "default/gateway",
+				Reason:    gwSkipNoMatchingHost,
+				Listeners: listeners(nil, gwSkipNoMatchingHost),
+			}},
+		},
+		{
+			Kind:      "HTTPRoute",
+			Namespace: "default",
+			Name:      "no-matching-host",
+			Reason:    gwSkipNoMatchingParent,
+			Parents: []*GatewayParentReport{{
+				Gateway:   "default/gateway",
+				Reason:    gwSkipNoMatchingHost,
+				Listeners: listeners(nil, gwSkipNoMatchingHost),
+			}},
+		},
+		{
+			Kind:      "HTTPRoute",
+			Namespace: "default",
+			Name:      "not-accepted",
+			Reason:    gwSkipNoMatchingParent,
+			Parents: []*GatewayParentReport{{
+				Gateway: "default/gateway",
+				Reason:  gwSkipNotAccepted,
+			}},
+		},
+		{
+			Kind:      "HTTPRoute",
+			Namespace: "default",
+			Name:      "other-controller",
+			Reason:    gwSkipControllerMismatch,
+		},
+		{
+			Kind:      "HTTPRoute",
+			Namespace: "default",
+			Name:      "published",
+			Hosts:     []string{"published.example.internal"},
+			Parents: []*GatewayParentReport{{
+				Gateway:   "default/gateway",
+				Listeners: listeners([]string{"published.example.internal"}, ""),
+			}},
+		},
+	}, reports)
+
+	_, err = json.Marshal(reports)
+	require.NoError(t, err, "failed to serialize reports")
+}
+
+func TestGatewayHTTPRouteSourceValidateMissingSection(t *testing.T) {
+	ctx := context.Background()
+	gwClient := gatewayfake.NewSimpleClientset()
+	_, err := gwClient.GatewayV1beta1().Gateways("default").Create(ctx, &v1beta1.Gateway{
+		ObjectMeta: objectMeta("default", "gateway"),
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{{Name: "http", Protocol: v1.HTTPProtocolType}},
+		},
+		Status: gatewayStatus("1.2.3.4"),
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Gateway")
+	ref := gwParentRef("default", "gateway", withSectionName("htpp"))
+	rt := &v1beta1.HTTPRoute{
+		ObjectMeta: objectMeta("default", "typo"),
+		Spec: v1.HTTPRouteSpec{
+			CommonRouteSpec: v1.CommonRouteSpec{ParentRefs: []v1.ParentReference{ref}},
+			Hostnames:       []v1.Hostname{"typo.example.internal"},
+		},
+		Status: httpRouteStatus(ref),
+	}
+	rt.Status.Parents[0].Conditions[0].Status = metav1.ConditionFalse
+	rt.Status.Parents[0].Conditions[0].Reason = string(v1.RouteReasonNoMatchingParent)
+	_, err = gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, rt, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create HTTPRoute")
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubefake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}), nil)
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+
+	missingSections := func() float64 {
+		var m dto.Metric
+		require.NoError(t, gatewayMissingSectionsTotal.CounterVec.WithLabelValues("HTTPRoute").Write(&m))
+		return m.GetCounter().GetValue()
+	}
+	before := missingSections()
+	_, err = src.(GatewayRouteValidator).Validate(ctx)
+	require.NoError(t, err, "failed to validate Routes")
+	require.Equal(t, before, missingSections(), "validation must not count missing sections")
+
+	_, err = src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	require.Equal(t, before+1, missingSections(), "the missing section of the rejected Route must be counted")
+}
+
+func TestGatewayHTTPRouteSourceValidateDeniedHosts(t *testing.T) {
+	ctx := context.Background()
+	gwClient := gatewayfake.NewSimpleClientset()
+	_, err := gwClient.GatewayV1beta1().Gateways("default").Create(ctx, &v1beta1.Gateway{
+		ObjectMeta: objectMeta("default", "gateway"),
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{{Name: "http", Protocol: v1.HTTPProtocolType}},
+		},
+		Status: gatewayStatus("1.2.3.4"),
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Gateway")
+	ref := gwParentRef("default", "gateway")
+	_, err = gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, &v1beta1.HTTPRoute{
+		ObjectMeta: objectMeta("default", "tenant"),
+		Spec: v1.HTTPRouteSpec{
+			CommonRouteSpec: v1.CommonRouteSpec{ParentRefs: []v1.ParentReference{ref}},
+			Hostnames:       []v1.Hostname{"app.example.internal", "console.admin.example.internal"},
+		},
+		Status: httpRouteStatus(ref),
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create HTTPRoute")
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubefake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}), nil)
+	src, err := NewGatewayHTTPRouteSource(ctx, clients, &Config{
+		GatewayDeniedHostnames: []string{"*.admin.example.internal"},
+	})
+	require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+
+	deniedHosts := func() float64 {
+		var m dto.Metric
+		require.NoError(t, gatewayDeniedHostnamesTotal.CounterVec.WithLabelValues("HTTPRoute").Write(&m))
+		return m.GetCounter().GetValue()
+	}
+	before := deniedHosts()
+	reports, err := src.(GatewayRouteValidator).Validate(ctx)
+	require.NoError(t, err, "failed to validate Routes")
+	require.Len(t, reports, 1)
+	require.Equal(t, []string{"app.example.internal"}, reports[0].Hosts)
+	require.Equal(t, []string{"console.admin.example.internal"}, reports[0].DeniedHosts)
+	require.Equal(t, before, deniedHosts(), "validation must not count denied hosts")
+
+	_, err = src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	require.Equal(t, before+1, deniedHosts(), "the denied host must be counted")
+}
@@ -29,7 +29,7 @@ func sortEndpoints(endpoints []*endpoint.Endpoint) {
 		sort.Strings([]string(ep.Targets))
 	}
 	sort.Slice(endpoints, func(i, k int) bool {
-		// Sort by DNSName, RecordType, and Targets
+		// Sort by DNSName, RecordType, SetIdentifier, and Targets
 		ei, ek := endpoints[i], endpoints[k]
 		if ei.DNSName != ek.DNSName {
 			return ei.DNSName < ek.DNSName
@@ -37,6 +37,9 @@ func sortEndpoints(endpoints []*endpoint.Endpoint) {
 		if ei.RecordType != ek.RecordType {
 			return ei.RecordType < ek.RecordType
 		}
+		if ei.SetIdentifier != ek.SetIdentifier {
+			return ei.SetIdentifier < ek.SetIdentifier
+		}
 		// Targets are sorted ahead of time.
 		for j, ti := range ei.Targets {
 			if j >= len(ek.Targets) {
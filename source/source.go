@@ -71,6 +71,13 @@ func getLabelSelector(annotationFilter string) (labels.Selector, error) {
 	return metav1.LabelSelectorAsSelector(labelSelector)
 }
 
+// getAnnotationSelector parses an annotation filter with label selector semantics. Unlike
+// getLabelSelector, it doesn't round-trip through a metav1.LabelSelector, which can't express
+// the != operator, so that all operators of label selectors are supported.
+func getAnnotationSelector(annotationFilter string) (labels.Selector, error) {
+	return labels.Parse(annotationFilter)
+}
+
 func matchLabelSelector(selector labels.Selector, srcAnnotations map[string]string) bool {
 	return selector.Matches(labels.Set(srcAnnotations))
 }
@@ -56,6 +56,82 @@ func TestGetLabelSelector(t *testing.T) {
 	}
 }
 
+func TestGetAnnotationSelector(t *testing.T) {
+	annots := map[string]string{"example.com/team": "a", "example.com/tier": "gold"}
+	tests := []struct {
+		name             string
+		annotationFilter string
+		expectedMatch    bool
+	}{
+		{
+			name:             "Empty",
+			annotationFilter: "",
+			expectedMatch:    true,
+		},
+		{
+			name:             "Exists",
+			annotationFilter: "example.com/team",
+			expectedMatch:    true,
+		},
+		{
+			name:             "Exists missing",
+			annotationFilter: "example.com/owner",
+			expectedMatch:    false,
+		},
+		{
+			name:             "DoesNotExist",
+			annotationFilter: "!example.com/owner",
+			expectedMatch:    true,
+		},
+		{
+			name:             "DoesNotExist present",
+			annotationFilter: "!example.com/team",
+			expectedMatch:    false,
+		},
+		{
+			name:             "Equals",
+			annotationFilter: "example.com/team=a",
+			expectedMatch:    true,
+		},
+		{
+			name:             "DoubleEquals",
+			annotationFilter: "example.com/team==b",
+			expectedMatch:    false,
+		},
+		{
+			name:             "NotEquals",
+			annotationFilter: "example.com/team!=b",
+			expectedMatch:    true,
+		},
+		{
+			name:             "In",
+			annotationFilter: "example.com/tier in (gold, silver)",
+			expectedMatch:    true,
+		},
+		{
+			name:             "NotIn",
+			annotationFilter: "example.com/tier notin (gold)",
+			expectedMatch:    false,
+		},
+		{
+			name:             "Combined",
+			annotationFilter: "example.com/team, !example.com/owner, example.com/tier in (gold)",
+			expectedMatch:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := getAnnotationSelector(tt.annotationFilter)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedMatch, matchLabelSelector(selector, annots))
+		})
+	}
+
+	_, err := getAnnotationSelector("example.com/team=a b")
+	assert.Error(t, err)
+}
+
 func TestMatchLabelSelector(t *testing.T) {
 	tests := []struct {
 		name           string
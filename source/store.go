@@ -73,7 +73,59 @@ type Config struct {
 	ListenEndpointEvents           bool
 	GatewayName                    string
 	GatewayNamespace               string
+	GatewayNamespaceAnnotations    bool
+	GatewayNamespaceTTL            map[string]string
+	GatewayOnDemandNamespaces      bool
+	GatewayParentGroup             string
+	GatewayParentKind              string
+	GatewayParentMatching          string
+	GatewayParentlessRoutes        bool
+	GatewayFilterHostnames         bool
+	GatewayPreferredAddressType    string
+	GatewayLabelFallback           bool
+	GatewayBackendHostnames        bool
+	GatewayBackendZone             string
 	GatewayLabelFilter             string
+	GatewayListBackoff             time.Duration
+	GatewayListRetries             int
+	GatewayListenerRecordTypes     []string
+	GatewayMaxRouteEndpoints       int
+	GatewayClassFilter             string
+	GatewayClassParameters         bool
+	GatewayClusterName             string
+	GatewayControllerValue         string
+	GatewayCreatePTR               bool
+	GatewayMultiLabelWildcards     bool
+	GatewayEventDebounce           time.Duration
+	GatewayDeletionGracePeriod     time.Duration
+	GatewayDeniedHostnames         []string
+	GatewayFallbackTargets         []string
+	GatewayHostnameSuffixes        []string
+	GatewayIgnoreL4FQDNTemplate    bool
+	GatewayIncludeUnaccepted       bool
+	GatewayRequireReferenceGrant   bool
+	GatewayResolveConcurrency      int
+	GatewayRequireProgrammed       bool
+	GatewayRequireAttachedRoutes   bool
+	GatewayRequireBackends         bool
+	GatewayStrictHostnames         bool
+	GatewayStrictListenerPorts     bool
+	GatewayStrictProtocols         bool
+	GatewayCertificateHostnames    bool
+	GatewayResolveBackends         bool
+	GatewayResolveNodes            bool
+	GatewayResolveHostnameTargets  bool
+	GatewaySetIdentifierTemplate   string
+	GatewayTargetCIDRAllowlist     []string
+	GatewayResourceTemplate        string
+	GatewayRouteEvents             bool
+	GatewayRouteFieldSelector      string
+	GatewayRouteLabels             bool
+	GatewayRoutingPolicyProvider   string
+	GatewayWarnUnsuffixedHosts     bool
+	GatewayWeightedTargets         bool
+	GatewayWildcardApex            bool
+	GatewayTargetTransformer       GatewayTargetTransformer
 	Compatibility                  string
 	PodSourceDomain                string
 	PublishInternal                bool
@@ -119,7 +171,58 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		ListenEndpointEvents:           cfg.ListenEndpointEvents,
 		GatewayName:                    cfg.GatewayName,
 		GatewayNamespace:               cfg.GatewayNamespace,
+		GatewayNamespaceAnnotations:    cfg.GatewayNamespaceAnnotations,
+		GatewayNamespaceTTL:            cfg.GatewayNamespaceTTL,
+		GatewayOnDemandNamespaces:      cfg.GatewayOnDemandNamespaces,
+		GatewayParentGroup:             cfg.GatewayParentGroup,
+		GatewayParentKind:              cfg.GatewayParentKind,
+		GatewayParentMatching:          cfg.GatewayParentMatching,
+		GatewayParentlessRoutes:        cfg.GatewayParentlessRoutes,
+		GatewayFilterHostnames:         cfg.GatewayFilterHostnames,
+		GatewayPreferredAddressType:    cfg.GatewayPreferredAddressType,
+		GatewayLabelFallback:           cfg.GatewayLabelFallback,
+		GatewayBackendHostnames:        cfg.GatewayBackendHostnames,
+		GatewayBackendZone:             cfg.GatewayBackendZone,
 		GatewayLabelFilter:             cfg.GatewayLabelFilter,
+		GatewayListBackoff:             cfg.GatewayListBackoff,
+		GatewayListRetries:             cfg.GatewayListRetries,
+		GatewayListenerRecordTypes:     cfg.GatewayListenerRecordTypes,
+		GatewayMaxRouteEndpoints:       cfg.GatewayMaxRouteEndpoints,
+		GatewayResolveConcurrency:      cfg.GatewayResolveConcurrency,
+		GatewayClassFilter:             cfg.GatewayClassFilter,
+		GatewayClassParameters:         cfg.GatewayClassParameters,
+		GatewayClusterName:             cfg.GatewayClusterName,
+		GatewayControllerValue:         cfg.GatewayControllerValue,
+		GatewayCreatePTR:               cfg.GatewayCreatePTR,
+		GatewayMultiLabelWildcards:     cfg.GatewayMultiLabelWildcards,
+		GatewayEventDebounce:           cfg.GatewayEventDebounce,
+		GatewayDeletionGracePeriod:     cfg.GatewayDeletionGracePeriod,
+		GatewayDeniedHostnames:         cfg.GatewayDeniedHostnames,
+		GatewayFallbackTargets:         cfg.GatewayFallbackTargets,
+		GatewayHostnameSuffixes:        cfg.GatewayHostnameSuffixes,
+		GatewayIgnoreL4FQDNTemplate:    cfg.GatewayIgnoreL4FQDNTemplate,
+		GatewayIncludeUnaccepted:       cfg.GatewayIncludeUnaccepted,
+		GatewayRequireReferenceGrant:   cfg.GatewayRequireReferenceGrant,
+		GatewayRequireProgrammed:       cfg.GatewayRequireProgrammed,
+		GatewayRequireAttachedRoutes:   cfg.GatewayRequireAttachedRoutes,
+		GatewayRequireBackends:         cfg.GatewayRequireBackends,
+		GatewayStrictHostnames:         cfg.GatewayStrictHostnames,
+		GatewayStrictListenerPorts:     cfg.GatewayStrictListenerPorts,
+		GatewayStrictProtocols:         cfg.GatewayStrictProtocols,
+		GatewayCertificateHostnames:    cfg.GatewayCertificateHostnames,
+		GatewayResolveBackends:         cfg.GatewayResolveBackends,
+		GatewayResolveNodes:            cfg.GatewayResolveNodes,
+		GatewayResolveHostnameTargets:  cfg.GatewayResolveHostnameTargets,
+		GatewaySetIdentifierTemplate:   cfg.GatewaySetIdentifierTemplate,
+		GatewayTargetCIDRAllowlist:     cfg.GatewayTargetCIDRAllowlist,
+		GatewayResourceTemplate:        cfg.GatewayResourceTemplate,
+		GatewayRouteEvents:             cfg.GatewayRouteEvents,
+		GatewayRouteFieldSelector:      cfg.GatewayRouteFieldSelector,
+		GatewayRouteLabels:             cfg.GatewayRouteLabels,
+		GatewayRoutingPolicyProvider:   cfg.GatewayRoutingPolicyProvider,
+		GatewayWarnUnsuffixedHosts:     cfg.GatewayWarnUnsuffixedHosts,
+		GatewayWeightedTargets:         cfg.GatewayWeightedTargets,
+		GatewayWildcardApex:            cfg.GatewayWildcardApex,
 		Compatibility:                  cfg.Compatibility,
 		PodSourceDomain:                cfg.PodSourceDomain,
 		PublishInternal:                cfg.PublishInternal,
@@ -312,6 +415,7 @@ func ByNames(ctx context.Context, p ClientGenerator, names []string, cfg *Config
 // - "service": Kubernetes services
 // - "ingress": Kubernetes ingresses
 // - "pod": Kubernetes pods
+// - "gateway": Gateway API Gateway Listeners
 // - "gateway-*": Gateway API resources (httproute, grpcroute, tlsroute, tcproute, udproute)
 // - "istio-*": Istio resources (gateway, virtualservice)
 // - "cloudfoundry": CloudFoundry applications
@@ -339,16 +443,18 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		return buildIngressSource(ctx, p, cfg)
 	case "pod":
 		return buildPodSource(ctx, p, cfg)
+	case "gateway":
+		return NewGatewaySource(ctx, p, cfg)
 	case "gateway-httproute":
-		return NewGatewayHTTPRouteSource(p, cfg)
+		return NewGatewayHTTPRouteSource(ctx, p, cfg)
 	case "gateway-grpcroute":
-		return NewGatewayGRPCRouteSource(p, cfg)
+		return NewGatewayGRPCRouteSource(ctx, p, cfg)
 	case "gateway-tlsroute":
-		return NewGatewayTLSRouteSource(p, cfg)
+		return NewGatewayTLSRouteSource(ctx, p, cfg)
 	case "gateway-tcproute":
-		return NewGatewayTCPRouteSource(p, cfg)
+		return NewGatewayTCPRouteSource(ctx, p, cfg)
 	case "gateway-udproute":
-		return NewGatewayUDPRouteSource(p, cfg)
+		return NewGatewayUDPRouteSource(ctx, p, cfg)
 	case "istio-gateway":
 		return buildIstioGatewaySource(ctx, p, cfg)
 	case "istio-virtualservice":